@@ -1,10 +1,17 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 	"taskmanagementapi/pkg/config"
 	"taskmanagementapi/pkg/di"
+	"taskmanagementapi/pkg/logging"
+	"taskmanagementapi/pkg/tracing"
 )
 
 func main() {
@@ -13,14 +20,51 @@ func main() {
 		log.Fatal("cannot load config: ", configErr)
 	}
 
-	infoLog := log.New(os.Stdout, "INFO\t", log.Ldate|log.Ltime)
-	errorLog := log.New(os.Stderr, "ERROR\t", log.Ldate|log.Ltime|log.Lshortfile)
-	server, diErr := di.InitializeAPI(config)
+	logger, loggerErr := logging.New(os.Stdout, config.LogLevel)
+	if loggerErr != nil {
+		log.Fatal("cannot load config: ", loggerErr)
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, tracingErr := tracing.Setup(context.Background(), config)
+	if tracingErr != nil {
+		logger.Error("cannot set up tracing", "error", tracingErr)
+		os.Exit(1)
+	}
 
+	server, cleanup, diErr := di.InitializeAPI(context.Background(), config, logger)
 	if diErr != nil {
-		log.Fatal("cannot start server: ", diErr)
-	} else {
-		server.Start(infoLog, errorLog)
+		logger.Error("cannot start server", "error", diErr)
+		os.Exit(1)
 	}
 
+	signals, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.Start()
+	}()
+
+	exitCode := 0
+	select {
+	case err := <-serverErr:
+		logger.Error("server stopped", "error", err)
+		exitCode = 1
+	case <-signals.Done():
+		logger.Info("shutting down", "timeout", config.ShutdownTimeout.String())
+	}
+	// A second signal kills the process instead of waiting for the deadline.
+	stopSignals()
+
+	// Requests are drained first, since they need the workers and MongoDB,
+	// and spans are flushed last.
+	ctx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	shutdownErr := errors.Join(server.Shutdown(ctx), cleanup(ctx), shutdownTracing(ctx))
+	cancel()
+	if shutdownErr != nil {
+		logger.Error("shutdown incomplete", "error", shutdownErr)
+		exitCode = 1
+	} else {
+		logger.Info("shutdown complete")
+	}
+	os.Exit(exitCode)
 }
@@ -0,0 +1,189 @@
+package docs
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// generator derives schemas from Go types the same way encoding/json and the
+// validator read them, so the spec follows the models without being edited
+// by hand. Named structs become components and are referenced by $ref.
+type generator struct {
+	schemas map[string]*Schema
+}
+
+func newGenerator() *generator {
+	return &generator{schemas: make(map[string]*Schema)}
+}
+
+func (g *generator) schemaOf(v interface{}) *Schema {
+	return g.schema(reflect.TypeOf(v))
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		if _, ok := g.schemas[t.Name()]; !ok {
+			// Reserve the name first so self-referencing types terminate.
+			g.schemas[t.Name()] = &Schema{}
+			*g.schemas[t.Name()] = *g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	}
+	return &Schema{}
+}
+
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(s, t)
+	return s
+}
+
+func (g *generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := fieldName(field, "json")
+		if !ok {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(s, field.Type)
+			continue
+		}
+		prop := g.schema(field.Type)
+		if applyRules(prop, field.Type, field.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+}
+
+// parameters turns a struct with query tags into query parameters.
+func (g *generator) parameters(v interface{}) []Parameter {
+	t := reflect.TypeOf(v)
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := fieldName(field, "query")
+		if !ok {
+			continue
+		}
+		schema := g.schema(field.Type)
+		required := applyRules(schema, field.Type, field.Tag.Get("validate"))
+		params = append(params, Parameter{Name: name, In: "query", Required: required, Schema: schema})
+	}
+	return params
+}
+
+// fieldName mirrors encoding/json: unexported and "-" fields are skipped and
+// untagged fields keep their Go name.
+func fieldName(field reflect.StructField, tag string) (string, bool) {
+	if field.PkgPath != "" && !field.Anonymous {
+		return "", false
+	}
+	name := strings.Split(field.Tag.Get(tag), ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// applyRules copies validate tag constraints onto s and reports whether the
+// field is required. Rules after "dive" apply to the items of a slice.
+func applyRules(s *Schema, t reflect.Type, tag string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	required := false
+	target, kind := s, t.Kind()
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			if target.Items == nil {
+				return required
+			}
+			target, kind = target.Items, t.Elem().Kind()
+		case "required":
+			required = target == s
+		case "email":
+			target.Format = "email"
+		case "url":
+			target.Format = "uri"
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				if n, err := strconv.Atoi(value); err == nil && kind != reflect.String {
+					target.Enum = append(target.Enum, n)
+				} else {
+					target.Enum = append(target.Enum, value)
+				}
+			}
+		case "min", "max", "len":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			setBound(target, kind, name, n)
+		}
+	}
+	return required
+}
+
+func setBound(s *Schema, kind reflect.Kind, rule string, n int) {
+	switch kind {
+	case reflect.String:
+		if rule != "max" {
+			s.MinLength = &n
+		}
+		if rule != "min" {
+			s.MaxLength = &n
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if rule != "max" {
+			s.MinItems = &n
+		}
+		if rule != "min" {
+			s.MaxItems = &n
+		}
+	default:
+		f := float64(n)
+		if rule != "max" {
+			s.Minimum = &f
+		}
+		if rule != "min" {
+			s.Maximum = &f
+		}
+	}
+}
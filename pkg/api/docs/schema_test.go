@@ -0,0 +1,58 @@
+package docs
+
+import (
+	"testing"
+
+	"taskmanagementapi/pkg/utils/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Schema(t *testing.T) {
+	g := newGenerator()
+
+	ref := g.schemaOf(models.CreateToken{})
+	assert.Equal(t, "#/components/schemas/CreateToken", ref.Ref)
+	token := g.schemas["CreateToken"]
+	require.NotNil(t, token)
+	assert.ElementsMatch(t, []string{"name", "scopes"}, token.Required)
+	assert.Equal(t, 100, *token.Properties["name"].MaxLength)
+	assert.Equal(t, 1, *token.Properties["scopes"].MinItems)
+	assert.Equal(t, []interface{}{models.ScopeTasksRead, models.ScopeTasksWrite}, token.Properties["scopes"].Items.Enum)
+	assert.Equal(t, 365.0, *token.Properties["expires_in_days"].Maximum)
+
+	g.schemaOf(models.CreatedToken{})
+	created := g.schemas["CreatedToken"]
+	assert.Contains(t, created.Properties, "token")
+	assert.Contains(t, created.Properties, "id", "embedded fields are flattened")
+	assert.Equal(t, "date-time", created.Properties["expires_at"].Format)
+
+	g.schemaOf(models.TaskDetails{})
+	assert.Contains(t, g.schemas["TaskDetails"].Properties, "Title", "untagged fields keep their Go name")
+
+	g.schemaOf(models.Session{})
+	assert.NotContains(t, g.schemas["Session"].Properties, "jti")
+}
+
+func Test_Parameters(t *testing.T) {
+	params := newGenerator().parameters(models.TaskQuery{})
+	require.Len(t, params, 4)
+	assert.Equal(t, "sort", params[0].Name)
+	assert.Len(t, params[0].Schema.Enum, 4)
+	assert.Equal(t, 1.0, *params[1].Schema.Minimum)
+	assert.False(t, params[1].Required)
+
+	params = newGenerator().parameters(oidcCallbackQuery{})
+	assert.True(t, params[0].Required)
+}
+
+func Test_SpecOperationsUnique(t *testing.T) {
+	ids := make(map[string]bool)
+	for _, op := range operations {
+		assert.False(t, ids[op.id], "duplicate operationId %s", op.id)
+		ids[op.id] = true
+	}
+	_, err := JSON()
+	assert.NoError(t, err)
+}
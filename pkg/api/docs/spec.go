@@ -0,0 +1,305 @@
+package docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type authKind int
+
+const (
+	authNone authKind = iota
+	// authSession accepts only a signed-in user's JWT.
+	authSession
+	// authAny also accepts personal access tokens.
+	authAny
+)
+
+type responseKind int
+
+const (
+	respMessage responseKind = iota
+	respData
+	respToken
+	respRaw
+	respExport
+	respRedirect
+)
+
+// Prefix is the version the document describes. The unprefixed aliases of
+// the same routes are deprecated and left out.
+const Prefix = "/v1"
+
+// operation is one row of the route table. Paths use OpenAPI {param} syntax,
+// are relative to Prefix unless unversioned, and path parameters are derived
+// from them.
+type operation struct {
+	method      string
+	path        string
+	unversioned bool
+	id          string
+	summary     string
+	tag         string
+	auth        authKind
+	scope       string
+	query       interface{}
+	headers     []Parameter
+	body        interface{}
+	// bodies maps media types to schemas for operations that accept more
+	// than JSON; body is then unused.
+	bodies   map[string]interface{}
+	optional bool
+	status   int
+	response responseKind
+	data     interface{}
+}
+
+type pageQuery struct {
+	Page  int `query:"page" validate:"omitempty,min=1"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type userListQuery struct {
+	Search string `query:"search"`
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type exportQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=json zip"`
+}
+
+// taskMergePatch documents a merge patch of models.CreateTask: every member is
+// optional and null removes it.
+type taskMergePatch struct {
+	Title       *string `json:"title" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description" validate:"omitempty,min=1,max=1000"`
+	Project     *string `json:"project" validate:"omitempty,max=100"`
+}
+
+type jsonPatchOperation struct {
+	Op    string      `json:"op" validate:"required,oneof=add remove replace move copy test"`
+	Path  string      `json:"path" validate:"required"`
+	From  string      `json:"from"`
+	Value interface{} `json:"value"`
+}
+
+type oidcCallbackQuery struct {
+	State string `query:"state" validate:"required"`
+	Code  string `query:"code" validate:"required"`
+	Error string `query:"error"`
+}
+
+var operations = []operation{
+	{method: http.MethodGet, path: "/.well-known/jwks.json", unversioned: true, id: "getJWKS", summary: "Public keys for verifying issued tokens", tag: "auth", status: http.StatusOK, response: respRaw, data: models.JWKS{}},
+
+	{method: http.MethodPost, path: "/user/signup", id: "signUp", summary: "Create an account", tag: "auth", body: models.UserSignup{}, status: http.StatusCreated},
+	{method: http.MethodPost, path: "/user/signin", id: "signIn", summary: "Sign in with email and password", tag: "auth", body: models.UserSignIn{}, status: http.StatusCreated, response: respToken},
+	{method: http.MethodPost, path: "/user/signout", id: "signOut", summary: "Sign out the current session and clear its cookie", tag: "auth", auth: authSession, status: http.StatusOK},
+	{method: http.MethodGet, path: "/user/oidc/login", id: "oidcLogin", summary: "Redirect to the OpenID Connect provider", tag: "auth", status: http.StatusFound, response: respRedirect},
+	{method: http.MethodGet, path: "/user/oidc/callback", id: "oidcCallback", summary: "Complete an OpenID Connect sign-in", tag: "auth", query: oidcCallbackQuery{}, status: http.StatusOK, response: respToken},
+
+	{method: http.MethodGet, path: "/user/me", id: "getProfile", summary: "Current user's profile", tag: "account", auth: authAny, status: http.StatusOK, response: respData, data: models.UserProfile{}},
+	{method: http.MethodPatch, path: "/user/me", id: "updateProfile", summary: "Update name or start an email change", tag: "account", auth: authSession, body: models.UpdateProfile{}, status: http.StatusOK},
+	{method: http.MethodPost, path: "/user/me/email/verify", id: "verifyEmail", summary: "Confirm a pending email change", tag: "account", auth: authSession, body: models.VerifyEmail{}, status: http.StatusOK},
+	{method: http.MethodPost, path: "/user/me/password", id: "changePassword", summary: "Change password and get a fresh token", tag: "account", auth: authSession, body: models.ChangePassword{}, status: http.StatusOK, response: respToken},
+	{method: http.MethodGet, path: "/user/me/export", id: "exportData", summary: "Download all account data", tag: "account", auth: authSession, query: exportQuery{}, status: http.StatusOK, response: respExport},
+	{method: http.MethodDelete, path: "/user/me", id: "deleteAccount", summary: "Delete the account and its data", tag: "account", auth: authSession, body: models.DeleteAccount{}, optional: true, status: http.StatusOK},
+
+	{method: http.MethodPost, path: "/user/tokens", id: "createToken", summary: "Create a personal access token", tag: "tokens", auth: authSession, body: models.CreateToken{}, status: http.StatusCreated, response: respData, data: models.CreatedToken{}},
+	{method: http.MethodGet, path: "/user/tokens", id: "listTokens", summary: "List personal access tokens", tag: "tokens", auth: authSession, status: http.StatusOK, response: respData, data: []models.TokenDetails{}},
+	{method: http.MethodDelete, path: "/user/tokens/{id}", id: "revokeToken", summary: "Revoke a personal access token", tag: "tokens", auth: authSession, status: http.StatusOK},
+
+	{method: http.MethodGet, path: "/user/sessions", id: "listSessions", summary: "List signed-in sessions", tag: "sessions", auth: authSession, status: http.StatusOK, response: respData, data: []models.Session{}},
+	{method: http.MethodDelete, path: "/user/sessions/{id}", id: "revokeSession", summary: "Sign out a session", tag: "sessions", auth: authSession, status: http.StatusOK},
+
+	{method: http.MethodGet, path: "/user/preferences", id: "getPreferences", summary: "Current user's preferences", tag: "preferences", auth: authSession, status: http.StatusOK, response: respData, data: models.Preferences{}},
+	{method: http.MethodPut, path: "/user/preferences", id: "updatePreferences", summary: "Replace the current user's preferences", tag: "preferences", auth: authSession, body: models.Preferences{}, status: http.StatusOK, response: respData, data: models.Preferences{}},
+
+	{method: http.MethodPost, path: "/tasks", id: "createTask", summary: "Create a task; retries with the same Idempotency-Key replay the first response", tag: "tasks", auth: authAny, scope: models.ScopeTasksWrite, headers: []Parameter{idempotencyKeyHeader}, body: models.CreateTask{}, status: http.StatusCreated, response: respData, data: models.CreatedTask{}},
+	{method: http.MethodGet, path: "/tasks", id: "listTasks", summary: "List tasks", tag: "tasks", auth: authAny, scope: models.ScopeTasksRead, query: models.TaskQuery{}, status: http.StatusOK, response: respData, data: []models.TaskDetails{}},
+	{method: http.MethodPost, path: "/tasks/bulk", id: "bulkTasks", summary: "Create, update and delete many tasks; each item reports its own status", tag: "tasks", auth: authAny, scope: models.ScopeTasksWrite, body: models.BulkTaskRequest{}, status: http.StatusOK, response: respData, data: []models.BulkTaskResult{}},
+	{method: http.MethodGet, path: "/tasks/{id}", id: "getTask", summary: "Get a task", tag: "tasks", auth: authAny, scope: models.ScopeTasksRead, status: http.StatusOK, response: respData, data: models.TaskDetails{}},
+	{method: http.MethodPut, path: "/tasks/{id}", id: "updateTask", summary: "Replace a task", tag: "tasks", auth: authAny, scope: models.ScopeTasksWrite, body: models.CreateTask{}, status: http.StatusOK},
+	{method: http.MethodPatch, path: "/tasks/{id}", id: "patchTask", summary: "Change some fields of a task", tag: "tasks", auth: authAny, scope: models.ScopeTasksWrite, bodies: map[string]interface{}{models.MergePatchContentType: taskMergePatch{}, models.JSONPatchContentType: []jsonPatchOperation{}}, status: http.StatusOK, response: respData, data: models.TaskDetails{}},
+	{method: http.MethodDelete, path: "/tasks/{id}", id: "deleteTask", summary: "Delete a task", tag: "tasks", auth: authAny, scope: models.ScopeTasksWrite, status: http.StatusOK},
+
+	{method: http.MethodGet, path: "/admin/users", id: "adminListUsers", summary: "List users", tag: "admin", auth: authSession, query: userListQuery{}, status: http.StatusOK, response: respData, data: []models.UserSummary{}},
+	{method: http.MethodPost, path: "/admin/users/{id}/disable", id: "adminDisableUser", summary: "Disable a user", tag: "admin", auth: authSession, status: http.StatusOK},
+	{method: http.MethodPost, path: "/admin/users/{id}/enable", id: "adminEnableUser", summary: "Enable a user", tag: "admin", auth: authSession, status: http.StatusOK},
+	{method: http.MethodPost, path: "/admin/users/{id}/password", id: "adminResetPassword", summary: "Set a user's password", tag: "admin", auth: authSession, body: models.ResetPassword{}, status: http.StatusOK},
+	{method: http.MethodPut, path: "/admin/users/{id}/role", id: "adminUpdateRole", summary: "Change a user's role", tag: "admin", auth: authSession, body: models.UpdateRole{}, status: http.StatusOK},
+	{method: http.MethodGet, path: "/admin/users/{id}/tasks", id: "adminGetUserTasks", summary: "List a user's tasks", tag: "admin", auth: authSession, query: pageQuery{}, status: http.StatusOK, response: respData, data: []models.TaskDetails{}},
+	{method: http.MethodGet, path: "/admin/audit-logs", id: "adminGetAuditLogs", summary: "List audit log entries", tag: "admin", auth: authSession, query: pageQuery{}, status: http.StatusOK, response: respData, data: []models.AuditLog{}},
+}
+
+var idempotencyKeyHeader = Parameter{Name: "Idempotency-Key", In: "header", Schema: &Schema{Type: "string", MaxLength: intPtr(255)}}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+var (
+	specOnce sync.Once
+	specJSON []byte
+	specErr  error
+)
+
+// JSON returns the encoded document. It is built on first use.
+func JSON() ([]byte, error) {
+	specOnce.Do(func() {
+		specJSON, specErr = json.Marshal(Spec())
+	})
+	return specJSON, specErr
+}
+
+// Spec builds the OpenAPI document for every registered route.
+func Spec() *Document {
+	g := newGenerator()
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Task Management API",
+			Description: "Errors are returned as RFC 7807 problem details. The same routes are also served without the " + Prefix + " prefix; those aliases are deprecated and answer with Deprecation and Sunset headers.",
+			Version:     "1.0.0",
+		},
+		Tags: []Tag{
+			{Name: "auth"}, {Name: "account"}, {Name: "tokens"}, {Name: "sessions"},
+			{Name: "preferences"}, {Name: "tasks"}, {Name: "admin", Description: "Requires the admin role."},
+		},
+		Paths: make(map[string]PathItem),
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth":  {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "Token returned by sign-in."},
+				"cookieAuth":  {Type: "apiKey", In: "cookie", Name: "Authorization", Description: "Sign-in token sent as a cookie."},
+				"accessToken": {Type: "http", Scheme: "bearer", Description: "Personal access token created under " + Prefix + "/user/tokens."},
+			},
+		},
+	}
+	problem := g.schemaOf(models.Problem{})
+	for _, op := range operations {
+		path := op.fullPath()
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(PathItem)
+		}
+		doc.Paths[path][strings.ToLower(op.method)] = g.operation(op, problem)
+	}
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+// Has reports whether the document describes method on path.
+func Has(method, path string) bool {
+	for _, op := range operations {
+		if op.method == method && op.fullPath() == path {
+			return true
+		}
+	}
+	return false
+}
+
+// Paths lists every documented method and path.
+func Paths() [][2]string {
+	paths := make([][2]string, 0, len(operations))
+	for _, op := range operations {
+		paths = append(paths, [2]string{op.method, op.fullPath()})
+	}
+	return paths
+}
+
+func (op operation) fullPath() string {
+	if op.unversioned {
+		return op.path
+	}
+	return Prefix + op.path
+}
+
+func (g *generator) operation(op operation, problem *Schema) *Operation {
+	o := &Operation{
+		OperationID: op.id,
+		Summary:     op.summary,
+		Tags:        []string{op.tag},
+		Responses: map[string]Response{
+			strconv.Itoa(op.status): g.response(op),
+			"default": {
+				Description: "Error",
+				Content:     map[string]MediaType{"application/problem+json": {Schema: problem}},
+			},
+		},
+	}
+	switch op.auth {
+	case authSession:
+		o.Security = []map[string][]string{{"bearerAuth": {}}, {"cookieAuth": {}}}
+	case authAny:
+		o.Security = []map[string][]string{{"bearerAuth": {}}, {"cookieAuth": {}}, {"accessToken": {}}}
+	}
+	if op.scope != "" {
+		o.Summary += " (access tokens need " + op.scope + ")"
+	}
+	for _, match := range pathParam.FindAllStringSubmatch(op.path, -1) {
+		o.Parameters = append(o.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	o.Parameters = append(o.Parameters, op.headers...)
+	if op.query != nil {
+		o.Parameters = append(o.Parameters, g.parameters(op.query)...)
+	}
+	if op.body != nil {
+		op.bodies = map[string]interface{}{"application/json": op.body}
+	}
+	if op.bodies != nil {
+		o.RequestBody = &RequestBody{Required: !op.optional, Content: make(map[string]MediaType)}
+		for mediaType, body := range op.bodies {
+			o.RequestBody.Content[mediaType] = MediaType{Schema: g.schemaOf(body)}
+		}
+	}
+	return o
+}
+
+func (g *generator) response(op operation) Response {
+	description := http.StatusText(op.status)
+	envelope := func(key string, value *Schema) Response {
+		properties := map[string]*Schema{"message": {Type: "string"}}
+		required := []string{"message"}
+		if value != nil {
+			properties[key] = value
+			required = append(required, key)
+		}
+		return Response{
+			Description: description,
+			Content: map[string]MediaType{"application/json": {
+				Schema: &Schema{Type: "object", Properties: properties, Required: required},
+			}},
+		}
+	}
+	switch op.response {
+	case respData:
+		return envelope("data", g.schemaOf(op.data))
+	case respToken:
+		return envelope("token", &Schema{Type: "string"})
+	case respRaw:
+		return Response{Description: description, Content: map[string]MediaType{"application/json": {Schema: g.schemaOf(op.data)}}}
+	case respExport:
+		return Response{
+			Description: description,
+			Headers:     map[string]Header{"Content-Disposition": {Schema: &Schema{Type: "string"}}},
+			Content: map[string]MediaType{
+				"application/json": {Schema: g.schemaOf(models.UserExport{})},
+				"application/zip":  {Schema: &Schema{Type: "string", Format: "binary"}},
+			},
+		}
+	case respRedirect:
+		return Response{
+			Description: description,
+			Headers:     map[string]Header{"Location": {Description: "Provider authorization URL.", Schema: &Schema{Type: "string", Format: "uri"}}},
+		}
+	}
+	return envelope("", nil)
+}
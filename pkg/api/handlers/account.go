@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"taskmanagementapi/pkg/logging"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AccountHandler struct {
+	AccountUseCase services.AccountUseCase
+}
+
+func NewAccountHandler(useCase services.AccountUseCase) *AccountHandler {
+	return &AccountHandler{
+		AccountUseCase: useCase,
+	}
+}
+
+func (ah *AccountHandler) ExportData(c *fiber.Ctx) error {
+	format := c.Query("format", models.ExportFormatJSON)
+	if format != models.ExportFormatJSON && format != models.ExportFormatZIP {
+		return services.InvalidInput("invalid_format", "format must be json or zip", models.FieldError{Field: "format", Rule: "oneof", Message: "must be one of: json, zip"})
+	}
+	userID := c.Locals("user_id").(string)
+	export, err := ah.AccountUseCase.ExportData(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+	// The export is built before anything is sent, so that a failure is
+	// still an error response rather than a truncated download.
+	var body bytes.Buffer
+	contentType := fiber.MIMEApplicationJSON
+	if format == models.ExportFormatZIP {
+		contentType = "application/zip"
+		err = writeExportZip(&body, export)
+	} else {
+		err = json.NewEncoder(&body).Encode(export)
+	}
+	if err != nil {
+		logging.FromContext(c.UserContext()).Error("write export", "format", format, "error", err)
+		return errors.New("error from write export")
+	}
+	filename := fmt.Sprintf("export-%s-%s.%s", userID, export.ExportedAt.Format("20060102"), format)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Status(fiber.StatusOK).Send(body.Bytes())
+}
+
+// writeExportZip puts each part of the export in its own file so the archive
+// can be read without tooling.
+func writeExportZip(w io.Writer, export models.UserExport) error {
+	archive := zip.NewWriter(w)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"preferences.json", export.Preferences},
+		{"tasks.json", export.Tasks},
+		{"tokens.json", export.Tokens},
+	}
+	for _, file := range files {
+		f, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func (ah *AccountHandler) DeleteAccount(c *fiber.Ctx) error {
+	var confirm models.DeleteAccount
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&confirm); err != nil {
+			return errInvalidBody
+		}
+	}
+	userID := c.Locals("user_id").(string)
+	err := ah.AccountUseCase.DeleteAccount(c.UserContext(), userID, confirm)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Account deleted"})
+}
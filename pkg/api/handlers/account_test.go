@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"taskmanagementapi/pkg/api/handlers"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/usecase/mock"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAccountApp(useCase *mock.MockAccountUseCase) *fiber.App {
+	accountHandler := handlers.NewAccountHandler(useCase)
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user1")
+		return c.Next()
+	})
+	app.Get("/me/export", accountHandler.ExportData)
+	app.Delete("/me", accountHandler.DeleteAccount)
+	return app
+}
+
+func Test_ExportData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUseCase := mock.NewMockAccountUseCase(ctrl)
+	app := newAccountApp(mockUseCase)
+
+	export := models.UserExport{
+		ExportedAt: time.Date(2024, 10, 9, 0, 0, 0, 0, time.UTC),
+		Profile:    models.UserProfile{ID: "user1", Email: "akhil@example.com"},
+		Tasks:      []models.TaskDetails{{ID: "task1"}},
+		Tokens:     []models.TokenDetails{},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		mockUseCase.EXPECT().ExportData(gomock.Any(), "user1").Return(export, nil).Times(1)
+		resp, err := app.Test(httptest.NewRequest("GET", "/me/export", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "export-user1-20241009.json")
+		var got models.UserExport
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		assert.Equal(t, export, got)
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		mockUseCase.EXPECT().ExportData(gomock.Any(), "user1").Return(export, nil).Times(1)
+		resp, err := app.Test(httptest.NewRequest("GET", "/me/export?format=zip", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, "application/zip", resp.Header.Get(fiber.HeaderContentType))
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		assert.NoError(t, err)
+		var names []string
+		for _, f := range archive.File {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"profile.json", "preferences.json", "tasks.json", "tokens.json"}, names)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/me/export?format=xml", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func Test_DeleteAccount(t *testing.T) {
+	testCases := map[string]struct {
+		body       string
+		buildStub  func(useCaseMock *mock.MockAccountUseCase)
+		wantStatus int
+	}{
+		"deleted": {
+			body: `{"password":"password"}`,
+			buildStub: func(useCaseMock *mock.MockAccountUseCase) {
+				useCaseMock.EXPECT().DeleteAccount(gomock.Any(), "user1", models.DeleteAccount{Password: "password"}).Return(nil).Times(1)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"no body": {
+			buildStub: func(useCaseMock *mock.MockAccountUseCase) {
+				useCaseMock.EXPECT().DeleteAccount(gomock.Any(), "user1", models.DeleteAccount{}).Return(nil).Times(1)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"wrong password": {
+			body: `{"password":"wrong"}`,
+			buildStub: func(useCaseMock *mock.MockAccountUseCase) {
+				useCaseMock.EXPECT().DeleteAccount(gomock.Any(), "user1", gomock.Any()).Return(services.ErrInvalidCredentials).Times(1)
+			},
+			wantStatus: fiber.StatusUnauthorized,
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUseCase := mock.NewMockAccountUseCase(ctrl)
+			test.buildStub(mockUseCase)
+			app := newAccountApp(mockUseCase)
+
+			req := httptest.NewRequest("DELETE", "/me", bytes.NewBufferString(test.body))
+			if test.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"taskmanagementapi/pkg/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+type AdminHandler struct {
+	AdminUseCase services.AdminUseCase
+}
+
+func NewAdminHandler(useCase services.AdminUseCase) *AdminHandler {
+	return &AdminHandler{
+		AdminUseCase: useCase,
+	}
+}
+
+func pagination(c *fiber.Ctx) (int, int) {
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit < 1 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	return page, limit
+}
+
+func (ad *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	page, limit := pagination(c)
+	filter := models.UserFilter{
+		Search: c.Query("search"),
+		Page:   page,
+		Limit:  limit,
+	}
+	adminID := c.Locals("user_id").(string)
+	users, err := ad.AdminUseCase.ListUsers(c.UserContext(), adminID, filter)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Get Users", "data": users})
+}
+
+func (ad *AdminHandler) DisableUser(c *fiber.Ctx) error {
+	return ad.setUserDisabled(c, true)
+}
+
+func (ad *AdminHandler) EnableUser(c *fiber.Ctx) error {
+	return ad.setUserDisabled(c, false)
+}
+
+func (ad *AdminHandler) setUserDisabled(c *fiber.Ctx, disabled bool) error {
+	userID := c.Params("id")
+	adminID := c.Locals("user_id").(string)
+	err := ad.AdminUseCase.SetUserDisabled(c.UserContext(), adminID, userID, disabled)
+	if err != nil {
+		return err
+	}
+	if disabled {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Disabled User"})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Enabled User"})
+}
+
+func (ad *AdminHandler) ResetPassword(c *fiber.Ctx) error {
+	var reset models.ResetPassword
+	if err := c.BodyParser(&reset); err != nil {
+		return errInvalidBody
+	}
+	err := validation.Struct(reset)
+	if err != nil {
+		return err
+	}
+	userID := c.Params("id")
+	adminID := c.Locals("user_id").(string)
+	err = ad.AdminUseCase.ResetPassword(c.UserContext(), adminID, userID, reset)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Reset Password"})
+}
+
+func (ad *AdminHandler) UpdateRole(c *fiber.Ctx) error {
+	var role models.UpdateRole
+	if err := c.BodyParser(&role); err != nil {
+		return errInvalidBody
+	}
+	err := validation.Struct(role)
+	if err != nil {
+		return err
+	}
+	userID := c.Params("id")
+	adminID := c.Locals("user_id").(string)
+	err = ad.AdminUseCase.UpdateRole(c.UserContext(), adminID, userID, role)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Updated Role"})
+}
+
+func (ad *AdminHandler) GetUserTasks(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	adminID := c.Locals("user_id").(string)
+	tasks, err := ad.AdminUseCase.GetUserTasks(c.UserContext(), adminID, userID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Get Tasks", "data": tasks})
+}
+
+func (ad *AdminHandler) GetAuditLogs(c *fiber.Ctx) error {
+	page, limit := pagination(c)
+	logs, err := ad.AdminUseCase.GetAuditLogs(c.UserContext(), page, limit)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Get Audit Logs", "data": logs})
+}
@@ -0,0 +1,133 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"taskmanagementapi/pkg/api/handlers"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/usecase/mock"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListUsers(t *testing.T) {
+	testCases := map[string]struct {
+		query         string
+		buildStub     func(useCaseMock *mock.MockAdminUseCase)
+		checkResponse func(t *testing.T, resp *http.Response)
+	}{
+		"Search With Pagination": {
+			query: "?search=arun&page=2&limit=10",
+			buildStub: func(useCaseMock *mock.MockAdminUseCase) {
+				useCaseMock.EXPECT().ListUsers(gomock.Any(), "admin", models.UserFilter{Search: "arun", Page: 2, Limit: 10}).Times(1).Return([]models.UserSummary{}, nil)
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			},
+		},
+		"Defaults For Invalid Pagination": {
+			query: "?page=0&limit=1000",
+			buildStub: func(useCaseMock *mock.MockAdminUseCase) {
+				useCaseMock.EXPECT().ListUsers(gomock.Any(), "admin", models.UserFilter{Page: 1, Limit: 20}).Times(1).Return([]models.UserSummary{}, nil)
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			},
+		},
+		"Users Retrieval Failure": {
+			query: "",
+			buildStub: func(useCaseMock *mock.MockAdminUseCase) {
+				useCaseMock.EXPECT().ListUsers(gomock.Any(), "admin", gomock.Any()).Times(1).Return(nil, errors.New("failed"))
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+			},
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mock.NewMockAdminUseCase(ctrl)
+			test.buildStub(mockUseCase)
+			adminHandler := handlers.NewAdminHandler(mockUseCase)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Get("/users", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "admin")
+				return adminHandler.ListUsers(c)
+			})
+
+			req := httptest.NewRequest("GET", "/users"+test.query, nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			test.checkResponse(t, resp)
+		})
+	}
+}
+
+func Test_AdminResetPassword(t *testing.T) {
+	testCases := map[string]struct {
+		input         models.ResetPassword
+		buildStub     func(useCaseMock *mock.MockAdminUseCase, reset models.ResetPassword)
+		checkResponse func(t *testing.T, resp *http.Response)
+	}{
+		"Valid Reset": {
+			input: models.ResetPassword{Password: "newpassword"},
+			buildStub: func(useCaseMock *mock.MockAdminUseCase, reset models.ResetPassword) {
+				useCaseMock.EXPECT().ResetPassword(gomock.Any(), "admin", "user1", reset).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			},
+		},
+		"Password Too Short": {
+			input: models.ResetPassword{Password: "abc"},
+			buildStub: func(useCaseMock *mock.MockAdminUseCase, reset models.ResetPassword) {
+				useCaseMock.EXPECT().ResetPassword(gomock.Any(), "admin", "user1", reset).Times(1).Return(fmt.Errorf("%w: too short", services.ErrWeakPassword))
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+			},
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mock.NewMockAdminUseCase(ctrl)
+			test.buildStub(mockUseCase, test.input)
+			adminHandler := handlers.NewAdminHandler(mockUseCase)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Post("/users/:id/password", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "admin")
+				return adminHandler.ResetPassword(c)
+			})
+			jsonData, err := json.Marshal(test.input)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest("POST", "/users/user1/password", bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			test.checkResponse(t, resp)
+		})
+	}
+}
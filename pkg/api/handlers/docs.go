@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"path"
+	"strings"
+	"taskmanagementapi/pkg/api/docs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func OpenAPI(c *fiber.Ctx) error {
+	spec, err := docs.JSON()
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(fiber.StatusOK).Send(spec)
+}
+
+func DocsUI(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	c.Set(fiber.HeaderContentSecurityPolicy, docs.UIContentSecurityPolicy)
+	return c.Status(fiber.StatusOK).Send(docs.UI)
+}
+
+func DocsUIScript(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJavaScriptCharsetUTF8)
+	return c.Status(fiber.StatusOK).Send(docs.UIScript)
+}
+
+func DocsUIAsset(c *fiber.Ctx) error {
+	name := c.Params("file")
+	asset, ok := docs.UIAsset(name)
+	if !ok {
+		return fiber.ErrNotFound
+	}
+	c.Type(strings.TrimPrefix(path.Ext(name), "."), "utf-8")
+	return c.Status(fiber.StatusOK).Send(asset)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+const problemContentType = "application/problem+json"
+
+var statusByKind = map[services.Kind]int{
+	services.KindNotFound:        fiber.StatusNotFound,
+	services.KindConflict:        fiber.StatusConflict,
+	services.KindValidation:      fiber.StatusUnprocessableEntity,
+	services.KindUnauthorized:    fiber.StatusUnauthorized,
+	services.KindForbidden:       fiber.StatusForbidden,
+	services.KindTooManyRequests: fiber.StatusTooManyRequests,
+	services.KindInvalidInput:    fiber.StatusBadRequest,
+}
+
+// errInvalidBody is returned when the request body cannot be decoded at all.
+var errInvalidBody = services.InvalidInput("invalid_body", "request body could not be parsed")
+
+// errInvalidQuery is returned when the query string cannot be decoded, such
+// as a page that is not a number.
+var errInvalidQuery = services.InvalidInput("invalid_query", "query parameters could not be parsed")
+
+// ErrorHandler answers for every handler and middleware that returns an
+// error, always with an RFC 7807 problem.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	problem := problemFor(err)
+	problem.Instance = c.OriginalURL()
+	return c.Status(problem.Status).JSON(problem, problemContentType)
+}
+
+// problemFor describes err as a problem. Domain errors carry their own status
+// and code; fiber errors such as unknown routes keep theirs; anything else is
+// a 500.
+func problemFor(err error) models.Problem {
+	problem := models.Problem{
+		Type:   "about:blank",
+		Status: fiber.StatusInternalServerError,
+		Detail: err.Error(),
+		Code:   "internal_error",
+	}
+	var domainErr *services.Error
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &domainErr):
+		if status, ok := statusByKind[domainErr.Kind]; ok {
+			problem.Status = status
+		}
+		problem.Code = domainErr.Code
+		problem.Errors = domainErr.Fields
+	case errors.As(err, &fiberErr):
+		problem.Status = fiberErr.Code
+		problem.Code = strings.ReplaceAll(strings.ToLower(utils.StatusMessage(fiberErr.Code)), " ", "_")
+	}
+	problem.Title = utils.StatusMessage(problem.Status)
+	return problem
+}
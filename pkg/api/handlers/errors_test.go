@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"taskmanagementapi/pkg/api/handlers"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ErrorHandler(t *testing.T) {
+	testCases := map[string]struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		"not found":         {err: services.NotFound("task_not_found", "task doesn't exist"), wantStatus: fiber.StatusNotFound, wantCode: "task_not_found"},
+		"conflict":          {err: services.Conflict("email_taken", "taken"), wantStatus: fiber.StatusConflict, wantCode: "email_taken"},
+		"validation":        {err: services.Validation("invalid_token", "bad token"), wantStatus: fiber.StatusUnprocessableEntity, wantCode: "invalid_token"},
+		"unauthorized":      {err: services.ErrInvalidCredentials, wantStatus: fiber.StatusUnauthorized, wantCode: "invalid_credentials"},
+		"forbidden":         {err: services.Forbidden("account_disabled", "disabled"), wantStatus: fiber.StatusForbidden, wantCode: "account_disabled"},
+		"too many requests": {err: services.ErrTooManyAttempts, wantStatus: fiber.StatusTooManyRequests, wantCode: "too_many_attempts"},
+		"invalid input":     {err: services.InvalidInput("invalid_body", "bad body"), wantStatus: fiber.StatusBadRequest, wantCode: "invalid_body"},
+		"wrapped":           {err: fmt.Errorf("%w: too short", services.ErrWeakPassword), wantStatus: fiber.StatusUnprocessableEntity, wantCode: "weak_password"},
+		"fiber error":       {err: fiber.ErrMethodNotAllowed, wantStatus: fiber.StatusMethodNotAllowed, wantCode: "method_not_allowed"},
+		"unexpected":        {err: errors.New("error from get task"), wantStatus: fiber.StatusInternalServerError, wantCode: "internal_error"},
+	}
+
+	for testName, test := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Get("/things", func(c *fiber.Ctx) error {
+				return test.err
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/things?page=2", nil), -1)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+			assert.Equal(t, "application/problem+json", resp.Header.Get(fiber.HeaderContentType))
+
+			var problem models.Problem
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+			assert.Equal(t, models.Problem{
+				Type:     "about:blank",
+				Title:    utils.StatusMessage(test.wantStatus),
+				Status:   test.wantStatus,
+				Detail:   test.err.Error(),
+				Instance: "/things?page=2",
+				Code:     test.wantCode,
+			}, problem)
+		})
+	}
+}
+
+func Test_ValidationProblem(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	app.Post("/signup", handlers.NewUserHandler(nil).UserSignUp)
+
+	req := httptest.NewRequest("POST", "/signup", strings.NewReader(`{"name":"Al","email":"not-an-email"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var problem models.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, "invalid_fields", problem.Code)
+	assert.ElementsMatch(t, []models.FieldError{
+		{Field: "name", Rule: "min", Message: "must be at least 3 characters long"},
+		{Field: "email", Rule: "email", Message: "must be a valid email address"},
+		{Field: "password", Rule: "required", Message: "is required"},
+	}, problem.Errors)
+}
+
+func Test_InvalidBodyProblem(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	app.Post("/signup", handlers.NewUserHandler(nil).UserSignUp)
+
+	req := httptest.NewRequest("POST", "/signup", strings.NewReader(`{"name":`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var problem models.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, "invalid_body", problem.Code)
+	assert.Empty(t, problem.Errors)
+}
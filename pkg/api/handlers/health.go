@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"taskmanagementapi/pkg/buildinfo"
+	services "taskmanagementapi/pkg/usecase/interface"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HealthHandler struct {
+	HealthUseCase services.HealthUseCase
+}
+
+func NewHealthHandler(useCase services.HealthUseCase) *HealthHandler {
+	return &HealthHandler{
+		HealthUseCase: useCase,
+	}
+}
+
+// Healthz only shows that the process is serving requests; it does not touch
+// any dependency, so an outage of MongoDB does not get the process restarted.
+func (hh *HealthHandler) Healthz(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "ok"})
+}
+
+// Readyz reports whether the instance should receive traffic.
+func (hh *HealthHandler) Readyz(c *fiber.Ctx) error {
+	readiness := hh.HealthUseCase.Ready(c.UserContext())
+	status := fiber.StatusOK
+	if !readiness.Ready {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(readiness)
+}
+
+func Version(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(buildinfo.Get())
+}
@@ -0,0 +1,76 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"runtime"
+	"taskmanagementapi/pkg/api/handlers"
+	"taskmanagementapi/pkg/usecase/mock"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HealthHandlers(t *testing.T) {
+	testCases := map[string]struct {
+		path       string
+		buildStub  func(useCaseMock *mock.MockHealthUseCase)
+		wantStatus int
+	}{
+		"alive": {
+			path:       "/healthz",
+			buildStub:  func(useCaseMock *mock.MockHealthUseCase) {},
+			wantStatus: fiber.StatusOK,
+		},
+		"ready": {
+			path: "/readyz",
+			buildStub: func(useCaseMock *mock.MockHealthUseCase) {
+				useCaseMock.EXPECT().Ready(gomock.Any()).Return(models.Readiness{Ready: true, Checks: map[string]string{"mongodb": "ok"}}).Times(1)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"not ready": {
+			path: "/readyz",
+			buildStub: func(useCaseMock *mock.MockHealthUseCase) {
+				useCaseMock.EXPECT().Ready(gomock.Any()).Return(models.Readiness{Checks: map[string]string{"mongodb": "unreachable"}}).Times(1)
+			},
+			wantStatus: fiber.StatusServiceUnavailable,
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUseCase := mock.NewMockHealthUseCase(ctrl)
+			test.buildStub(mockUseCase)
+			healthHandler := handlers.NewHealthHandler(mockUseCase)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Get("/healthz", healthHandler.Healthz)
+			app.Get("/readyz", healthHandler.Readyz)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, test.path, nil), -1)
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Version(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", handlers.Version)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/version", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var info models.BuildInfo
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.NotEmpty(t, info.Commit)
+	assert.NotEmpty(t, info.BuildTime)
+}
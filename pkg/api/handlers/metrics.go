@@ -0,0 +1,11 @@
+package handlers
+
+import (
+	"taskmanagementapi/pkg/metrics"
+
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics serves the Prometheus text exposition of metrics.Registry.
+var Metrics = adaptor.HTTPHandler(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"taskmanagementapi/pkg/metrics"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// oidcStateCookie holds the state of the login this browser started. The
+// callback must present the same state, so that nobody can finish their own
+// login in someone else's browser.
+const oidcStateCookie = "oidc_state"
+
+var (
+	errMissingOIDCParams = services.InvalidInput("invalid_callback", "state and code are required")
+	errOIDCStateMismatch = services.Unauthorized("invalid_login_state", "login was not started in this browser")
+)
+
+type OIDCHandler struct {
+	OIDCUseCase services.OIDCUseCase
+}
+
+func NewOIDCHandler(useCase services.OIDCUseCase) *OIDCHandler {
+	return &OIDCHandler{
+		OIDCUseCase: useCase,
+	}
+}
+
+func (oh *OIDCHandler) Login(c *fiber.Ctx) error {
+	login, err := oh.OIDCUseCase.LoginURL(c.UserContext())
+	if err != nil {
+		return err
+	}
+	c.Cookie(&fiber.Cookie{
+		Name:  oidcStateCookie,
+		Value: login.State,
+		// Only sent to the callback next to this route.
+		Path:     strings.TrimSuffix(c.Path(), "login"),
+		Expires:  login.ExpiresAt,
+		HTTPOnly: true,
+		Secure:   true,
+		// Lax still sends it on the provider's top-level redirect back.
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	return c.Redirect(login.URL, fiber.StatusFound)
+}
+
+func (oh *OIDCHandler) Callback(c *fiber.Ctx) error {
+	if providerErr := c.Query("error"); providerErr != "" {
+		return services.Unauthorized("sso_failed", providerErr)
+	}
+	state, code := c.Query("state"), c.Query("code")
+	if state == "" || code == "" {
+		return errMissingOIDCParams
+	}
+	if subtle.ConstantTimeCompare([]byte(c.Cookies(oidcStateCookie)), []byte(state)) != 1 {
+		return errOIDCStateMismatch
+	}
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Path:     strings.TrimSuffix(c.Path(), "callback"),
+		Expires:  time.Now().Add(-1 * time.Hour),
+		HTTPOnly: true,
+		Secure:   true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	token, err := oh.OIDCUseCase.Callback(c.UserContext(), state, code, clientInfo(c))
+	// Only the provider's verdict counts, not failures to reach it.
+	if err == nil || errors.Is(err, services.ErrUnauthorized) {
+		metrics.AuthAttempt(metrics.AuthOIDC, err)
+	}
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "User signIn Successful", "token": token})
+}
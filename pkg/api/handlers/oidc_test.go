@@ -0,0 +1,128 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"taskmanagementapi/pkg/api/handlers"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/usecase/mock"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OIDCLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUseCase := mock.NewMockOIDCUseCase(ctrl)
+	oidcHandler := handlers.NewOIDCHandler(mockUseCase)
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	app.Get("/oidc/login", oidcHandler.Login)
+
+	mockUseCase.EXPECT().LoginURL(gomock.Any()).Return(models.OIDCLogin{URL: "https://idp.example.com/authorize?state=abc", State: "abc", ExpiresAt: time.Now().Add(10 * time.Minute)}, nil)
+	resp, err := app.Test(httptest.NewRequest("GET", "/oidc/login", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusFound, resp.StatusCode)
+	assert.Equal(t, "https://idp.example.com/authorize?state=abc", resp.Header.Get("Location"))
+	cookies := resp.Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, "oidc_state", cookies[0].Name)
+		assert.Equal(t, "abc", cookies[0].Value)
+		assert.Equal(t, "/oidc/", cookies[0].Path)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+	}
+
+	mockUseCase.EXPECT().LoginURL(gomock.Any()).Return(models.OIDCLogin{}, services.NotFound("sso_not_configured", "oidc login is not configured"))
+	resp, err = app.Test(httptest.NewRequest("GET", "/oidc/login", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	mockUseCase.EXPECT().LoginURL(gomock.Any()).Return(models.OIDCLogin{}, errors.New("error from insert login state"))
+	resp, err = app.Test(httptest.NewRequest("GET", "/oidc/login", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
+
+func Test_OIDCCallback(t *testing.T) {
+	testCases := map[string]struct {
+		query         string
+		cookie        string
+		buildStub     func(useCaseMock *mock.MockOIDCUseCase)
+		checkResponse func(t *testing.T, resp *http.Response)
+	}{
+		"Successful Callback": {
+			query:  "?state=s&code=c",
+			cookie: "s",
+			buildStub: func(useCaseMock *mock.MockOIDCUseCase) {
+				useCaseMock.EXPECT().Callback(gomock.Any(), "s", "c", gomock.Any()).Times(1).Return("jwt", nil)
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+				// The state is used up, so the cookie is cleared.
+				cookies := resp.Cookies()
+				if assert.Len(t, cookies, 1) {
+					assert.Equal(t, "oidc_state", cookies[0].Name)
+					assert.Empty(t, cookies[0].Value)
+				}
+			},
+		},
+		"Provider Error": {
+			query:     "?error=access_denied&state=s",
+			buildStub: func(useCaseMock *mock.MockOIDCUseCase) {},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+			},
+		},
+		"Missing State Cookie": {
+			query:     "?state=s&code=c",
+			buildStub: func(useCaseMock *mock.MockOIDCUseCase) {},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+			},
+		},
+		"State Started In Another Browser": {
+			query:     "?state=s&code=c",
+			cookie:    "other",
+			buildStub: func(useCaseMock *mock.MockOIDCUseCase) {},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+			},
+		},
+		"Missing Code": {
+			query:     "?state=s",
+			buildStub: func(useCaseMock *mock.MockOIDCUseCase) {},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			},
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUseCase := mock.NewMockOIDCUseCase(ctrl)
+			test.buildStub(mockUseCase)
+			oidcHandler := handlers.NewOIDCHandler(mockUseCase)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Get("/oidc/callback", oidcHandler.Callback)
+
+			req := httptest.NewRequest("GET", "/oidc/callback"+test.query, nil)
+			if test.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "oidc_state", Value: test.cookie})
+			}
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			test.checkResponse(t, resp)
+		})
+	}
+}
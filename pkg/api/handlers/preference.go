@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"taskmanagementapi/pkg/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PreferenceHandler struct {
+	PreferenceUseCase services.PreferenceUseCase
+}
+
+func NewPreferenceHandler(useCase services.PreferenceUseCase) *PreferenceHandler {
+	return &PreferenceHandler{
+		PreferenceUseCase: useCase,
+	}
+}
+
+func (ph *PreferenceHandler) GetPreferences(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	preferences, err := ph.PreferenceUseCase.GetPreferences(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Get Preferences", "data": preferences})
+}
+
+func (ph *PreferenceHandler) UpdatePreferences(c *fiber.Ctx) error {
+	var preferences models.Preferences
+	if err := c.BodyParser(&preferences); err != nil {
+		return errInvalidBody
+	}
+	err := validation.Struct(preferences)
+	if err != nil {
+		return err
+	}
+	userID := c.Locals("user_id").(string)
+	updated, err := ph.PreferenceUseCase.UpdatePreferences(c.UserContext(), userID, preferences)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Preferences updated", "data": updated})
+}
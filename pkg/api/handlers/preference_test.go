@@ -0,0 +1,77 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"taskmanagementapi/pkg/api/handlers"
+	"taskmanagementapi/pkg/usecase/mock"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PreferenceHandlers(t *testing.T) {
+	valid := models.DefaultPreferences()
+	invalid := models.DefaultPreferences()
+	invalid.WeekStart = "friday"
+
+	testCases := map[string]struct {
+		method     string
+		input      interface{}
+		buildStub  func(useCaseMock *mock.MockPreferenceUseCase)
+		wantStatus int
+	}{
+		"get preferences": {
+			method: "GET",
+			buildStub: func(useCaseMock *mock.MockPreferenceUseCase) {
+				useCaseMock.EXPECT().GetPreferences(gomock.Any(), "user1").Return(valid, nil).Times(1)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"update preferences": {
+			method: "PUT",
+			input:  valid,
+			buildStub: func(useCaseMock *mock.MockPreferenceUseCase) {
+				useCaseMock.EXPECT().UpdatePreferences(gomock.Any(), "user1", valid).Return(valid, nil).Times(1)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"invalid week start": {
+			method:     "PUT",
+			input:      invalid,
+			buildStub:  func(useCaseMock *mock.MockPreferenceUseCase) {},
+			wantStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUseCase := mock.NewMockPreferenceUseCase(ctrl)
+			test.buildStub(mockUseCase)
+			preferenceHandler := handlers.NewPreferenceHandler(mockUseCase)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Use(func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user1")
+				return c.Next()
+			})
+			app.Get("/preferences", preferenceHandler.GetPreferences)
+			app.Put("/preferences", preferenceHandler.UpdatePreferences)
+
+			jsonData, err := json.Marshal(test.input)
+			assert.NoError(t, err)
+			req := httptest.NewRequest(test.method, "/preferences", bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SessionHandler struct {
+	SessionUseCase services.SessionUseCase
+}
+
+func NewSessionHandler(useCase services.SessionUseCase) *SessionHandler {
+	return &SessionHandler{
+		SessionUseCase: useCase,
+	}
+}
+
+func clientInfo(c *fiber.Ctx) models.ClientInfo {
+	return models.ClientInfo{
+		IP:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+}
+
+func (sh *SessionHandler) GetSessions(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	jti, _ := c.Locals("jti").(string)
+	sessions, err := sh.SessionUseCase.GetSessions(c.UserContext(), userID, jti)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Get Sessions", "data": sessions})
+}
+
+// SignOut revokes the session of the JWT the request was made with and clears
+// the cookie.
+func (sh *SessionHandler) SignOut(c *fiber.Ctx) error {
+	jti, _ := c.Locals("jti").(string)
+	if err := sh.SessionUseCase.SignOut(c.UserContext(), jti); err != nil {
+		return err
+	}
+	c.Cookie(&fiber.Cookie{
+		Name:     "Authorization",
+		Value:    "",
+		Expires:  time.Now().Add(-1 * time.Hour),
+		HTTPOnly: true,
+		Secure:   true,
+	})
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "User signout successful"})
+}
+
+func (sh *SessionHandler) RevokeSession(c *fiber.Ctx) error {
+	sessionID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+	err := sh.SessionUseCase.RevokeSession(c.UserContext(), userID, sessionID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Revoked Session"})
+}
@@ -0,0 +1,81 @@
+package handlers_test
+
+import (
+	"net/http/httptest"
+	"taskmanagementapi/pkg/api/handlers"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/usecase/mock"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SessionHandlers(t *testing.T) {
+	testCases := map[string]struct {
+		method     string
+		path       string
+		buildStub  func(useCaseMock *mock.MockSessionUseCase)
+		wantStatus int
+	}{
+		"list sessions": {
+			method: "GET",
+			path:   "/sessions",
+			buildStub: func(useCaseMock *mock.MockSessionUseCase) {
+				useCaseMock.EXPECT().GetSessions(gomock.Any(), "user1", "jti1").Return([]models.Session{{ID: "s1", Current: true}}, nil).Times(1)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"revoke session": {
+			method: "DELETE",
+			path:   "/sessions/s1",
+			buildStub: func(useCaseMock *mock.MockSessionUseCase) {
+				useCaseMock.EXPECT().RevokeSession(gomock.Any(), "user1", "s1").Return(nil).Times(1)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"sign out": {
+			method: "POST",
+			path:   "/signout",
+			buildStub: func(useCaseMock *mock.MockSessionUseCase) {
+				useCaseMock.EXPECT().SignOut(gomock.Any(), "jti1").Return(nil).Times(1)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"revoke unknown session": {
+			method: "DELETE",
+			path:   "/sessions/s2",
+			buildStub: func(useCaseMock *mock.MockSessionUseCase) {
+				useCaseMock.EXPECT().RevokeSession(gomock.Any(), "user1", "s2").Return(services.NotFound("session_not_found", "session doesn't exist")).Times(1)
+			},
+			wantStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUseCase := mock.NewMockSessionUseCase(ctrl)
+			test.buildStub(mockUseCase)
+			sessionHandler := handlers.NewSessionHandler(mockUseCase)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Use(func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user1")
+				c.Locals("jti", "jti1")
+				return c.Next()
+			})
+			app.Get("/sessions", sessionHandler.GetSessions)
+			app.Delete("/sessions/:id", sessionHandler.RevokeSession)
+			app.Post("/signout", sessionHandler.SignOut)
+
+			resp, err := app.Test(httptest.NewRequest(test.method, test.path, nil), -1)
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+		})
+	}
+}
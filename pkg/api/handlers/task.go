@@ -1,10 +1,11 @@
 package handlers
 
 import (
+	"strings"
 	services "taskmanagementapi/pkg/usecase/interface"
 	"taskmanagementapi/pkg/utils/models"
+	"taskmanagementapi/pkg/validation"
 
-	"github.com/go-playground/validator"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -21,25 +22,61 @@ func NewTaskHandler(useCase services.TaskUseCase) *TaskHandler {
 func (tk *TaskHandler) CreateTask(c *fiber.Ctx) error {
 	var task models.CreateTask
 	if err := c.BodyParser(&task); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+		return errInvalidBody
 	}
-	err := validator.New().Struct(task)
+	err := validation.Struct(task)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Constraints not statisfied"})
+		return err
 	}
 	userID := c.Locals("user_id").(string)
-	err = tk.TaskUseCase.CreateTask(task, userID)
+	taskID, err := tk.TaskUseCase.CreateTask(c.UserContext(), task, userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Task creation failed", "message": err.Error()})
+		return err
 	}
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Task created"})
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Task created", "data": models.CreatedTask{ID: taskID}})
+}
+
+func (tk *TaskHandler) BulkTasks(c *fiber.Ctx) error {
+	var bulk models.BulkTaskRequest
+	if err := c.BodyParser(&bulk); err != nil {
+		return errInvalidBody
+	}
+	err := validation.Struct(bulk)
+	if err != nil {
+		return err
+	}
+	userID := c.Locals("user_id").(string)
+	results, err := tk.TaskUseCase.BulkTasks(c.UserContext(), userID, bulk)
+	if err != nil {
+		return err
+	}
+	for i, result := range results {
+		switch {
+		case result.Err != nil:
+			problem := problemFor(result.Err)
+			results[i].Status, results[i].Error = problem.Status, &problem
+		case result.Op == models.BulkCreate:
+			results[i].Status = fiber.StatusCreated
+		default:
+			results[i].Status = fiber.StatusOK
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Bulk operations processed", "data": results})
 }
 
 func (tk *TaskHandler) GetTasks(c *fiber.Ctx) error {
+	var query models.TaskQuery
+	if err := c.QueryParser(&query); err != nil {
+		return errInvalidQuery
+	}
+	err := validation.Struct(query)
+	if err != nil {
+		return err
+	}
 	userID := c.Locals("user_id").(string)
-	tasks, err := tk.TaskUseCase.GetTasks(userID)
+	tasks, err := tk.TaskUseCase.GetTasks(c.UserContext(), userID, query)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Tasks Retrieve failed", "message": err.Error()})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Get Tasks", "data": tasks})
 }
@@ -47,9 +84,9 @@ func (tk *TaskHandler) GetTasks(c *fiber.Ctx) error {
 func (tk *TaskHandler) GetTask(c *fiber.Ctx) error {
 	taskID := c.Params("id")
 	userID := c.Locals("user_id").(string)
-	task, err := tk.TaskUseCase.GetTask(userID, taskID)
+	task, err := tk.TaskUseCase.GetTask(c.UserContext(), userID, taskID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Task Retrieve failed", "message": err.Error()})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Get Task", "data": task})
 }
@@ -59,25 +96,40 @@ func (tk *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(string)
 	var task models.CreateTask
 	if err := c.BodyParser(&task); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+		return errInvalidBody
 	}
-	err := validator.New().Struct(task)
+	err := validation.Struct(task)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Constraints not statisfied"})
+		return err
 	}
-	err = tk.TaskUseCase.UpdateTask(userID, taskID, task)
+	err = tk.TaskUseCase.UpdateTask(c.UserContext(), userID, taskID, task)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Task Update failed", "message": err.Error()})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Update Task"})
 }
 
+func (tk *TaskHandler) PatchTask(c *fiber.Ctx) error {
+	taskID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0]))
+	if contentType != models.MergePatchContentType && contentType != models.JSONPatchContentType {
+		c.Set("Accept-Patch", models.MergePatchContentType+", "+models.JSONPatchContentType)
+		return fiber.NewError(fiber.StatusUnsupportedMediaType, "patch must be "+models.MergePatchContentType+" or "+models.JSONPatchContentType)
+	}
+	task, err := tk.TaskUseCase.PatchTask(c.UserContext(), userID, taskID, models.TaskPatch{ContentType: contentType, Patch: c.Body()})
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Patched Task", "data": task})
+}
+
 func (tk *TaskHandler) DeleteTask(c *fiber.Ctx) error {
 	taskID := c.Params("id")
 	userID := c.Locals("user_id").(string)
-	err := tk.TaskUseCase.DeleteTask(userID, taskID)
+	err := tk.TaskUseCase.DeleteTask(c.UserContext(), userID, taskID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Task Delete failed", "message": err.Error()})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Deleted Task"})
 }
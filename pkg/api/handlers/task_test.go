@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"taskmanagementapi/pkg/api/handlers"
+	services "taskmanagementapi/pkg/usecase/interface"
 	"taskmanagementapi/pkg/usecase/mock"
 	"taskmanagementapi/pkg/utils/models"
 	"testing"
@@ -33,10 +34,15 @@ func Test_CreateTask(t *testing.T) {
             },
             userID: "1",
             buildStub: func(useCaseMock *mock.MockTaskUseCase, task models.CreateTask, userID string) {
-                useCaseMock.EXPECT().CreateTask(task, userID).Times(1).Return(nil)
+                useCaseMock.EXPECT().CreateTask(gomock.Any(), task, userID).Times(1).Return("t1", nil)
             },
             checkResponse: func(t *testing.T, resp *http.Response) {
                 assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+                var body struct {
+                    Data models.CreatedTask `json:"data"`
+                }
+                require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+                assert.Equal(t, "t1", body.Data.ID)
             },
         },
         "Invalid Task Input": {
@@ -58,7 +64,7 @@ func Test_CreateTask(t *testing.T) {
             },
             userID: "1",
             buildStub: func(useCaseMock *mock.MockTaskUseCase, task models.CreateTask, userID string) {
-                useCaseMock.EXPECT().CreateTask(task, userID).Times(1).Return(errors.New("task creation failed"))
+                useCaseMock.EXPECT().CreateTask(gomock.Any(), task, userID).Times(1).Return("", errors.New("task creation failed"))
             },
             checkResponse: func(t *testing.T, resp *http.Response) {
                 assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
@@ -78,7 +84,7 @@ func Test_CreateTask(t *testing.T) {
 
             taskHandler := handlers.NewTaskHandler(mockUseCase)
 
-            app := fiber.New()
+            app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
             app.Post("/task", func(c *fiber.Ctx) error {
                 c.Locals("user_id", test.userID)
                 return taskHandler.CreateTask(c)
@@ -97,6 +103,81 @@ func Test_CreateTask(t *testing.T) {
 }
 
 
+func Test_BulkTasks(t *testing.T) {
+	testCases := map[string]struct {
+		body          string
+		buildStub     func(useCaseMock *mock.MockTaskUseCase)
+		checkResponse func(t *testing.T, resp *http.Response)
+	}{
+		"Per Item Results": {
+			body: `{"operations":[{"op":"create","task":{"title":"Task","description":"Description"}},{"op":"delete","id":"2"},{"op":"update","id":"3"}]}`,
+			buildStub: func(useCaseMock *mock.MockTaskUseCase) {
+				useCaseMock.EXPECT().BulkTasks(gomock.Any(), "1", gomock.Any()).Times(1).Return([]models.BulkTaskResult{
+					{Index: 0, Op: models.BulkCreate, ID: "new"},
+					{Index: 1, Op: models.BulkDelete, ID: "2", Err: services.NotFound("task_not_found", "task doesn't exist")},
+					{Index: 2, Op: models.BulkUpdate, ID: "3", Err: services.InvalidInput("invalid_fields", "one or more fields are invalid")},
+				}, nil)
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+				var body struct {
+					Data []models.BulkTaskResult `json:"data"`
+				}
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				require.Len(t, body.Data, 3)
+				assert.Equal(t, fiber.StatusCreated, body.Data[0].Status)
+				assert.Equal(t, "new", body.Data[0].ID)
+				assert.Nil(t, body.Data[0].Error)
+				assert.Equal(t, fiber.StatusNotFound, body.Data[1].Status)
+				assert.Equal(t, "task_not_found", body.Data[1].Error.Code)
+				assert.Equal(t, fiber.StatusBadRequest, body.Data[2].Status)
+			},
+		},
+		"No Operations": {
+			body:      `{"operations":[]}`,
+			buildStub: func(useCaseMock *mock.MockTaskUseCase) {},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			},
+		},
+		"Request Failure": {
+			body: `{"operations":[{"op":"delete","id":"2"}]}`,
+			buildStub: func(useCaseMock *mock.MockTaskUseCase) {
+				useCaseMock.EXPECT().BulkTasks(gomock.Any(), "1", gomock.Any()).Times(1).Return(nil, errors.New("error from bulk write tasks"))
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+			},
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mock.NewMockTaskUseCase(ctrl)
+			test.buildStub(mockUseCase)
+
+			taskHandler := handlers.NewTaskHandler(mockUseCase)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Post("/task/bulk", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "1")
+				return taskHandler.BulkTasks(c)
+			})
+
+			req := httptest.NewRequest("POST", "/task/bulk", bytes.NewBufferString(test.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			test.checkResponse(t, resp)
+		})
+	}
+}
+
 func Test_GetTasks(t *testing.T) {
 	testCases := map[string]struct {
 		userID        string
@@ -107,7 +188,7 @@ func Test_GetTasks(t *testing.T) {
 			userID: "1",
 			buildStub: func(useCaseMock *mock.MockTaskUseCase, userID string) {
 				createdAt, _ := time.Parse(time.RFC3339, "2024-10-08T00:28:52+05:30")
-				useCaseMock.EXPECT().GetTasks(userID).Times(1).Return([]models.TaskDetails{
+				useCaseMock.EXPECT().GetTasks(gomock.Any(), userID, models.TaskQuery{}).Times(1).Return([]models.TaskDetails{
 					{
 						ID:          "6705824f80a09eb0313f0e4",
 						Title:       "Task 1",
@@ -129,7 +210,7 @@ func Test_GetTasks(t *testing.T) {
 		"Tasks Retrieval Failure": {
 			userID: "6705824f80a09eb0313f0e42",
 			buildStub: func(useCaseMock *mock.MockTaskUseCase, userID string) {
-				useCaseMock.EXPECT().GetTasks(userID).Times(1).Return(nil, errors.New("failed to get tasks"))
+				useCaseMock.EXPECT().GetTasks(gomock.Any(), userID, models.TaskQuery{}).Times(1).Return(nil, errors.New("failed to get tasks"))
 			},
 			checkResponse: func(t *testing.T, resp *http.Response) {
 				assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
@@ -149,7 +230,7 @@ func Test_GetTasks(t *testing.T) {
 
 			taskHandler := handlers.NewTaskHandler(mockUseCase)
 
-			app := fiber.New()
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
 			app.Get("/tasks", func(c *fiber.Ctx) error {
 				c.Locals("user_id", test.userID)
 				return taskHandler.GetTasks(c)
@@ -164,6 +245,39 @@ func Test_GetTasks(t *testing.T) {
 		})
 	}
 }
+func Test_GetTasksQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUseCase := mock.NewMockTaskUseCase(ctrl)
+	taskHandler := handlers.NewTaskHandler(mockUseCase)
+
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	app.Get("/tasks", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user1")
+		return taskHandler.GetTasks(c)
+	})
+
+	mockUseCase.EXPECT().GetTasks(gomock.Any(), "user1", models.TaskQuery{Sort: "title", Page: 2, Limit: 5, Project: "home"}).Times(1).Return([]models.TaskDetails{}, nil)
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks?sort=title&page=2&limit=5&project=home", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/tasks?sort=priority", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/tasks?limit=1000", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/tasks?page=two", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	var problem models.Problem
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, "invalid_query", problem.Code)
+}
+
 func Test_GetTask(t *testing.T) {
     testCases := map[string]struct {
         userID        string
@@ -176,7 +290,7 @@ func Test_GetTask(t *testing.T) {
             taskID: "6705824f80a09eb0313f0e4",
             buildStub: func(useCaseMock *mock.MockTaskUseCase, userID, taskID string) {
                 createdAt, _ := time.Parse(time.RFC3339, "2024-10-08T00:28:52+05:30")
-                useCaseMock.EXPECT().GetTask(userID, taskID).Times(1).Return(models.TaskDetails{
+                useCaseMock.EXPECT().GetTask(gomock.Any(), userID, taskID).Times(1).Return(models.TaskDetails{
                     ID:          taskID,
                     Title:       "Task 1",
                     Description: "This is task 1",
@@ -187,11 +301,24 @@ func Test_GetTask(t *testing.T) {
                 assert.Equal(t, fiber.StatusOK, resp.StatusCode)
             },
         },
+        "Task Not Found": {
+            userID: "6705824f80a09eb0313f0e42",
+            taskID: "6705824f80a09eb0313f0e4",
+            buildStub: func(useCaseMock *mock.MockTaskUseCase, userID, taskID string) {
+                useCaseMock.EXPECT().GetTask(gomock.Any(), userID, taskID).Times(1).Return(models.TaskDetails{}, services.NotFound("task_not_found", "task doesn't exist"))
+            },
+            checkResponse: func(t *testing.T, resp *http.Response) {
+                assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+                var problem models.Problem
+                require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+                assert.Equal(t, "task_not_found", problem.Code)
+            },
+        },
         "Task Retrieval Failure": {
             userID: "6705824f80a09eb0313f0e42",
             taskID: "6705824f80a09eb0313f0e4",
             buildStub: func(useCaseMock *mock.MockTaskUseCase, userID, taskID string) {
-                useCaseMock.EXPECT().GetTask(userID, taskID).Times(1).Return(models.TaskDetails{}, errors.New("failed to get task"))
+                useCaseMock.EXPECT().GetTask(gomock.Any(), userID, taskID).Times(1).Return(models.TaskDetails{}, errors.New("failed to get task"))
             },
             checkResponse: func(t *testing.T, resp *http.Response) {
                 assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
@@ -211,7 +338,7 @@ func Test_GetTask(t *testing.T) {
 
             taskHandler := handlers.NewTaskHandler(mockUseCase)
 
-            app := fiber.New()
+            app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
             app.Get("/tasks/:id", func(c *fiber.Ctx) error {
                 c.Locals("user_id", test.userID)
                 return taskHandler.GetTask(c)
@@ -244,7 +371,7 @@ func Test_UpdateTask(t *testing.T) {
 				Description: "This is an updated task.",
 			},
 			buildStub: func(useCaseMock *mock.MockTaskUseCase, userID, taskID string, task models.CreateTask) {
-				useCaseMock.EXPECT().UpdateTask(userID, taskID, task).Times(1).Return(nil)
+				useCaseMock.EXPECT().UpdateTask(gomock.Any(), userID, taskID, task).Times(1).Return(nil)
 			},
 			checkResponse: func(t *testing.T, resp *http.Response) {
 				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
@@ -271,7 +398,7 @@ func Test_UpdateTask(t *testing.T) {
 				Description: "This is an updated task.",
 			},
 			buildStub: func(useCaseMock *mock.MockTaskUseCase, userID, taskID string, task models.CreateTask) {
-				useCaseMock.EXPECT().UpdateTask(userID, taskID, task).Times(1).Return(errors.New("update failed"))
+				useCaseMock.EXPECT().UpdateTask(gomock.Any(), userID, taskID, task).Times(1).Return(errors.New("update failed"))
 			},
 			checkResponse: func(t *testing.T, resp *http.Response) {
 				assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
@@ -291,7 +418,7 @@ func Test_UpdateTask(t *testing.T) {
 
 			taskHandler := handlers.NewTaskHandler(mockUseCase)
 
-			app := fiber.New()
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
 			app.Put("/task/:id", func(c *fiber.Ctx) error {
 				c.Locals("user_id", test.userID)
 				return taskHandler.UpdateTask(c)
@@ -310,6 +437,89 @@ func Test_UpdateTask(t *testing.T) {
 	}
 }
 
+func Test_PatchTask(t *testing.T) {
+	testCases := map[string]struct {
+		contentType   string
+		body          string
+		buildStub     func(useCaseMock *mock.MockTaskUseCase, patch models.TaskPatch)
+		checkResponse func(t *testing.T, resp *http.Response)
+	}{
+		"Merge Patch": {
+			contentType: "application/merge-patch+json; charset=utf-8",
+			body:        `{"title":"New title"}`,
+			buildStub: func(useCaseMock *mock.MockTaskUseCase, patch models.TaskPatch) {
+				patch.ContentType = models.MergePatchContentType
+				useCaseMock.EXPECT().PatchTask(gomock.Any(), "1", "1", patch).Times(1).Return(models.TaskDetails{ID: "1", Title: "New title"}, nil)
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+				var body struct {
+					Data models.TaskDetails `json:"data"`
+				}
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, "New title", body.Data.Title)
+			},
+		},
+		"JSON Patch": {
+			contentType: models.JSONPatchContentType,
+			body:        `[{"op":"replace","path":"/title","value":"New title"}]`,
+			buildStub: func(useCaseMock *mock.MockTaskUseCase, patch models.TaskPatch) {
+				patch.ContentType = models.JSONPatchContentType
+				useCaseMock.EXPECT().PatchTask(gomock.Any(), "1", "1", patch).Times(1).Return(models.TaskDetails{ID: "1", Title: "New title"}, nil)
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			},
+		},
+		"Unsupported Media Type": {
+			contentType: "application/json",
+			body:        `{"title":"New title"}`,
+			buildStub:   func(useCaseMock *mock.MockTaskUseCase, patch models.TaskPatch) {},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusUnsupportedMediaType, resp.StatusCode)
+				assert.Equal(t, "application/merge-patch+json, application/json-patch+json", resp.Header.Get("Accept-Patch"))
+			},
+		},
+		"Patch Conflict": {
+			contentType: models.JSONPatchContentType,
+			body:        `[{"op":"test","path":"/title","value":"Old"}]`,
+			buildStub: func(useCaseMock *mock.MockTaskUseCase, patch models.TaskPatch) {
+				patch.ContentType = models.JSONPatchContentType
+				useCaseMock.EXPECT().PatchTask(gomock.Any(), "1", "1", patch).Times(1).Return(models.TaskDetails{}, services.Conflict("patch_conflict", "test failed"))
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+			},
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mock.NewMockTaskUseCase(ctrl)
+			test.buildStub(mockUseCase, models.TaskPatch{Patch: []byte(test.body)})
+
+			taskHandler := handlers.NewTaskHandler(mockUseCase)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Patch("/task/:id", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "1")
+				return taskHandler.PatchTask(c)
+			})
+
+			req := httptest.NewRequest("PATCH", "/task/1", bytes.NewBufferString(test.body))
+			req.Header.Set("Content-Type", test.contentType)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			test.checkResponse(t, resp)
+		})
+	}
+}
+
 func Test_DeleteTask(t *testing.T) {
 	testCases := map[string]struct {
 		userID        string
@@ -321,7 +531,7 @@ func Test_DeleteTask(t *testing.T) {
 			userID: "1",
 			taskID: "1",
 			buildStub: func(useCaseMock *mock.MockTaskUseCase, userID, taskID string) {
-				useCaseMock.EXPECT().DeleteTask(userID, taskID).Times(1).Return(nil)
+				useCaseMock.EXPECT().DeleteTask(gomock.Any(), userID, taskID).Times(1).Return(nil)
 			},
 			checkResponse: func(t *testing.T, resp *http.Response) {
 				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
@@ -331,7 +541,7 @@ func Test_DeleteTask(t *testing.T) {
 			userID: "1",
 			taskID: "1",
 			buildStub: func(useCaseMock *mock.MockTaskUseCase, userID, taskID string) {
-				useCaseMock.EXPECT().DeleteTask(userID, taskID).Times(1).Return(errors.New("task deletion failed"))
+				useCaseMock.EXPECT().DeleteTask(gomock.Any(), userID, taskID).Times(1).Return(errors.New("task deletion failed"))
 			},
 			checkResponse: func(t *testing.T, resp *http.Response) {
 				assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
@@ -351,7 +561,7 @@ func Test_DeleteTask(t *testing.T) {
 
 			taskHandler := handlers.NewTaskHandler(mockUseCase)
 
-			app := fiber.New()
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
 			app.Delete("/task/:id", func(c *fiber.Ctx) error {
 				c.Locals("user_id", test.userID)
 				return taskHandler.DeleteTask(c)
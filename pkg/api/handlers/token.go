@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"taskmanagementapi/pkg/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TokenHandler struct {
+	TokenUseCase services.TokenUseCase
+}
+
+func NewTokenHandler(useCase services.TokenUseCase) *TokenHandler {
+	return &TokenHandler{
+		TokenUseCase: useCase,
+	}
+}
+
+func (th *TokenHandler) CreateToken(c *fiber.Ctx) error {
+	var token models.CreateToken
+	if err := c.BodyParser(&token); err != nil {
+		return errInvalidBody
+	}
+	err := validation.Struct(token)
+	if err != nil {
+		return err
+	}
+	userID := c.Locals("user_id").(string)
+	created, err := th.TokenUseCase.CreateToken(c.UserContext(), userID, token)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Token created", "data": created})
+}
+
+func (th *TokenHandler) GetTokens(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	tokens, err := th.TokenUseCase.GetTokens(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Get Tokens", "data": tokens})
+}
+
+func (th *TokenHandler) RevokeToken(c *fiber.Ctx) error {
+	tokenID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+	err := th.TokenUseCase.RevokeToken(c.UserContext(), userID, tokenID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Revoked Token"})
+}
+
+func (th *TokenHandler) GetJWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.Status(fiber.StatusOK).JSON(th.TokenUseCase.GetJWKS())
+}
@@ -0,0 +1,128 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"taskmanagementapi/pkg/api/handlers"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/usecase/mock"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateToken(t *testing.T) {
+	testCases := map[string]struct {
+		input         models.CreateToken
+		buildStub     func(useCaseMock *mock.MockTokenUseCase, token models.CreateToken)
+		checkResponse func(t *testing.T, resp *http.Response)
+	}{
+		"Valid Token Creation": {
+			input: models.CreateToken{Name: "ci", Scopes: []string{models.ScopeTasksRead}},
+			buildStub: func(useCaseMock *mock.MockTokenUseCase, token models.CreateToken) {
+				useCaseMock.EXPECT().CreateToken(gomock.Any(), "1", token).Times(1).Return(models.CreatedToken{Token: "tma_x"}, nil)
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+			},
+		},
+		"Unknown Scope": {
+			input: models.CreateToken{Name: "ci", Scopes: []string{"admin"}},
+			buildStub: func(useCaseMock *mock.MockTokenUseCase, token models.CreateToken) {
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			},
+		},
+		"Token Creation Failure": {
+			input: models.CreateToken{Name: "ci", Scopes: []string{models.ScopeTasksWrite}},
+			buildStub: func(useCaseMock *mock.MockTokenUseCase, token models.CreateToken) {
+				useCaseMock.EXPECT().CreateToken(gomock.Any(), "1", token).Times(1).Return(models.CreatedToken{}, errors.New("failed"))
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+			},
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mock.NewMockTokenUseCase(ctrl)
+			test.buildStub(mockUseCase, test.input)
+			tokenHandler := handlers.NewTokenHandler(mockUseCase)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Post("/tokens", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "1")
+				return tokenHandler.CreateToken(c)
+			})
+			jsonData, err := json.Marshal(test.input)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest("POST", "/tokens", bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			test.checkResponse(t, resp)
+		})
+	}
+}
+
+func Test_RevokeToken(t *testing.T) {
+	testCases := map[string]struct {
+		buildStub     func(useCaseMock *mock.MockTokenUseCase)
+		checkResponse func(t *testing.T, resp *http.Response)
+	}{
+		"Successfully Revoke Token": {
+			buildStub: func(useCaseMock *mock.MockTokenUseCase) {
+				useCaseMock.EXPECT().RevokeToken(gomock.Any(), "1", "abc").Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			},
+		},
+		"Token Revoke Failure": {
+			buildStub: func(useCaseMock *mock.MockTokenUseCase) {
+				useCaseMock.EXPECT().RevokeToken(gomock.Any(), "1", "abc").Times(1).Return(services.NotFound("token_not_found", "token doesn't exist"))
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+			},
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mock.NewMockTokenUseCase(ctrl)
+			test.buildStub(mockUseCase)
+			tokenHandler := handlers.NewTokenHandler(mockUseCase)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Delete("/tokens/:id", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "1")
+				return tokenHandler.RevokeToken(c)
+			})
+
+			req := httptest.NewRequest("DELETE", "/tokens/abc", nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			test.checkResponse(t, resp)
+		})
+	}
+}
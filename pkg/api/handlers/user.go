@@ -1,11 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"taskmanagementapi/pkg/metrics"
 	services "taskmanagementapi/pkg/usecase/interface"
 	"taskmanagementapi/pkg/utils/models"
-	"time"
+	"taskmanagementapi/pkg/validation"
 
-	"github.com/go-playground/validator"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -22,16 +23,15 @@ func NewUserHandler(useCase services.UserUseCase) *UserHandler {
 func (ur *UserHandler) UserSignUp(c *fiber.Ctx) error {
 	var user models.UserSignup
 	if err := c.BodyParser(&user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+		return errInvalidBody
 	}
-	err := validator.New().Struct(user)
+	err := validation.Struct(user)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Constraints not statisfied"})
+		return err
 	}
-	err = ur.UserUseCase.UserSignUp(user)
+	err = ur.UserUseCase.UserSignUp(c.UserContext(), user)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "User creation failed", "message": err.Error()})
-
+		return err
 	}
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "User created"})
 }
@@ -39,28 +39,83 @@ func (ur *UserHandler) UserSignUp(c *fiber.Ctx) error {
 func (ur *UserHandler) UserSignIn(c *fiber.Ctx) error {
 	var user models.UserSignIn
 	if err := c.BodyParser(&user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+		return errInvalidBody
 	}
-	err := validator.New().Struct(user)
+	err := validation.Struct(user)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Constraints not statisfied"})
+		return err
+	}
+	token, err := ur.UserUseCase.UserSignIn(c.UserContext(), user, clientInfo(c))
+	// Lockouts and internal errors are not a verdict on the password.
+	if err == nil || errors.Is(err, services.ErrInvalidCredentials) {
+		metrics.AuthAttempt(metrics.AuthPassword, err)
 	}
-	token, err := ur.UserUseCase.UserSignIn(user)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "User signIn failed", "message": err.Error()})
-
+		return err
 	}
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "User signIn Successful", "token": token})
 }
 
-func (u *UserHandler) UserSignOut(c *fiber.Ctx) error {
-	c.Cookie(&fiber.Cookie{
-		Name:     "Authorization",
-		Value:    "",
-		Expires:  time.Now().Add(-1 * time.Hour), 
-		HTTPOnly: true,
-		Secure:   true,
-	})
+func (ur *UserHandler) GetProfile(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	profile, err := ur.UserUseCase.GetProfile(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Successfully Get Profile", "data": profile})
+}
+
+func (ur *UserHandler) UpdateProfile(c *fiber.Ctx) error {
+	var update models.UpdateProfile
+	if err := c.BodyParser(&update); err != nil {
+		return errInvalidBody
+	}
+	err := validation.Struct(update)
+	if err != nil {
+		return err
+	}
+	userID := c.Locals("user_id").(string)
+	err = ur.UserUseCase.UpdateProfile(c.UserContext(), userID, update)
+	if err != nil {
+		return err
+	}
+	message := "Profile updated"
+	if update.Email != nil {
+		message = "Profile updated, confirm the new email address to complete the change"
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": message})
+}
+
+func (ur *UserHandler) VerifyEmail(c *fiber.Ctx) error {
+	var verify models.VerifyEmail
+	if err := c.BodyParser(&verify); err != nil {
+		return errInvalidBody
+	}
+	err := validation.Struct(verify)
+	if err != nil {
+		return err
+	}
+	userID := c.Locals("user_id").(string)
+	err = ur.UserUseCase.VerifyEmail(c.UserContext(), userID, verify)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Email updated"})
+}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "User signout successful"})
+func (ur *UserHandler) ChangePassword(c *fiber.Ctx) error {
+	var change models.ChangePassword
+	if err := c.BodyParser(&change); err != nil {
+		return errInvalidBody
+	}
+	err := validation.Struct(change)
+	if err != nil {
+		return err
+	}
+	userID := c.Locals("user_id").(string)
+	token, err := ur.UserUseCase.ChangePassword(c.UserContext(), userID, change, clientInfo(c))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Password changed", "token": token})
 }
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"taskmanagementapi/pkg/api/handlers"
+	services "taskmanagementapi/pkg/usecase/interface"
 	"taskmanagementapi/pkg/usecase/mock"
 	"taskmanagementapi/pkg/utils/models"
 	"testing"
@@ -29,7 +31,7 @@ func Test_UserSignUp(t *testing.T) {
 				Password: "password123",
 			},
 			buildStub: func(useCaseMock *mock.MockUserUseCase, user models.UserSignup) {
-				useCaseMock.EXPECT().UserSignUp(gomock.Any()).Times(1).Return(nil)
+				useCaseMock.EXPECT().UserSignUp(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 			checkResponse: func(t *testing.T, resp *http.Response) {
 				assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
@@ -47,6 +49,32 @@ func Test_UserSignUp(t *testing.T) {
 				assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 			},
 		},
+		"Weak Password": {
+			input: models.UserSignup{
+				Name:     "Arun C",
+				Email:    "arun@gmail.com",
+				Password: "arun",
+			},
+			buildStub: func(useCaseMock *mock.MockUserUseCase, user models.UserSignup) {
+				useCaseMock.EXPECT().UserSignUp(gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("%w: too short", services.ErrWeakPassword))
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+			},
+		},
+		"Email Taken": {
+			input: models.UserSignup{
+				Name:     "Arun C",
+				Email:    "arun@gmail.com",
+				Password: "password123",
+			},
+			buildStub: func(useCaseMock *mock.MockUserUseCase, user models.UserSignup) {
+				useCaseMock.EXPECT().UserSignUp(gomock.Any(), gomock.Any()).Times(1).Return(services.Conflict("email_taken", "user with this email is already exists"))
+			},
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+			},
+		},
 		"User Creation Failure": {
 			input: models.UserSignup{
 				Name:     "Arun C",
@@ -54,7 +82,7 @@ func Test_UserSignUp(t *testing.T) {
 				Password: "password123",
 			},
 			buildStub: func(useCaseMock *mock.MockUserUseCase, user models.UserSignup) {
-				useCaseMock.EXPECT().UserSignUp(gomock.Any()).Times(1).Return(errors.New("user creation failed"))
+				useCaseMock.EXPECT().UserSignUp(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("user creation failed"))
 			},
 			checkResponse: func(t *testing.T, resp *http.Response) {
 				assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
@@ -69,7 +97,7 @@ func Test_UserSignUp(t *testing.T) {
 			mockUseCase := mock.NewMockUserUseCase(ctrl)
 			test.buildStub(mockUseCase, test.input)
 			userHandler := handlers.NewUserHandler(mockUseCase)
-			app := fiber.New()
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
 			app.Post("/signup", userHandler.UserSignUp)
 			jsonData, err := json.Marshal(test.input)
 			assert.NoError(t, err)
@@ -95,7 +123,7 @@ func Test_UserSignIn(t *testing.T) {
                 Password: "password123",
             },
             buildStub: func(useCaseMock *mock.MockUserUseCase, user models.UserSignIn) {
-                useCaseMock.EXPECT().UserSignIn(user).Times(1).Return("mocked_jwt_token", nil)
+                useCaseMock.EXPECT().UserSignIn(gomock.Any(), user, gomock.Any()).Times(1).Return("mocked_jwt_token", nil)
             },
             checkResponse: func(t *testing.T, resp *http.Response) {
                 assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
@@ -119,13 +147,37 @@ func Test_UserSignIn(t *testing.T) {
                 Password: "wrongpassword",
             },
             buildStub: func(useCaseMock *mock.MockUserUseCase, user models.UserSignIn) {
-                useCaseMock.EXPECT().UserSignIn(user).Times(1).Return("", errors.New("user signIn failed"))
+                useCaseMock.EXPECT().UserSignIn(gomock.Any(), user, gomock.Any()).Times(1).Return("", errors.New("user signIn failed"))
             },
             checkResponse: func(t *testing.T, resp *http.Response) {
                 assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
               
             },
         },
+        "Invalid Credentials": {
+            input: models.UserSignIn{
+                Email:    "arun@gmail.com",
+                Password: "wrongpassword",
+            },
+            buildStub: func(useCaseMock *mock.MockUserUseCase, user models.UserSignIn) {
+                useCaseMock.EXPECT().UserSignIn(gomock.Any(), user, gomock.Any()).Times(1).Return("", services.ErrInvalidCredentials)
+            },
+            checkResponse: func(t *testing.T, resp *http.Response) {
+                assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+            },
+        },
+        "Account Locked": {
+            input: models.UserSignIn{
+                Email:    "arun@gmail.com",
+                Password: "wrongpassword",
+            },
+            buildStub: func(useCaseMock *mock.MockUserUseCase, user models.UserSignIn) {
+                useCaseMock.EXPECT().UserSignIn(gomock.Any(), user, gomock.Any()).Times(1).Return("", services.ErrTooManyAttempts)
+            },
+            checkResponse: func(t *testing.T, resp *http.Response) {
+                assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+            },
+        },
     }
 
     for testName, test := range testCases {
@@ -140,7 +192,7 @@ func Test_UserSignIn(t *testing.T) {
 
             userHandler := handlers.NewUserHandler(mockUseCase)
 
-            app := fiber.New()
+            app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
             app.Post("/signin", userHandler.UserSignIn)
 
             jsonData, err := json.Marshal(test.input)
@@ -157,20 +209,96 @@ func Test_UserSignIn(t *testing.T) {
 }
 
 
-func Test_UserSignOut(t *testing.T) {
-	t.Run("Successful User SignOut", func(t *testing.T) {
-		ctrl := gomock.NewController(t)
-		defer ctrl.Finish()
+func Test_ProfileHandlers(t *testing.T) {
+	name := "Akhil K"
+	badEmail := "not-an-email"
+	testCases := map[string]struct {
+		method     string
+		path       string
+		input      interface{}
+		buildStub  func(useCaseMock *mock.MockUserUseCase)
+		wantStatus int
+	}{
+		"get profile": {
+			method: "GET",
+			path:   "/me",
+			buildStub: func(useCaseMock *mock.MockUserUseCase) {
+				useCaseMock.EXPECT().GetProfile(gomock.Any(), "user1").Times(1).Return(models.UserProfile{ID: "user1"}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"update name": {
+			method: "PATCH",
+			path:   "/me",
+			input:  models.UpdateProfile{Name: &name},
+			buildStub: func(useCaseMock *mock.MockUserUseCase) {
+				useCaseMock.EXPECT().UpdateProfile(gomock.Any(), "user1", models.UpdateProfile{Name: &name}).Times(1).Return(nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"update with invalid email": {
+			method:     "PATCH",
+			path:       "/me",
+			input:      models.UpdateProfile{Email: &badEmail},
+			buildStub:  func(useCaseMock *mock.MockUserUseCase) {},
+			wantStatus: fiber.StatusBadRequest,
+		},
+		"verify email": {
+			method: "POST",
+			path:   "/me/email/verify",
+			input:  models.VerifyEmail{Token: "code"},
+			buildStub: func(useCaseMock *mock.MockUserUseCase) {
+				useCaseMock.EXPECT().VerifyEmail(gomock.Any(), "user1", models.VerifyEmail{Token: "code"}).Times(1).Return(nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"change password": {
+			method: "POST",
+			path:   "/me/password",
+			input:  models.ChangePassword{CurrentPassword: "oldpass", NewPassword: "newpass"},
+			buildStub: func(useCaseMock *mock.MockUserUseCase) {
+				useCaseMock.EXPECT().ChangePassword(gomock.Any(), "user1", gomock.Any(), gomock.Any()).Times(1).Return("token", nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		"change password with wrong current password": {
+			method: "POST",
+			path:   "/me/password",
+			input:  models.ChangePassword{CurrentPassword: "wrong", NewPassword: "newpass"},
+			buildStub: func(useCaseMock *mock.MockUserUseCase) {
+				useCaseMock.EXPECT().ChangePassword(gomock.Any(), "user1", gomock.Any(), gomock.Any()).Times(1).Return("", services.ErrInvalidCredentials)
+			},
+			wantStatus: fiber.StatusUnauthorized,
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-		mockUseCase := mock.NewMockUserUseCase(ctrl)
-		userHandler := handlers.NewUserHandler(mockUseCase)
+			mockUseCase := mock.NewMockUserUseCase(ctrl)
+			test.buildStub(mockUseCase)
+			userHandler := handlers.NewUserHandler(mockUseCase)
 
-		app := fiber.New()
-		app.Post("/signout", userHandler.UserSignOut)
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Use(func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user1")
+				return c.Next()
+			})
+			app.Get("/me", userHandler.GetProfile)
+			app.Patch("/me", userHandler.UpdateProfile)
+			app.Post("/me/email/verify", userHandler.VerifyEmail)
+			app.Post("/me/password", userHandler.ChangePassword)
 
-		req := httptest.NewRequest("POST", "/signout", nil)
-		resp, err := app.Test(req, -1)
-		assert.NoError(t, err)
-		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
-	})
+			jsonData, err := json.Marshal(test.input)
+			assert.NoError(t, err)
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+		})
+	}
 }
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RoutePrefix records the prefix an API version is mounted under, so that
+// middleware can recognise a route reached through one of its aliases.
+func RoutePrefix(prefix string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("route_prefix", prefix)
+		return c.Next()
+	}
+}
+
+// Deprecated marks responses from a deprecated API version with the
+// Deprecation (RFC 9745) and Sunset (RFC 8594) headers. When successor is set,
+// a Link header points at the same request under that prefix.
+func Deprecated(deprecation, sunset time.Time, successor string) fiber.Handler {
+	deprecationValue := fmt.Sprintf("@%d", deprecation.Unix())
+	var sunsetValue string
+	if !sunset.IsZero() {
+		sunsetValue = sunset.UTC().Format(http.TimeFormat)
+	}
+	return func(c *fiber.Ctx) error {
+		c.Set("Deprecation", deprecationValue)
+		if sunsetValue != "" {
+			c.Set("Sunset", sunsetValue)
+		}
+		if successor != "" {
+			c.Set(fiber.HeaderLink, fmt.Sprintf(`<%s%s>; rel="successor-version"`, successor, c.OriginalURL()))
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"taskmanagementapi/pkg/logging"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxIdempotencyKeyLength = 255
+
+var errInvalidIdempotencyKey = services.InvalidInput("invalid_idempotency_key", "Idempotency-Key must be at most 255 characters",
+	models.FieldError{Field: "Idempotency-Key", Rule: "max", Message: "must be at most 255 characters long"})
+
+// Idempotency replays the stored response when a request is retried with the
+// same Idempotency-Key header. Requests without the header run as usual. A
+// request that fails with an error or a 5xx releases its key so that it can
+// be retried. It must run after the auth middleware. The request is
+// identified by its path without the version prefix, so a retry through a
+// deprecated alias of the route is recognised.
+func Idempotency(useCase services.IdempotencyUseCase) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get("Idempotency-Key")
+		if key == "" {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return errInvalidIdempotencyKey
+		}
+		userID := c.Locals("user_id").(string)
+		hash := sha256.New()
+		prefix, _ := c.Locals("route_prefix").(string)
+		hash.Write([]byte(c.Method() + " " + strings.TrimPrefix(c.Path(), prefix) + "\n"))
+		hash.Write(c.Body())
+		stored, err := useCase.Begin(c.UserContext(), userID, key, hex.EncodeToString(hash.Sum(nil)))
+		if err != nil {
+			return err
+		}
+		if stored != nil {
+			c.Set("Idempotent-Replayed", "true")
+			c.Set(fiber.HeaderContentType, stored.ContentType)
+			return c.Status(stored.Status).Send(stored.Body)
+		}
+
+		log := logging.FromContext(c.UserContext())
+		if err := c.Next(); err != nil {
+			if releaseErr := useCase.Release(c.UserContext(), userID, key); releaseErr != nil {
+				log.Error("release idempotency key", "error", releaseErr)
+			}
+			return err
+		}
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			if err := useCase.Release(c.UserContext(), userID, key); err != nil {
+				log.Error("release idempotency key", "error", err)
+			}
+			return nil
+		}
+		err = useCase.Complete(c.UserContext(), userID, key, models.IdempotencyRecord{
+			Status:      status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		})
+		if err != nil {
+			// The key stays in flight until its lease runs out.
+			log.Error("complete idempotency key", "error", err)
+		}
+		return nil
+	}
+}
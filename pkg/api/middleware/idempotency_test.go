@@ -0,0 +1,153 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"taskmanagementapi/pkg/api/handlers"
+	"taskmanagementapi/pkg/api/middleware"
+	"taskmanagementapi/pkg/usecase/mock"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Idempotency(t *testing.T) {
+	testCases := map[string]struct {
+		key        string
+		handlerErr error
+		buildStub  func(useCaseMock *mock.MockIdempotencyUseCase)
+		wantStatus int
+		wantBody   string
+		wantCalled bool
+	}{
+		"no key": {
+			buildStub:  func(useCaseMock *mock.MockIdempotencyUseCase) {},
+			wantStatus: fiber.StatusCreated,
+			wantBody:   `{"id":"new"}`,
+			wantCalled: true,
+		},
+		"first request is stored": {
+			key: "k1",
+			buildStub: func(useCaseMock *mock.MockIdempotencyUseCase) {
+				useCaseMock.EXPECT().Begin(gomock.Any(), "u1", "k1", gomock.Any()).Return(nil, nil).Times(1)
+				useCaseMock.EXPECT().Complete(gomock.Any(), "u1", "k1", models.IdempotencyRecord{
+					Status:      fiber.StatusCreated,
+					ContentType: fiber.MIMEApplicationJSON,
+					Body:        []byte(`{"id":"new"}`),
+				}).Times(1)
+			},
+			wantStatus: fiber.StatusCreated,
+			wantBody:   `{"id":"new"}`,
+			wantCalled: true,
+		},
+		"retry is replayed": {
+			key: "k1",
+			buildStub: func(useCaseMock *mock.MockIdempotencyUseCase) {
+				useCaseMock.EXPECT().Begin(gomock.Any(), "u1", "k1", gomock.Any()).Return(&models.IdempotencyRecord{
+					Status:      fiber.StatusCreated,
+					ContentType: fiber.MIMEApplicationJSON,
+					Body:        []byte(`{"id":"first"}`),
+				}, nil).Times(1)
+			},
+			wantStatus: fiber.StatusCreated,
+			wantBody:   `{"id":"first"}`,
+		},
+		"failed request releases the key": {
+			key:        "k1",
+			handlerErr: errors.New("error from insert task"),
+			buildStub: func(useCaseMock *mock.MockIdempotencyUseCase) {
+				useCaseMock.EXPECT().Begin(gomock.Any(), "u1", "k1", gomock.Any()).Return(nil, nil).Times(1)
+				useCaseMock.EXPECT().Release(gomock.Any(), "u1", "k1").Times(1)
+			},
+			wantStatus: fiber.StatusInternalServerError,
+			wantCalled: true,
+		},
+		"key too long": {
+			key:        strings.Repeat("k", 256),
+			buildStub:  func(useCaseMock *mock.MockIdempotencyUseCase) {},
+			wantStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			useCaseMock := mock.NewMockIdempotencyUseCase(ctrl)
+			test.buildStub(useCaseMock)
+
+			called := false
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Post("/tasks", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "u1")
+				return c.Next()
+			}, middleware.Idempotency(useCaseMock), func(c *fiber.Ctx) error {
+				called = true
+				if test.handlerErr != nil {
+					return test.handlerErr
+				}
+				return c.Status(fiber.StatusCreated).JSON(fiber.Map{"id": "new"})
+			})
+
+			req := httptest.NewRequest(fiber.MethodPost, "/tasks", strings.NewReader(`{"title":"Task"}`))
+			if test.key != "" {
+				req.Header.Set("Idempotency-Key", test.key)
+			}
+			resp, err := app.Test(req, -1)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+			assert.Equal(t, test.wantCalled, called)
+			if test.wantBody != "" {
+				body, _ := io.ReadAll(resp.Body)
+				assert.JSONEq(t, test.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func Test_IdempotencyFingerprintIgnoresVersionPrefix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	useCaseMock := mock.NewMockIdempotencyUseCase(ctrl)
+	var fingerprints []string
+	useCaseMock.EXPECT().Begin(gomock.Any(), "u1", "k1", gomock.Any()).DoAndReturn(func(_ context.Context, userID, key, fingerprint string) (*models.IdempotencyRecord, error) {
+		fingerprints = append(fingerprints, fingerprint)
+		return nil, nil
+	}).Times(3)
+	useCaseMock.EXPECT().Complete(gomock.Any(), "u1", "k1", gomock.Any()).Return(nil).Times(3)
+
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+	for _, prefix := range []string{"/v1", ""} {
+		app.Post(prefix+"/tasks", middleware.RoutePrefix(prefix), func(c *fiber.Ctx) error {
+			c.Locals("user_id", "u1")
+			return c.Next()
+		}, middleware.Idempotency(useCaseMock), func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusCreated)
+		})
+	}
+	app.Post("/v1/other", middleware.RoutePrefix("/v1"), func(c *fiber.Ctx) error {
+		c.Locals("user_id", "u1")
+		return c.Next()
+	}, middleware.Idempotency(useCaseMock), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	for _, path := range []string{"/v1/tasks", "/tasks", "/v1/other"} {
+		req := httptest.NewRequest(fiber.MethodPost, path, strings.NewReader(`{"title":"Task"}`))
+		req.Header.Set("Idempotency-Key", "k1")
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	}
+	require.Len(t, fingerprints, 3)
+	assert.Equal(t, fingerprints[0], fingerprints[1])
+	assert.NotEqual(t, fingerprints[0], fingerprints[2])
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"taskmanagementapi/pkg/logging"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const maxRequestIDLength = 128
+
+// Logging gives each request an ID, taken from X-Request-ID when the client or
+// proxy sent a usable one, and echoes it on the response. The request's user
+// context carries a logger tagged with the ID for the layers below, and one
+// access log line is written once the response is ready.
+func Logging(logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		requestID := c.Get(fiber.HeaderXRequestID)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		c.Set(fiber.HeaderXRequestID, requestID)
+		c.Locals("request_id", requestID)
+		requestLogger := logger.With("request_id", requestID)
+		if span := trace.SpanContextFromContext(c.UserContext()); span.IsValid() {
+			requestLogger = requestLogger.With("trace_id", span.TraceID().String())
+		}
+		c.SetUserContext(logging.WithContext(c.UserContext(), requestLogger))
+
+		// Render errors here rather than in the app so that the status
+		// they map to is logged.
+		chainErr := c.Next()
+		if chainErr != nil {
+			if err := c.App().Config().ErrorHandler(c, chainErr); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		attrs := []any{
+			"method", c.Method(),
+			"path", c.Path(),
+			"route", c.Route().Path,
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"ip", c.IP(),
+		}
+		log := logging.FromContext(c.UserContext())
+		if status >= fiber.StatusInternalServerError {
+			log.Error("request", append(attrs, "error", chainErr)...)
+		} else {
+			log.Info("request", attrs...)
+		}
+		return nil
+	}
+}
+
+// validRequestID only accepts visible ASCII so that a client cannot forge
+// log fields or response headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"taskmanagementapi/pkg/api/handlers"
+	"taskmanagementapi/pkg/api/middleware"
+	"taskmanagementapi/pkg/logging"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Logging(t *testing.T) {
+	testCases := map[string]struct {
+		requestID  string
+		path       string
+		wantID     string
+		wantStatus int
+		wantLevel  string
+	}{
+		"propagates request ID": {requestID: "abc-123", path: "/tasks/1", wantID: "abc-123", wantStatus: fiber.StatusOK, wantLevel: "INFO"},
+		"generates request ID":  {path: "/tasks/1", wantStatus: fiber.StatusOK, wantLevel: "INFO"},
+		"replaces unsafe ID":    {requestID: "a b", path: "/tasks/1", wantStatus: fiber.StatusOK, wantLevel: "INFO"},
+		"logs mapped status":    {requestID: "abc-123", path: "/missing", wantID: "abc-123", wantStatus: fiber.StatusNotFound, wantLevel: "INFO"},
+		"logs server errors":    {requestID: "abc-123", path: "/fail", wantID: "abc-123", wantStatus: fiber.StatusInternalServerError, wantLevel: "ERROR"},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := logging.New(&buf, "info")
+			require.NoError(t, err)
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Use(middleware.Logging(logger))
+			app.Use(func(c *fiber.Ctx) error {
+				c.SetUserContext(logging.With(c.UserContext(), "user_id", "u1"))
+				return c.Next()
+			})
+			app.Get("/tasks/:id", func(c *fiber.Ctx) error {
+				logging.FromContext(c.UserContext()).Info("handler")
+				return c.SendStatus(fiber.StatusOK)
+			})
+			app.Get("/fail", func(c *fiber.Ctx) error {
+				return fiber.ErrInternalServerError
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, test.path, nil)
+			if test.requestID != "" {
+				req.Header.Set(fiber.HeaderXRequestID, test.requestID)
+			}
+			resp, err := app.Test(req, -1)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+
+			requestID := resp.Header.Get(fiber.HeaderXRequestID)
+			if test.wantID != "" {
+				assert.Equal(t, test.wantID, requestID)
+			} else {
+				assert.Len(t, requestID, 32)
+			}
+
+			lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+			var access map[string]any
+			require.NoError(t, json.Unmarshal(lines[len(lines)-1], &access))
+			assert.Equal(t, "request", access["msg"])
+			assert.Equal(t, test.wantLevel, access["level"])
+			assert.Equal(t, requestID, access["request_id"])
+			assert.Equal(t, "u1", access["user_id"])
+			assert.Equal(t, test.path, access["path"])
+			assert.Equal(t, float64(test.wantStatus), access["status"])
+			for _, line := range lines[:len(lines)-1] {
+				var entry map[string]any
+				require.NoError(t, json.Unmarshal(line, &entry))
+				assert.Equal(t, requestID, entry["request_id"])
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"taskmanagementapi/pkg/metrics"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics records the status and duration of each request by route. It must
+// run before Logging, which renders errors into the status recorded here.
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		metrics.ObserveRequest(c.Method(), c.Route().Path, c.Response().StatusCode(), time.Since(start))
+		return err
+	}
+}
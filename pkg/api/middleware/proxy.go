@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ForwardedFor reduces X-Forwarded-For to the client address before Fiber
+// reads it. Each proxy appends the address it received the request from, so
+// only the entries the trusted proxies added can be believed: the client is
+// the rightmost address that is not one of them, and anything to its left was
+// sent by the client. proxies are the already validated TRUSTED_PROXIES
+// entries.
+func ForwardedFor(proxies []string) fiber.Handler {
+	var trusted []*net.IPNet
+	for _, proxy := range proxies {
+		if _, ipNet, err := net.ParseCIDR(proxy); err == nil {
+			trusted = append(trusted, ipNet)
+		} else if ip := net.ParseIP(proxy); ip != nil {
+			trusted = append(trusted, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+		}
+	}
+	isTrusted := func(ip net.IP) bool {
+		for _, ipNet := range trusted {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	return func(c *fiber.Ctx) error {
+		header := &c.Request().Header
+		values := header.PeekAll(fiber.HeaderXForwardedFor)
+		if len(values) == 0 || !c.IsProxyTrusted() {
+			return c.Next()
+		}
+		var entries []string
+		for _, value := range values {
+			entries = append(entries, strings.Split(string(value), ",")...)
+		}
+		client := ""
+		for i := len(entries) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(entries[i]))
+			if ip == nil {
+				// Nothing left of an entry the proxies did not write can
+				// be trusted.
+				break
+			}
+			client = ip.String()
+			if !isTrusted(ip) {
+				break
+			}
+		}
+		header.Del(fiber.HeaderXForwardedFor)
+		if client != "" {
+			header.Set(fiber.HeaderXForwardedFor, client)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var errRateLimited = services.TooManyRequests("rate_limited", "too many requests, try again later")
+
+// RateLimit takes one token per request from the bucket that key picks and
+// rejects the request with 429 and Retry-After once it is empty. Responses
+// carry the RateLimit-* headers from the IETF RateLimit header fields draft.
+func RateLimit(useCase services.RateLimitUseCase, limit models.RateLimit, key func(*fiber.Ctx) string) fiber.Handler {
+	if !limit.Enabled() {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+	policy := fmt.Sprintf("%d;w=%s", limit.Burst, seconds(time.Duration(limit.Burst)*limit.Every))
+	return func(c *fiber.Ctx) error {
+		result := useCase.Allow(c.UserContext(), key(c), limit)
+		if result.Limit > 0 {
+			c.Set("RateLimit-Policy", policy)
+			c.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			c.Set("RateLimit-Reset", seconds(result.Reset))
+		}
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, seconds(result.RetryAfter))
+			return errRateLimited
+		}
+		return c.Next()
+	}
+}
+
+// ByUser keys buckets by the authenticated user, so it must run after the
+// auth middleware.
+func ByUser(scope string) func(*fiber.Ctx) string {
+	return func(c *fiber.Ctx) string {
+		return scope + ":user:" + c.Locals("user_id").(string)
+	}
+}
+
+func ByIP(scope string) func(*fiber.Ctx) string {
+	return func(c *fiber.Ctx) string {
+		return scope + ":ip:" + c.IP()
+	}
+}
+
+// seconds rounds up so that clients never retry too early.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,84 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"taskmanagementapi/pkg/api/handlers"
+	"taskmanagementapi/pkg/api/middleware"
+	"taskmanagementapi/pkg/usecase/mock"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RateLimit(t *testing.T) {
+	limit := models.RateLimit{Burst: 10, Every: 6 * time.Second}
+	testCases := map[string]struct {
+		result      models.RateLimitResult
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		"allowed": {
+			result:     models.RateLimitResult{Allowed: true, Limit: 10, Remaining: 9, Reset: 6 * time.Second},
+			wantStatus: fiber.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Policy":    "10;w=60",
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "9",
+				"RateLimit-Reset":     "6",
+				"Retry-After":         "",
+			},
+		},
+		"rejected": {
+			result:     models.RateLimitResult{Limit: 10, Reset: 59 * time.Second, RetryAfter: 1500 * time.Millisecond},
+			wantStatus: fiber.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				"RateLimit-Remaining": "0",
+				"Retry-After":         "2",
+			},
+		},
+		"store unavailable": {
+			result:      models.RateLimitResult{Allowed: true},
+			wantStatus:  fiber.StatusOK,
+			wantHeaders: map[string]string{"RateLimit-Limit": ""},
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			useCaseMock := mock.NewMockRateLimitUseCase(ctrl)
+			useCaseMock.EXPECT().Allow(gomock.Any(), "signin:ip:0.0.0.0", limit).Return(test.result).Times(1)
+
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Get("/", middleware.RateLimit(useCaseMock, limit, middleware.ByIP("signin")), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), -1)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+			for header, want := range test.wantHeaders {
+				assert.Equal(t, want, resp.Header.Get(header), header)
+			}
+		})
+	}
+}
+
+func Test_RateLimitDisabled(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", middleware.RateLimit(nil, models.PerMinute(10, 0), middleware.ByIP("signin")), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("RateLimit-Limit"))
+}
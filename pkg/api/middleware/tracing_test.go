@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"taskmanagementapi/pkg/api/handlers"
+	"taskmanagementapi/pkg/api/middleware"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testCases := map[string]struct {
+		path        string
+		traceparent string
+		wantName    string
+		wantStatus  int
+		wantError   bool
+		wantTraceID string
+	}{
+		"continues caller trace": {path: "/tasks/1", traceparent: traceparent, wantName: "GET /tasks/:id", wantStatus: fiber.StatusOK, wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		"starts new trace":       {path: "/tasks/1", wantName: "GET /tasks/:id", wantStatus: fiber.StatusOK},
+		"client error":           {path: "/missing", traceparent: traceparent, wantName: "GET /", wantStatus: fiber.StatusNotFound, wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		"server error":           {path: "/fail", wantName: "GET /fail", wantStatus: fiber.StatusInternalServerError, wantError: true},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			recorder.Reset()
+			var handlerTraceID trace.TraceID
+			app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
+			app.Use(middleware.Tracing(), middleware.Logging(slog.New(slog.NewJSONHandler(io.Discard, nil))))
+			app.Get("/tasks/:id", func(c *fiber.Ctx) error {
+				handlerTraceID = trace.SpanContextFromContext(c.UserContext()).TraceID()
+				return c.SendStatus(fiber.StatusOK)
+			})
+			app.Get("/fail", func(c *fiber.Ctx) error {
+				return fiber.ErrInternalServerError
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, test.path, nil)
+			if test.traceparent != "" {
+				req.Header.Set("traceparent", test.traceparent)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, test.wantName, span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", test.wantStatus))
+			if test.wantError {
+				assert.Equal(t, codes.Error, span.Status().Code)
+			} else {
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			}
+			if test.wantTraceID != "" {
+				assert.Equal(t, test.wantTraceID, span.SpanContext().TraceID().String())
+				assert.True(t, span.Parent().IsRemote())
+			} else {
+				assert.False(t, span.Parent().IsValid())
+			}
+			if test.path == "/tasks/1" {
+				assert.Equal(t, span.SpanContext().TraceID(), handlerTraceID)
+			}
+		})
+	}
+}
@@ -1,13 +1,21 @@
 package middleware
 
 import (
-	"net/http"
+	"errors"
 	"taskmanagementapi/pkg/helper"
+	"taskmanagementapi/pkg/logging"
+	"taskmanagementapi/pkg/metrics"
+	services "taskmanagementapi/pkg/usecase/interface"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func UserAuthMiddleware() fiber.Handler {
+var (
+	errMissingToken    = services.Unauthorized("missing_token", "Authorization cookie not found")
+	errSessionRequired = services.Forbidden("session_required", "personal access tokens are not allowed here")
+)
+
+func UserAuthMiddleware(tokenUseCase services.TokenUseCase, sessionUseCase services.SessionUseCase) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		tokenString := helper.GetTokenFromHeader(authHeader)
@@ -15,28 +23,81 @@ func UserAuthMiddleware() fiber.Handler {
 		if tokenString == "" {
 			tokenString = c.Cookies("Authorization")
 			if tokenString == "" {
-				return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
-					"status":  http.StatusUnauthorized,
-					"message": "Unauthorized",
-					"data":    nil,
-					"error":   "Authorization cookie not found",
-				})
+				return errMissingToken
 			}
 		}
 
-		userID, userEmail, err := helper.ExtractUserIDFromToken(tokenString)
+		if helper.IsAccessToken(tokenString) {
+			auth, err := tokenUseCase.ValidateToken(c.UserContext(), tokenString)
+			recordAuthAttempt(metrics.AuthAccessToken, err)
+			if err != nil {
+				return err
+			}
+			c.Locals("user_id", auth.UserID)
+			c.SetUserContext(logging.With(c.UserContext(), "user_id", auth.UserID))
+			c.Locals("scopes", auth.Scopes)
+			return c.Next()
+		}
+
+		claims, err := tokenUseCase.ValidateJWT(c.UserContext(), tokenString)
+		recordAuthAttempt(metrics.AuthJWT, err)
 		if err != nil {
-			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
-				"status":  http.StatusUnauthorized,
-				"message": "Invalid Token",
-				"data":    nil,
-				"error":   err.Error(),
-			})
+			return err
 		}
 
-		c.Locals("user_id", userID)
-		c.Locals("email", userEmail)
+		c.Locals("user_id", claims.Id)
+		c.SetUserContext(logging.With(c.UserContext(), "user_id", claims.Id))
+		c.Locals("email", claims.Email)
+		c.Locals("role", claims.Role)
+		c.Locals("jti", claims.StandardClaims.Id)
+		sessionUseCase.Touch(claims.StandardClaims.Id)
+
+		return c.Next()
+	}
+}
+
+// recordAuthAttempt counts accepted and rejected credentials. Other errors,
+// such as a failed database lookup, say nothing about the credential and
+// are left out.
+func recordAuthAttempt(method string, err error) {
+	if err == nil || errors.Is(err, services.ErrUnauthorized) {
+		metrics.AuthAttempt(method, err)
+	}
+}
 
+// RequireScope rejects personal access tokens that were not granted scope.
+// Requests authenticated with a sign-in JWT carry no scopes and are allowed.
+func RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scopes, ok := c.Locals("scopes").([]string)
+		if !ok {
+			return c.Next()
+		}
+		for _, s := range scopes {
+			if s == scope {
+				return c.Next()
+			}
+		}
+		return services.Forbidden("missing_scope", "token is missing scope "+scope)
+	}
+}
+
+// RequireSession rejects requests authenticated with a personal access token,
+// so that a leaked token cannot be used to mint further tokens.
+func RequireSession() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.Locals("scopes").([]string); ok {
+			return errSessionRequired
+		}
+		return c.Next()
+	}
+}
+
+func RequireRole(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if userRole, _ := c.Locals("role").(string); userRole != role {
+			return services.Forbidden("missing_role", "requires role "+role)
+		}
 		return c.Next()
 	}
 }
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// preforkChildEnv marks a child process; fiber.IsChild checks for it, and the
+// app's Listen then serves on a port shared with the other children.
+const preforkChildEnv = "FIBER_PREFORK_CHILD=1"
+
+// children supervises the prefork child processes in place of Fiber's own
+// master, which kills every remaining child as soon as one exits and so
+// cannot let them drain their requests on shutdown.
+type children struct {
+	// command builds the command for one child; it starts this binary again.
+	command func() *exec.Cmd
+
+	mu       sync.Mutex
+	procs    []*os.Process
+	stopping bool
+	running  sync.WaitGroup
+}
+
+func newChildren() *children {
+	return &children{command: func() *exec.Cmd {
+		return exec.Command(os.Args[0], os.Args[1:]...)
+	}}
+}
+
+// run starts one child per CPU and waits. A child that exits before stop is
+// called has failed, and run returns its error; after stop it returns nil.
+// Children already started when run fails are left for stop.
+func (ch *children) run() error {
+	count := runtime.GOMAXPROCS(0)
+	exited := make(chan error, count)
+	for i := 0; i < count; i++ {
+		cmd := ch.command()
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		cmd.Env = append(os.Environ(), preforkChildEnv)
+		// The children get the shutdown signal from the master only, not
+		// from a terminal or process manager signalling the whole group.
+		cmd.SysProcAttr = childProcAttr()
+		ch.mu.Lock()
+		if ch.stopping {
+			ch.mu.Unlock()
+			return nil
+		}
+		if err := cmd.Start(); err != nil {
+			ch.mu.Unlock()
+			return fmt.Errorf("start prefork child: %w", err)
+		}
+		ch.procs = append(ch.procs, cmd.Process)
+		ch.running.Add(1)
+		ch.mu.Unlock()
+		go func() {
+			err := cmd.Wait()
+			ch.running.Done()
+			exited <- err
+		}()
+	}
+	err := <-exited
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	if ch.stopping {
+		return nil
+	}
+	if err == nil {
+		err = errors.New("exited")
+	}
+	return fmt.Errorf("prefork child: %w", err)
+}
+
+// stop forwards the shutdown signal to every child and waits for them to
+// exit, killing those still running when ctx is done.
+func (ch *children) stop(ctx context.Context) error {
+	ch.mu.Lock()
+	ch.stopping = true
+	procs := ch.procs
+	ch.mu.Unlock()
+	for _, proc := range procs {
+		if err := stopChild(proc); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			return fmt.Errorf("signal prefork child %d: %w", proc.Pid, err)
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		ch.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		for _, proc := range procs {
+			_ = proc.Kill()
+		}
+		return fmt.Errorf("prefork children: %w", ctx.Err())
+	}
+}
+
+// isPreforkMaster reports whether this process should supervise children
+// rather than serve.
+func isPreforkMaster(prefork bool) bool {
+	return prefork && !fiber.IsChild()
+}
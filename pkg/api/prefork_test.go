@@ -0,0 +1,51 @@
+//go:build !windows
+
+package http
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PreforkChildrenStop(t *testing.T) {
+	ch := newChildren()
+	ch.command = func() *exec.Cmd { return exec.Command("sleep", "30") }
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- ch.run()
+	}()
+	require.Eventually(t, func() bool {
+		ch.mu.Lock()
+		defer ch.mu.Unlock()
+		return len(ch.procs) == runtime.GOMAXPROCS(0)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// sleep exits on SIGTERM, long before its 30 seconds are up.
+	require.NoError(t, ch.stop(ctx))
+	select {
+	case err := <-runErr:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not return after stop")
+	}
+}
+
+func Test_PreforkChildFails(t *testing.T) {
+	ch := newChildren()
+	ch.command = func() *exec.Cmd { return exec.Command("false") }
+
+	err := ch.run()
+	assert.ErrorContains(t, err, "prefork child")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, ch.stop(ctx))
+}
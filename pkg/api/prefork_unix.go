@@ -0,0 +1,18 @@
+//go:build !windows
+
+package http
+
+import (
+	"os"
+	"syscall"
+)
+
+// childProcAttr puts a child in its own process group.
+func childProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+// stopChild asks a child to shut down gracefully.
+func stopChild(proc *os.Process) error {
+	return proc.Signal(syscall.SIGTERM)
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+package http
+
+import (
+	"os"
+	"syscall"
+)
+
+func childProcAttr() *syscall.SysProcAttr {
+	return nil
+}
+
+// stopChild kills a child, since Windows cannot deliver SIGTERM to it.
+func stopChild(proc *os.Process) error {
+	return proc.Kill()
+}
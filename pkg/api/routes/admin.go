@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"taskmanagementapi/pkg/api/handlers"
+	"taskmanagementapi/pkg/api/middleware"
+	"taskmanagementapi/pkg/utils/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AdminRoutes(app fiber.Router, adminHandler *handlers.AdminHandler, auth fiber.Handler) {
+	app.Use(auth, middleware.RequireSession(), middleware.RequireRole(models.RoleAdmin))
+	{
+		app.Get("/users", adminHandler.ListUsers)
+		app.Post("/users/:id/disable", adminHandler.DisableUser)
+		app.Post("/users/:id/enable", adminHandler.EnableUser)
+		app.Post("/users/:id/password", adminHandler.ResetPassword)
+		app.Put("/users/:id/role", adminHandler.UpdateRole)
+		app.Get("/users/:id/tasks", adminHandler.GetUserTasks)
+		app.Get("/audit-logs", adminHandler.GetAuditLogs)
+	}
+}
@@ -3,17 +3,20 @@ package routes
 import (
 	"taskmanagementapi/pkg/api/handlers"
 	"taskmanagementapi/pkg/api/middleware"
+	"taskmanagementapi/pkg/utils/models"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func TaskRoutes(app fiber.Router, taskHandler *handlers.TaskHandler) {
-	app.Use(middleware.UserAuthMiddleware())
+func TaskRoutes(app fiber.Router, taskHandler *handlers.TaskHandler, auth fiber.Handler, limit fiber.Handler, idempotency fiber.Handler) {
+	app.Use(auth, limit)
 	{
-		app.Post("", taskHandler.CreateTask)
-		app.Get("", taskHandler.GetTasks)
-		app.Get("/:id", taskHandler.GetTask)
-		app.Put("/:id", taskHandler.UpdateTask)
-		app.Delete("/:id", taskHandler.DeleteTask)
+		app.Post("", middleware.RequireScope(models.ScopeTasksWrite), idempotency, taskHandler.CreateTask)
+		app.Get("", middleware.RequireScope(models.ScopeTasksRead), taskHandler.GetTasks)
+		app.Post("/bulk", middleware.RequireScope(models.ScopeTasksWrite), taskHandler.BulkTasks)
+		app.Get("/:id", middleware.RequireScope(models.ScopeTasksRead), taskHandler.GetTask)
+		app.Put("/:id", middleware.RequireScope(models.ScopeTasksWrite), taskHandler.UpdateTask)
+		app.Patch("/:id", middleware.RequireScope(models.ScopeTasksWrite), taskHandler.PatchTask)
+		app.Delete("/:id", middleware.RequireScope(models.ScopeTasksWrite), taskHandler.DeleteTask)
 	}
 }
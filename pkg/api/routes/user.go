@@ -2,13 +2,41 @@ package routes
 
 import (
 	"taskmanagementapi/pkg/api/handlers"
+	"taskmanagementapi/pkg/api/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func UserRoutes(app fiber.Router, userHandler *handlers.UserHandler) {
-	app.Post("/signup", userHandler.UserSignUp)
-	app.Post("/signin", userHandler.UserSignIn)
-	app.Post("/signout", userHandler.UserSignOut)
+func UserRoutes(app fiber.Router, userHandler *handlers.UserHandler, tokenHandler *handlers.TokenHandler, oidcHandler *handlers.OIDCHandler, accountHandler *handlers.AccountHandler, sessionHandler *handlers.SessionHandler, preferenceHandler *handlers.PreferenceHandler, auth fiber.Handler, signUpLimit fiber.Handler, signInLimit fiber.Handler) {
+	app.Post("/signup", signUpLimit, userHandler.UserSignUp)
+	app.Post("/signin", signInLimit, userHandler.UserSignIn)
+	app.Post("/signout", auth, middleware.RequireSession(), sessionHandler.SignOut)
+	app.Get("/oidc/login", oidcHandler.Login)
+	app.Get("/oidc/callback", oidcHandler.Callback)
 
+	app.Get("/me", auth, userHandler.GetProfile)
+	app.Patch("/me", auth, middleware.RequireSession(), userHandler.UpdateProfile)
+	app.Post("/me/email/verify", auth, middleware.RequireSession(), userHandler.VerifyEmail)
+	app.Post("/me/password", auth, middleware.RequireSession(), userHandler.ChangePassword)
+	app.Get("/me/export", auth, middleware.RequireSession(), accountHandler.ExportData)
+	app.Delete("/me", auth, middleware.RequireSession(), accountHandler.DeleteAccount)
+
+	tokens := app.Group("/tokens", auth, middleware.RequireSession())
+	{
+		tokens.Post("", tokenHandler.CreateToken)
+		tokens.Get("", tokenHandler.GetTokens)
+		tokens.Delete("/:id", tokenHandler.RevokeToken)
+	}
+
+	sessions := app.Group("/sessions", auth, middleware.RequireSession())
+	{
+		sessions.Get("", sessionHandler.GetSessions)
+		sessions.Delete("/:id", sessionHandler.RevokeSession)
+	}
+
+	preferences := app.Group("/preferences", auth, middleware.RequireSession())
+	{
+		preferences.Get("", preferenceHandler.GetPreferences)
+		preferences.Put("", preferenceHandler.UpdatePreferences)
+	}
 }
@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"time"
+
+	"taskmanagementapi/pkg/api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// API registers the route groups of one API version on r. Every group it
+// creates must be given the middleware, which carries the version's
+// deprecation headers.
+type API func(r fiber.Router, middleware ...fiber.Handler)
+
+// Version mounts an API under Prefix. Several versions can be mounted side by
+// side, e.g. /v1 and /v2, and the same API can be mounted more than once to
+// keep old paths working as aliases.
+type Version struct {
+	Prefix string
+	API    API
+	// Deprecation is zero while the version is supported. Sunset is when it
+	// will be removed, and Successor the prefix clients should move to.
+	Deprecation time.Time
+	Sunset      time.Time
+	Successor   string
+}
+
+func Mount(app fiber.Router, versions ...Version) {
+	for _, version := range versions {
+		handlers := []fiber.Handler{middleware.RoutePrefix(version.Prefix)}
+		if !version.Deprecation.IsZero() {
+			handlers = append(handlers, middleware.Deprecated(version.Deprecation, version.Sunset, version.Successor))
+		}
+		router := app
+		if version.Prefix != "" {
+			router = app.Group(version.Prefix)
+		}
+		version.API(router, handlers...)
+	}
+}
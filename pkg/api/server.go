@@ -1,30 +1,134 @@
 package http
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
 	"taskmanagementapi/pkg/api/handlers"
+	"taskmanagementapi/pkg/api/middleware"
 	"taskmanagementapi/pkg/api/routes"
+	"taskmanagementapi/pkg/config"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
+)
+
+// The unprefixed paths predate /v1 and are kept as deprecated aliases of it.
+var (
+	legacyDeprecation = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	legacySunset      = time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
 )
 
 type ServerHTTP struct {
-	app *fiber.App
+	app    *fiber.App
+	addr   string
+	logger *slog.Logger
+	// children is set in the master process of a prefork server, which only
+	// supervises the children that serve.
+	children *children
 }
 
-func NewServerHTTP(userHandler *handlers.UserHandler, taskHandler *handlers.TaskHandler) *ServerHTTP {
-	app := fiber.New(fiber.Config{})
-	app.Use(logger.New())
-	routes.UserRoutes(app.Group("/user"), userHandler)
-	routes.TaskRoutes(app.Group("/tasks"), taskHandler)
-	return &ServerHTTP{app: app}
+func NewServerHTTP(cfg config.Config, logger *slog.Logger, userHandler *handlers.UserHandler, taskHandler *handlers.TaskHandler, tokenHandler *handlers.TokenHandler, adminHandler *handlers.AdminHandler, oidcHandler *handlers.OIDCHandler, accountHandler *handlers.AccountHandler, sessionHandler *handlers.SessionHandler, preferenceHandler *handlers.PreferenceHandler, healthHandler *handlers.HealthHandler, tokenUseCase services.TokenUseCase, sessionUseCase services.SessionUseCase, idempotencyUseCase services.IdempotencyUseCase, rateLimitUseCase services.RateLimitUseCase) (*ServerHTTP, error) {
+	trustedProxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	corsHandler, err := middleware.CORS(cfg.CORSAllowOrigins, cfg.CORSAllowMethods, cfg.CORSAllowCredentials, cfg.CORSMaxAge)
+	if err != nil {
+		return nil, err
+	}
+	app := fiber.New(fiber.Config{
+		ErrorHandler: handlers.ErrorHandler,
+		// Everything goes through the JSON logger, including startup.
+		DisableStartupMessage: true,
+		// Proxy headers, including X-Forwarded-Proto, are ignored unless the
+		// request comes straight from one of the trusted proxies.
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies,
+		ProxyHeader:             cfg.ProxyHeader,
+		EnableIPValidation:      true,
+		ReadTimeout:             cfg.HTTPReadTimeout,
+		WriteTimeout:            cfg.HTTPWriteTimeout,
+		IdleTimeout:             cfg.HTTPIdleTimeout,
+		BodyLimit:               cfg.HTTPBodyLimit,
+		Prefork:                 cfg.HTTPPrefork,
+	})
+	if strings.EqualFold(cfg.ProxyHeader, fiber.HeaderXForwardedFor) {
+		app.Use(middleware.ForwardedFor(trustedProxies))
+	}
+	app.Use(middleware.Tracing(), middleware.Metrics(), middleware.Logging(logger))
+	app.Use(middleware.SecurityHeaders(cfg.HSTSMaxAge, cfg.ContentSecurityPolicy, cfg.FrameOptions))
+	app.Use(corsHandler)
+	auth := middleware.UserAuthMiddleware(tokenUseCase, sessionUseCase)
+	idempotency := middleware.Idempotency(idempotencyUseCase)
+	authLimit := models.PerMinute(cfg.RateLimitAuthBurst, cfg.RateLimitAuthPerMinute)
+	signUpLimit := middleware.RateLimit(rateLimitUseCase, authLimit, middleware.ByIP("signup"))
+	signInLimit := middleware.RateLimit(rateLimitUseCase, authLimit, middleware.ByIP("signin"))
+	taskLimit := middleware.RateLimit(rateLimitUseCase, models.PerMinute(cfg.RateLimitTasksBurst, cfg.RateLimitTasksPerMinute), middleware.ByUser("tasks"))
+	app.Get("/.well-known/jwks.json", tokenHandler.GetJWKS)
+	app.Get("/openapi.json", handlers.OpenAPI)
+	app.Get("/docs", handlers.DocsUI)
+	app.Get("/docs/ui.js", handlers.DocsUIScript)
+	app.Get("/docs/swagger-ui/:file", handlers.DocsUIAsset)
+	app.Get("/metrics", handlers.Metrics)
+	app.Get("/healthz", healthHandler.Healthz)
+	app.Get("/readyz", healthHandler.Readyz)
+	app.Get("/version", handlers.Version)
+
+	v1 := func(r fiber.Router, deprecated ...fiber.Handler) {
+		routes.UserRoutes(r.Group("/user", deprecated...), userHandler, tokenHandler, oidcHandler, accountHandler, sessionHandler, preferenceHandler, auth, signUpLimit, signInLimit)
+		routes.TaskRoutes(r.Group("/tasks", deprecated...), taskHandler, auth, taskLimit, idempotency)
+		routes.AdminRoutes(r.Group("/admin", deprecated...), adminHandler, auth)
+	}
+	routes.Mount(app,
+		routes.Version{Prefix: "/v1", API: v1},
+		routes.Version{API: v1, Deprecation: legacyDeprecation, Sunset: legacySunset, Successor: "/v1"},
+	)
+	sh := &ServerHTTP{app: app, addr: cfg.HTTPAddr, logger: logger}
+	if isPreforkMaster(cfg.HTTPPrefork) {
+		sh.children = newChildren()
+	}
+	return sh, nil
 }
 
-func (sh *ServerHTTP) Start(infoLog *log.Logger, errorLog *log.Logger) {
-	infoLog.Printf("starting server on :3000")
-	err := sh.app.Listen(":3000")
-	if err != nil {
-		errorLog.Fatal(err)
+// parseTrustedProxies accepts IP addresses and CIDR ranges.
+func parseTrustedProxies(proxies []string) ([]string, error) {
+	var parsed []string
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q", proxy)
+		}
+		parsed = append(parsed, proxy)
+	}
+	return parsed, nil
+}
+
+// Start serves until the listener fails or Shutdown is called, in which case
+// it returns nil. The master of a prefork server runs the children instead,
+// returning when one of them fails.
+func (sh *ServerHTTP) Start() error {
+	if sh.children != nil {
+		sh.logger.Info("starting prefork children", "addr", sh.addr)
+		return sh.children.run()
+	}
+	sh.logger.Info("starting server", "addr", sh.addr)
+	return sh.app.Listen(sh.addr)
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests to
+// finish, giving up when ctx is done. The master of a prefork server passes
+// the signal on to the children and waits for them to do the same.
+func (sh *ServerHTTP) Shutdown(ctx context.Context) error {
+	if sh.children != nil {
+		return sh.children.stop(ctx)
 	}
+	return sh.app.ShutdownWithContext(ctx)
 }
@@ -0,0 +1,361 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	nethttp "net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"taskmanagementapi/pkg/api/docs"
+	"taskmanagementapi/pkg/api/handlers"
+	"taskmanagementapi/pkg/config"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fiberParam = regexp.MustCompile(`:(\w+)`)
+
+var discardLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+// undocumented lists routes that serve the documentation itself or are
+// meant for operators rather than API clients.
+var undocumented = map[string]bool{
+	"/openapi.json":          true,
+	"/docs":                  true,
+	"/docs/ui.js":            true,
+	"/docs/swagger-ui/:file": true,
+	"/metrics":               true,
+	"/healthz":               true,
+	"/readyz":                true,
+	"/version":               true,
+}
+
+func newTestServer(t *testing.T, cfg config.Config) *ServerHTTP {
+	sh, err := NewServerHTTP(cfg, discardLogger, &handlers.UserHandler{}, &handlers.TaskHandler{}, &handlers.TokenHandler{}, &handlers.AdminHandler{}, &handlers.OIDCHandler{}, &handlers.AccountHandler{}, &handlers.SessionHandler{}, &handlers.PreferenceHandler{}, &handlers.HealthHandler{}, nil, nil, nil, nil)
+	require.NoError(t, err)
+	return sh
+}
+
+func Test_RoutesDocumented(t *testing.T) {
+	sh := newTestServer(t, config.Config{})
+	registered := make(map[[2]string]bool)
+	for _, route := range sh.app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || undocumented[route.Path] {
+			continue
+		}
+		path := fiberParam.ReplaceAllString(route.Path, "{$1}")
+		if !docs.Has(route.Method, path) && docs.Has(route.Method, docs.Prefix+path) {
+			// A deprecated alias of a documented route.
+			continue
+		}
+		registered[[2]string{route.Method, path}] = true
+		assert.True(t, docs.Has(route.Method, path), "%s %s is registered but missing from the OpenAPI spec", route.Method, route.Path)
+	}
+	for _, op := range docs.Paths() {
+		assert.True(t, registered[op], "%s %s is in the OpenAPI spec but not registered", op[0], op[1])
+	}
+}
+
+func Test_OpenAPIRoute(t *testing.T) {
+	sh := newTestServer(t, config.Config{})
+
+	resp, err := sh.app.Test(httptest.NewRequest(fiber.MethodGet, "/openapi.json", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var doc docs.Document
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/v1/tasks/{id}")
+
+	resp, err = sh.app.Test(httptest.NewRequest(fiber.MethodGet, "/docs", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	// The page has no inline scripts; its bootstrap is served separately.
+	assert.NotContains(t, string(body), "<script>")
+	assert.Contains(t, string(body), `<script src="/docs/ui.js">`)
+
+	resp, err = sh.app.Test(httptest.NewRequest(fiber.MethodGet, "/docs/ui.js", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "/openapi.json")
+
+	// Swagger UI itself is served from the binary, not a CDN.
+	resp, err = sh.app.Test(httptest.NewRequest(fiber.MethodGet, "/docs/swagger-ui/swagger-ui-bundle.js", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/javascript; charset=utf-8", resp.Header.Get(fiber.HeaderContentType))
+
+	resp, err = sh.app.Test(httptest.NewRequest(fiber.MethodGet, "/docs/swagger-ui/swagger-ui.css", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/css; charset=utf-8", resp.Header.Get(fiber.HeaderContentType))
+
+	resp, err = sh.app.Test(httptest.NewRequest(fiber.MethodGet, "/docs/swagger-ui/LICENSE", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func Test_VersionedRoutes(t *testing.T) {
+	sh := newTestServer(t, config.Config{})
+	testCases := map[string]struct {
+		path           string
+		wantDeprecated bool
+	}{
+		"v1":           {path: "/v1/tasks/1"},
+		"legacy alias": {path: "/tasks/1", wantDeprecated: true},
+		"unversioned":  {path: "/openapi.json"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp, err := sh.app.Test(httptest.NewRequest(fiber.MethodGet, tc.path, nil))
+			require.NoError(t, err)
+			assert.NotEqual(t, fiber.StatusNotFound, resp.StatusCode)
+			if !tc.wantDeprecated {
+				assert.Empty(t, resp.Header.Get("Deprecation"))
+				assert.Empty(t, resp.Header.Get("Sunset"))
+				return
+			}
+			assert.Equal(t, fmt.Sprintf("@%d", legacyDeprecation.Unix()), resp.Header.Get("Deprecation"))
+			assert.Equal(t, legacySunset.Format(nethttp.TimeFormat), resp.Header.Get("Sunset"))
+			assert.Equal(t, `</v1/tasks/1>; rel="successor-version"`, resp.Header.Get("Link"))
+		})
+	}
+}
+
+func Test_CORS(t *testing.T) {
+	cfg := config.Config{
+		CORSAllowOrigins:     []string{"https://app.example.com", " https://*.example.org"},
+		CORSAllowMethods:     []string{"GET", "PATCH"},
+		CORSAllowCredentials: true,
+		CORSMaxAge:           10 * time.Minute,
+	}
+	testCases := map[string]struct {
+		cfg        config.Config
+		origin     string
+		wantOrigin string
+	}{
+		"allowed origin":     {cfg: cfg, origin: "https://app.example.com", wantOrigin: "https://app.example.com"},
+		"wildcard subdomain": {cfg: cfg, origin: "https://web.example.org", wantOrigin: "https://web.example.org"},
+		"other origin":       {cfg: cfg, origin: "https://evil.example.net"},
+		"cors disabled":      {cfg: config.Config{}, origin: "https://app.example.com"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sh := newTestServer(t, tc.cfg)
+			req := httptest.NewRequest(fiber.MethodOptions, "/v1/tasks/1", nil)
+			req.Header.Set(fiber.HeaderOrigin, tc.origin)
+			req.Header.Set(fiber.HeaderAccessControlRequestMethod, fiber.MethodPatch)
+			req.Header.Set(fiber.HeaderAccessControlRequestHeaders, "Authorization, Content-Type")
+
+			resp, err := sh.app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOrigin, resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+			if tc.wantOrigin == "" {
+				return
+			}
+			assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+			assert.Equal(t, "GET,PATCH", resp.Header.Get(fiber.HeaderAccessControlAllowMethods))
+			assert.Equal(t, "Authorization, Content-Type", resp.Header.Get(fiber.HeaderAccessControlAllowHeaders))
+			assert.Equal(t, "true", resp.Header.Get(fiber.HeaderAccessControlAllowCredentials))
+			assert.Equal(t, "600", resp.Header.Get(fiber.HeaderAccessControlMaxAge))
+		})
+	}
+
+	t.Run("exposed headers", func(t *testing.T) {
+		sh := newTestServer(t, cfg)
+		req := httptest.NewRequest(fiber.MethodGet, "/tasks/1", nil)
+		req.Header.Set(fiber.HeaderOrigin, "https://app.example.com")
+
+		resp, err := sh.app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, "https://app.example.com", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+		assert.Contains(t, resp.Header.Get(fiber.HeaderAccessControlExposeHeaders), "RateLimit-Remaining")
+		assert.NotEmpty(t, resp.Header.Get("Deprecation"))
+	})
+}
+
+func Test_SecurityHeaders(t *testing.T) {
+	sh := newTestServer(t, config.Config{
+		HSTSMaxAge:            24 * time.Hour,
+		ContentSecurityPolicy: "default-src 'none'",
+		FrameOptions:          "DENY",
+		TrustedProxies:        []string{"0.0.0.0"},
+	})
+	testCases := map[string]struct {
+		path      string
+		proto     string
+		wantHSTS  string
+		wantCSP   string
+		wantFrame string
+	}{
+		"plain http":  {path: "/openapi.json", wantCSP: "default-src 'none'"},
+		"https proxy": {path: "/openapi.json", proto: "https", wantHSTS: "max-age=86400", wantCSP: "default-src 'none'"},
+		"docs page":   {path: "/docs", wantCSP: docs.UIContentSecurityPolicy},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, tc.path, nil)
+			if tc.proto != "" {
+				req.Header.Set(fiber.HeaderXForwardedProto, tc.proto)
+			}
+
+			resp, err := sh.app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, "nosniff", resp.Header.Get(fiber.HeaderXContentTypeOptions))
+			assert.Equal(t, "DENY", resp.Header.Get(fiber.HeaderXFrameOptions))
+			assert.Equal(t, tc.wantHSTS, resp.Header.Get(fiber.HeaderStrictTransportSecurity))
+			assert.Equal(t, tc.wantCSP, resp.Header.Get(fiber.HeaderContentSecurityPolicy))
+		})
+	}
+}
+
+func Test_TrustedProxies(t *testing.T) {
+	// app.Test connects from 0.0.0.0.
+	testCases := map[string]struct {
+		proxies []string
+		header  string
+		wantIP  string
+	}{
+		"no trusted proxies": {header: "203.0.113.7", wantIP: "0.0.0.0"},
+		"untrusted proxy":    {proxies: []string{"10.0.0.0/8"}, header: "203.0.113.7", wantIP: "0.0.0.0"},
+		"trusted proxy":      {proxies: []string{"0.0.0.0"}, header: "203.0.113.7", wantIP: "203.0.113.7"},
+		"trusted range":      {proxies: []string{"0.0.0.0/8", "10.0.0.0/8"}, header: "203.0.113.7, 10.0.0.1", wantIP: "203.0.113.7"},
+		"spoofed entry":      {proxies: []string{"0.0.0.0"}, header: "198.51.100.1, 203.0.113.7", wantIP: "203.0.113.7"},
+		"untrusted hop":      {proxies: []string{"0.0.0.0/8"}, header: "203.0.113.7, 10.0.0.1", wantIP: "10.0.0.1"},
+		"only proxies":       {proxies: []string{"0.0.0.0/8", "10.0.0.0/8"}, header: "10.0.0.2, 10.0.0.1", wantIP: "10.0.0.2"},
+		"invalid header":     {proxies: []string{"0.0.0.0"}, header: "unknown", wantIP: "0.0.0.0"},
+		"invalid last entry": {proxies: []string{"0.0.0.0"}, header: "203.0.113.7, unknown", wantIP: "0.0.0.0"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sh := newTestServer(t, config.Config{TrustedProxies: tc.proxies, ProxyHeader: fiber.HeaderXForwardedFor})
+			sh.app.Get("/ip", func(c *fiber.Ctx) error {
+				return c.SendString(c.IP())
+			})
+			req := httptest.NewRequest(fiber.MethodGet, "/ip", nil)
+			req.Header.Set(fiber.HeaderXForwardedFor, tc.header)
+
+			resp, err := sh.app.Test(req)
+			require.NoError(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, tc.wantIP, string(body))
+		})
+	}
+}
+
+func Test_NewServerHTTPInvalidConfig(t *testing.T) {
+	testCases := map[string]config.Config{
+		"wildcard with credentials": {CORSAllowOrigins: []string{"*"}, CORSAllowCredentials: true},
+		"wildcard with others":      {CORSAllowOrigins: []string{"*", "https://app.example.com"}},
+		"origin with path":          {CORSAllowOrigins: []string{"https://app.example.com/"}},
+		"origin without scheme":     {CORSAllowOrigins: []string{"app.example.com"}},
+		"invalid proxy":             {TrustedProxies: []string{"10.0.0.0/33"}},
+	}
+	for name, cfg := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewServerHTTP(cfg, discardLogger, &handlers.UserHandler{}, &handlers.TaskHandler{}, &handlers.TokenHandler{}, &handlers.AdminHandler{}, &handlers.OIDCHandler{}, &handlers.AccountHandler{}, &handlers.SessionHandler{}, &handlers.PreferenceHandler{}, &handlers.HealthHandler{}, nil, nil, nil, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_MetricsRoute(t *testing.T) {
+	sh := newTestServer(t, config.Config{})
+	_, err := sh.app.Test(httptest.NewRequest(fiber.MethodGet, "/openapi.json", nil))
+	require.NoError(t, err)
+
+	resp, err := sh.app.Test(httptest.NewRequest(fiber.MethodGet, "/metrics", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `http_requests_total{method="GET",route="/openapi.json",status="200"}`)
+	assert.Contains(t, string(body), "http_request_duration_seconds_bucket")
+	assert.Contains(t, string(body), "go_goroutines")
+}
+
+func Test_BodyLimit(t *testing.T) {
+	sh := newTestServer(t, config.Config{HTTPBodyLimit: 16})
+	sh.app.Post("/echo", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := sh.app.Test(httptest.NewRequest(fiber.MethodPost, "/echo", strings.NewReader("small")))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+	// fasthttp answers 413 and closes the connection, which app.Test reports
+	// as an error.
+	_, err = sh.app.Test(httptest.NewRequest(fiber.MethodPost, "/echo", strings.NewReader(strings.Repeat("x", 17))))
+	assert.ErrorContains(t, err, "body size exceeds the given limit")
+}
+
+func Test_GracefulShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	sh := newTestServer(t, config.Config{HTTPAddr: addr, HTTPReadTimeout: time.Second})
+	inFlight, release := make(chan struct{}), make(chan struct{})
+	sh.app.Get("/slow", func(c *fiber.Ctx) error {
+		close(inFlight)
+		<-release
+		return c.SendString("done")
+	})
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- sh.Start()
+	}()
+	require.Eventually(t, func() bool {
+		resp, err := nethttp.Get("http://" + addr + "/healthz")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == fiber.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	slow := make(chan string, 1)
+	go func() {
+		resp, err := nethttp.Get("http://" + addr + "/slow")
+		if err != nil {
+			slow <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slow <- string(body)
+	}()
+	<-inFlight
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownErr <- sh.Shutdown(ctx)
+	}()
+	// New connections are refused while the in-flight request drains.
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+		}
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	close(release)
+
+	assert.Equal(t, "done", <-slow)
+	assert.NoError(t, <-shutdownErr)
+	assert.NoError(t, <-serverErr)
+}
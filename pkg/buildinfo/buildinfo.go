@@ -0,0 +1,39 @@
+// Package buildinfo reports which build of the service is running. Commit and
+// BuildTime are set by the makefile's build target with -ldflags -X.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+var (
+	Commit    string
+	BuildTime string
+)
+
+const unknown = "unknown"
+
+// Get falls back to the VCS details the Go toolchain stamps into binaries
+// built from a checkout when the build did not set Commit or BuildTime.
+func Get() models.BuildInfo {
+	info := models.BuildInfo{Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildTime == "":
+				info.BuildTime = setting.Value
+			}
+		}
+	}
+	if info.Commit == "" {
+		info.Commit = unknown
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = unknown
+	}
+	return info
+}
@@ -1,18 +1,139 @@
 package config
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
+	LogLevel string `mapstructure:"LOG_LEVEL"`
+
+	TracingExporter     string  `mapstructure:"TRACING_EXPORTER"`
+	TracingOTLPEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingStdoutFile   string  `mapstructure:"TRACING_STDOUT_FILE"`
+	TracingSampleRatio  float64 `mapstructure:"TRACING_SAMPLE_RATIO"`
+
+	// DBUrl must reach a replica set or a sharded cluster, since deleting an
+	// account runs in a transaction. A single-node replica set is enough.
 	DBUrl  string `mapstructure:"DB_URL"`
 	DBName string `mapstructure:"DB_NAME"`
 
-	JwtSecretKey string `mapstructure:"JWT_SECRET_KEY"`
+	JwtSecretKey           string        `mapstructure:"JWT_SECRET_KEY"`
+	JwtAlgorithm           string        `mapstructure:"JWT_ALGORITHM"`
+	JwtTokenTTL            time.Duration `mapstructure:"JWT_TOKEN_TTL"`
+	JwtKeyRotationInterval time.Duration `mapstructure:"JWT_KEY_ROTATION_INTERVAL"`
+
+	OIDCIssuer       string `mapstructure:"OIDC_ISSUER"`
+	OIDCClientID     string `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string `mapstructure:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL  string `mapstructure:"OIDC_REDIRECT_URL"`
+
+	PasswordMinLength        int    `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordMaxLength        int    `mapstructure:"PASSWORD_MAX_LENGTH"`
+	PasswordRequireUpper     bool   `mapstructure:"PASSWORD_REQUIRE_UPPER"`
+	PasswordRequireLower     bool   `mapstructure:"PASSWORD_REQUIRE_LOWER"`
+	PasswordRequireDigit     bool   `mapstructure:"PASSWORD_REQUIRE_DIGIT"`
+	PasswordRequireSymbol    bool   `mapstructure:"PASSWORD_REQUIRE_SYMBOL"`
+	PasswordDisallowPersonal bool   `mapstructure:"PASSWORD_DISALLOW_PERSONAL"`
+	PasswordBreachedFile     string `mapstructure:"PASSWORD_BREACHED_FILE"`
+
+	PasswordHashAlgorithm string `mapstructure:"PASSWORD_HASH_ALGORITHM"`
+	Argon2Memory          uint32 `mapstructure:"ARGON2_MEMORY"`
+	Argon2Iterations      uint32 `mapstructure:"ARGON2_ITERATIONS"`
+	Argon2Parallelism     uint8  `mapstructure:"ARGON2_PARALLELISM"`
+	BcryptCost            int    `mapstructure:"BCRYPT_COST"`
+
+	IdempotencyKeyTTL time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+
+	RateLimitStore          string `mapstructure:"RATE_LIMIT_STORE"`
+	RateLimitTasksBurst     int    `mapstructure:"RATE_LIMIT_TASKS_BURST"`
+	RateLimitTasksPerMinute int    `mapstructure:"RATE_LIMIT_TASKS_PER_MINUTE"`
+	RateLimitAuthBurst      int    `mapstructure:"RATE_LIMIT_AUTH_BURST"`
+	RateLimitAuthPerMinute  int    `mapstructure:"RATE_LIMIT_AUTH_PER_MINUTE"`
+
+	CORSAllowOrigins     []string      `mapstructure:"CORS_ALLOW_ORIGINS"`
+	CORSAllowMethods     []string      `mapstructure:"CORS_ALLOW_METHODS"`
+	CORSAllowCredentials bool          `mapstructure:"CORS_ALLOW_CREDENTIALS"`
+	CORSMaxAge           time.Duration `mapstructure:"CORS_MAX_AGE"`
+
+	HSTSMaxAge            time.Duration `mapstructure:"HSTS_MAX_AGE"`
+	ContentSecurityPolicy string        `mapstructure:"CONTENT_SECURITY_POLICY"`
+	FrameOptions          string        `mapstructure:"FRAME_OPTIONS"`
+
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
+	ProxyHeader    string   `mapstructure:"PROXY_HEADER"`
+
+	ReadinessTimeout time.Duration `mapstructure:"READINESS_TIMEOUT"`
+
+	HTTPAddr         string        `mapstructure:"HTTP_ADDR"`
+	HTTPReadTimeout  time.Duration `mapstructure:"HTTP_READ_TIMEOUT"`
+	HTTPWriteTimeout time.Duration `mapstructure:"HTTP_WRITE_TIMEOUT"`
+	HTTPIdleTimeout  time.Duration `mapstructure:"HTTP_IDLE_TIMEOUT"`
+	HTTPBodyLimit    int           `mapstructure:"HTTP_BODY_LIMIT"`
+	HTTPPrefork      bool          `mapstructure:"HTTP_PREFORK"`
+	ShutdownTimeout  time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
+}
+
+var defaults = map[string]interface{}{
+	"LOG_LEVEL":                 "info",
+	"TRACING_EXPORTER":          "none",
+	"TRACING_OTLP_ENDPOINT":     "http://localhost:4318",
+	"TRACING_SAMPLE_RATIO":      1.0,
+	"JWT_ALGORITHM":             "RS256",
+	"JWT_TOKEN_TTL":             "24h",
+	"JWT_KEY_ROTATION_INTERVAL": "720h",
+	// bcrypt only uses the first 72 bytes of a password.
+	"PASSWORD_MIN_LENGTH":        8,
+	"PASSWORD_MAX_LENGTH":        64,
+	"PASSWORD_DISALLOW_PERSONAL": true,
+	// OWASP's recommended argon2id settings: 64 MiB, 3 passes, 2 lanes.
+	"PASSWORD_HASH_ALGORITHM": "argon2id",
+	"ARGON2_MEMORY":           65536,
+	"ARGON2_ITERATIONS":       3,
+	"ARGON2_PARALLELISM":      2,
+	"BCRYPT_COST":             10,
+	"IDEMPOTENCY_KEY_TTL":     "24h",
+	// Per user across /tasks, and per client IP for each of sign-in and sign-up.
+	"RATE_LIMIT_STORE":            "memory",
+	"RATE_LIMIT_TASKS_BURST":      60,
+	"RATE_LIMIT_TASKS_PER_MINUTE": 60,
+	"RATE_LIMIT_AUTH_BURST":       10,
+	"RATE_LIMIT_AUTH_PER_MINUTE":  5,
+	"CORS_ALLOW_METHODS":          "GET,POST,PUT,PATCH,DELETE",
+	"CORS_MAX_AGE":                "10m",
+	"HSTS_MAX_AGE":                "8760h",
+	// The API only serves JSON, so nothing may load from or embed its responses.
+	"CONTENT_SECURITY_POLICY": "default-src 'none'; frame-ancestors 'none'",
+	"FRAME_OPTIONS":           "DENY",
+	"PROXY_HEADER":            "X-Forwarded-For",
+	"READINESS_TIMEOUT":       "2s",
+	"HTTP_ADDR":               ":3000",
+	"HTTP_READ_TIMEOUT":       "15s",
+	"HTTP_WRITE_TIMEOUT":      "30s",
+	"HTTP_IDLE_TIMEOUT":       "60s",
+	"HTTP_BODY_LIMIT":         4 * 1024 * 1024,
+	"SHUTDOWN_TIMEOUT":        "30s",
 }
 
 var envs = []string{
+	"LOG_LEVEL",
+	"TRACING_EXPORTER", "TRACING_OTLP_ENDPOINT", "TRACING_STDOUT_FILE", "TRACING_SAMPLE_RATIO",
 	"DB_URL", "DB_NAME", "JWT_SECRET_KEY",
+	"JWT_ALGORITHM", "JWT_TOKEN_TTL", "JWT_KEY_ROTATION_INTERVAL",
+	"OIDC_ISSUER", "OIDC_CLIENT_ID", "OIDC_CLIENT_SECRET", "OIDC_REDIRECT_URL",
+	"PASSWORD_MIN_LENGTH", "PASSWORD_MAX_LENGTH",
+	"PASSWORD_REQUIRE_UPPER", "PASSWORD_REQUIRE_LOWER", "PASSWORD_REQUIRE_DIGIT", "PASSWORD_REQUIRE_SYMBOL",
+	"PASSWORD_DISALLOW_PERSONAL", "PASSWORD_BREACHED_FILE",
+	"PASSWORD_HASH_ALGORITHM", "ARGON2_MEMORY", "ARGON2_ITERATIONS", "ARGON2_PARALLELISM", "BCRYPT_COST",
+	"IDEMPOTENCY_KEY_TTL",
+	"RATE_LIMIT_STORE", "RATE_LIMIT_TASKS_BURST", "RATE_LIMIT_TASKS_PER_MINUTE", "RATE_LIMIT_AUTH_BURST", "RATE_LIMIT_AUTH_PER_MINUTE",
+	"CORS_ALLOW_ORIGINS", "CORS_ALLOW_METHODS", "CORS_ALLOW_CREDENTIALS", "CORS_MAX_AGE",
+	"HSTS_MAX_AGE", "CONTENT_SECURITY_POLICY", "FRAME_OPTIONS",
+	"TRUSTED_PROXIES", "PROXY_HEADER",
+	"READINESS_TIMEOUT",
+	"HTTP_ADDR", "HTTP_READ_TIMEOUT", "HTTP_WRITE_TIMEOUT", "HTTP_IDLE_TIMEOUT", "HTTP_BODY_LIMIT", "HTTP_PREFORK",
+	"SHUTDOWN_TIMEOUT",
 }
 
 func LoadConfig() (Config, error) {
@@ -20,6 +141,9 @@ func LoadConfig() (Config, error) {
 	viper.AddConfigPath("./")
 	viper.SetConfigFile(".env")
 	viper.ReadInConfig()
+	for key, value := range defaults {
+		viper.SetDefault(key, value)
+	}
 	for _, env := range envs {
 		if err := viper.BindEnv(env); err != nil {
 			return config, err
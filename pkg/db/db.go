@@ -2,17 +2,18 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"taskmanagementapi/pkg/config"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
-func ConnectDatabase(c config.Config) (*mongo.Database, error) {
-	ctx := context.TODO()
-	mongoConn := options.Client().ApplyURI(c.DBUrl)
+func ConnectDatabase(ctx context.Context, c config.Config) (*mongo.Database, error) {
+	mongoConn := options.Client().ApplyURI(c.DBUrl).SetMonitor(commandMonitor())
 	mongoClient, err := mongo.Connect(ctx, mongoConn)
 	if err != nil {
 		return nil, err
@@ -21,6 +22,27 @@ func ConnectDatabase(c config.Config) (*mongo.Database, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkTransactions(ctx, mongoClient); err != nil {
+		return nil, err
+	}
 	fmt.Println("mongo connection established")
 	return mongoClient.Database(c.DBName), nil
 }
+
+// checkTransactions fails startup on a standalone mongod. Deleting an account
+// runs in a transaction, which MongoDB only supports on a replica set or a
+// sharded cluster, and would otherwise fail on first use with "Transaction
+// numbers are only allowed on a replica set member or mongos".
+func checkTransactions(ctx context.Context, client *mongo.Client) error {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return fmt.Errorf("check mongo deployment: %w", err)
+	}
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		return errors.New("DB_URL points at a standalone mongod, but transactions need a replica set or a sharded cluster; a single-node replica set is enough")
+	}
+	return nil
+}
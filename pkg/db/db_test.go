@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCheckTransactions(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	testCases := map[string]struct {
+		hello   bson.D
+		wantErr bool
+	}{
+		"replica set":     {hello: bson.D{{Key: "ok", Value: 1}, {Key: "setName", Value: "rs0"}}},
+		"sharded cluster": {hello: bson.D{{Key: "ok", Value: 1}, {Key: "msg", Value: "isdbgrid"}}},
+		"standalone":      {hello: bson.D{{Key: "ok", Value: 1}, {Key: "isWritablePrimary", Value: true}}, wantErr: true},
+	}
+	for name, test := range testCases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(test.hello)
+
+			err := checkTransactions(context.Background(), mt.Client)
+
+			if test.wantErr {
+				assert.ErrorContains(t, err, "standalone mongod")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"taskmanagementapi/pkg/metrics"
+	"taskmanagementapi/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type startedCommand struct {
+	collection string
+	span       trace.Span
+}
+
+// commandMonitor feeds every command the driver sends into the metrics and,
+// within a traced request, a span. The finished events do not carry the
+// command document, so what was learned from it is kept until then.
+func commandMonitor() *event.CommandMonitor {
+	var started sync.Map
+	key := func(connectionID string, requestID int64) string {
+		return connectionID + "/" + strconv.FormatInt(requestID, 10)
+	}
+	finished := func(e event.CommandFinishedEvent, failure string) {
+		value, ok := started.LoadAndDelete(key(e.ConnectionID, e.RequestID))
+		if !ok {
+			return
+		}
+		command := value.(startedCommand)
+		metrics.ObserveCommand(command.collection, e.CommandName, e.Duration, failure != "")
+		if command.span == nil {
+			return
+		}
+		if failure != "" {
+			command.span.SetStatus(codes.Error, failure)
+		}
+		command.span.End()
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			command := startedCommand{collection: commandCollection(e.Command)}
+			if span, ok := tracing.StartCommand(ctx, e.DatabaseName, command.collection, e.CommandName); ok {
+				command.span = span
+			}
+			started.Store(key(e.ConnectionID, e.RequestID), command)
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			finished(e.CommandFinishedEvent, "")
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			finished(e.CommandFinishedEvent, e.Failure)
+		},
+	}
+}
+
+// commandCollection returns the collection a command targets. Most commands
+// name it as the value of their first element; getMore names it separately,
+// and database commands such as ping have none.
+func commandCollection(command bson.Raw) string {
+	elements, err := command.Elements()
+	if err != nil || len(elements) == 0 {
+		return ""
+	}
+	if elements[0].Key() == "getMore" {
+		collection, _ := command.Lookup("collection").StringValueOK()
+		return collection
+	}
+	if elements[0].Value().Type != bsontype.String {
+		return ""
+	}
+	return elements[0].Value().StringValue()
+}
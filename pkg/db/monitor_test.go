@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestCommandCollection(t *testing.T) {
+	testCases := map[string]struct {
+		command bson.D
+		want    string
+	}{
+		"find":     {command: bson.D{{Key: "find", Value: "tasks"}, {Key: "filter", Value: bson.D{}}}, want: "tasks"},
+		"getMore":  {command: bson.D{{Key: "getMore", Value: int64(7)}, {Key: "collection", Value: "users"}}, want: "users"},
+		"database": {command: bson.D{{Key: "ping", Value: 1}}},
+	}
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			raw, err := bson.Marshal(test.command)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, commandCollection(raw))
+		})
+	}
+}
+
+func TestCommandMonitorSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	monitor := commandMonitor()
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	started := func(ctx context.Context, requestID int64, command bson.D) {
+		raw, _ := bson.Marshal(command)
+		monitor.Started(ctx, &event.CommandStartedEvent{Command: raw, DatabaseName: "app", CommandName: command[0].Key, RequestID: requestID, ConnectionID: "c1"})
+	}
+	finished := func(requestID int64, name string) event.CommandFinishedEvent {
+		return event.CommandFinishedEvent{CommandName: name, RequestID: requestID, ConnectionID: "c1", Duration: time.Millisecond}
+	}
+
+	started(ctx, 1, bson.D{{Key: "insert", Value: "tasks"}})
+	started(ctx, 2, bson.D{{Key: "find", Value: "tasks"}})
+	started(context.Background(), 3, bson.D{{Key: "delete", Value: "sessions"}})
+	monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: finished(1, "insert"), Failure: "duplicate key"})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: finished(2, "find")})
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished(3, "delete")})
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3, "the untraced delete has no span")
+	assert.Equal(t, "insert tasks", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, "find tasks", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, "request", spans[2].Name())
+}
@@ -1,31 +1,145 @@
 package di
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	server "taskmanagementapi/pkg/api"
 	"taskmanagementapi/pkg/api/handlers"
 	"taskmanagementapi/pkg/config"
 	"taskmanagementapi/pkg/db"
+	"taskmanagementapi/pkg/keyset"
+	"taskmanagementapi/pkg/mailer"
+	"taskmanagementapi/pkg/oidc"
+	"taskmanagementapi/pkg/password"
 	"taskmanagementapi/pkg/repository"
+	interfaces "taskmanagementapi/pkg/repository/interface"
 	"taskmanagementapi/pkg/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func InitializeAPI(cfg config.Config) (*server.ServerHTTP, error) {
-	database, err := db.ConnectDatabase(cfg)
+// InitializeAPI builds the server and starts the background workers. The
+// returned cleanup stops the workers and only then disconnects from MongoDB,
+// so that what they write on the way out, such as session last-seen times,
+// is not lost.
+func InitializeAPI(ctx context.Context, cfg config.Config, logger *slog.Logger) (*server.ServerHTTP, func(context.Context) error, error) {
+	database, err := db.ConnectDatabase(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := repository.CreateIndexes(ctx, database); err != nil {
+		return nil, nil, err
+	}
+	keySet, err := keyset.New(ctx, cfg, repository.NewSigningKeyRepository(database))
+	if err != nil {
+		return nil, nil, err
+	}
+	passwordPolicy, err := password.NewPolicy(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	hasher, err := password.NewHasher(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	userRepository := repository.NewUserRepository(database)
+
+	userRepository := repository.NewUserRepository(database, keySet)
 	taskRepository := repository.NewTaskRepository(database)
+	tokenRepository := repository.NewTokenRepository(database)
+	auditRepository := repository.NewAuditRepository(database)
+	oidcRepository := repository.NewOIDCRepository(database)
+	attemptRepository := repository.NewLoginAttemptRepository(database)
+	accountRepository := repository.NewAccountRepository(database)
+	sessionRepository := repository.NewSessionRepository(database)
+	preferenceRepository := repository.NewPreferenceRepository(database)
+	idempotencyRepository := repository.NewIdempotencyRepository(database)
+	rateLimitRepository, err := newRateLimitRepository(cfg, database)
+	if err != nil {
+		return nil, nil, err
+	}
+	healthRepository := repository.NewHealthRepository(database)
 
-	UserUseCase := usecase.NewUserUseCase(userRepository)
-	TaskUseCase := usecase.NewTaskUseCase(taskRepository)
+	UserUseCase := usecase.NewTracedUserUseCase(usecase.NewUserUseCase(userRepository, attemptRepository, sessionRepository, tokenRepository, mailer.NewLogMailer(logger), passwordPolicy, hasher))
+	TaskUseCase := usecase.NewTracedTaskUseCase(usecase.NewTaskUseCase(taskRepository, preferenceRepository))
+	TokenUseCase := usecase.NewTokenUseCase(tokenRepository, userRepository, sessionRepository, keySet)
+	AdminUseCase := usecase.NewAdminUseCase(userRepository, taskRepository, tokenRepository, sessionRepository, auditRepository, passwordPolicy, hasher)
+	OIDCUseCase := usecase.NewOIDCUseCase(oidc.NewClient(cfg), oidcRepository, userRepository, sessionRepository)
+	AccountUseCase := usecase.NewAccountUseCase(userRepository, taskRepository, tokenRepository, preferenceRepository, accountRepository, hasher)
+	SessionUseCase := usecase.NewSessionUseCase(sessionRepository)
+	PreferenceUseCase := usecase.NewPreferenceUseCase(preferenceRepository)
+	IdempotencyUseCase := usecase.NewIdempotencyUseCase(idempotencyRepository, cfg.IdempotencyKeyTTL)
+	RateLimitUseCase := usecase.NewRateLimitUseCase(rateLimitRepository)
+	HealthUseCase := usecase.NewHealthUseCase(healthRepository, cfg.ReadinessTimeout)
 
 	userHandler := handlers.NewUserHandler(UserUseCase)
 	taskHandler := handlers.NewTaskHandler(TaskUseCase)
+	tokenHandler := handlers.NewTokenHandler(TokenUseCase)
+	adminHandler := handlers.NewAdminHandler(AdminUseCase)
+	oidcHandler := handlers.NewOIDCHandler(OIDCUseCase)
+	accountHandler := handlers.NewAccountHandler(AccountUseCase)
+	sessionHandler := handlers.NewSessionHandler(SessionUseCase)
+	preferenceHandler := handlers.NewPreferenceHandler(PreferenceUseCase)
+	healthHandler := handlers.NewHealthHandler(HealthUseCase)
 
-	serverHttp := server.NewServerHTTP(userHandler,
+	serverHttp, err := server.NewServerHTTP(cfg, logger,
+		userHandler,
 		taskHandler,
+		tokenHandler,
+		adminHandler,
+		oidcHandler,
+		accountHandler,
+		sessionHandler,
+		preferenceHandler,
+		healthHandler,
+		TokenUseCase,
+		SessionUseCase,
+		IdempotencyUseCase,
+		RateLimitUseCase,
 	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Every process that serves keeps its own keys fresh and flushes the
+	// last-seen times it buffered. The purges work on shared collections, so
+	// with prefork only the master runs them.
+	workers, stopWorkers := context.WithCancel(context.Background())
+	serves := !cfg.HTTPPrefork || fiber.IsChild()
+	if serves {
+		HealthUseCase.Go(workers, "keyset", keyset.RefreshInterval, keySet.Refresh)
+		HealthUseCase.Go(workers, "sessions", usecase.SessionFlushInterval, SessionUseCase.Flush)
+	}
+	if !fiber.IsChild() {
+		HealthUseCase.Go(workers, "idempotency", usecase.IdempotencyPurgeInterval, IdempotencyUseCase.Purge)
+		HealthUseCase.Go(workers, "rate_limit", usecase.RateLimitPurgeInterval, RateLimitUseCase.Purge)
+	}
 
-	return serverHttp, nil
+	cleanup := func(ctx context.Context) error {
+		stopWorkers()
+		err := HealthUseCase.Wait(ctx)
+		if serves {
+			// Last-seen times touched since the last flush.
+			err = errors.Join(err, SessionUseCase.Flush(ctx))
+		}
+		return errors.Join(err, database.Client().Disconnect(ctx))
+	}
+	return serverHttp, cleanup, nil
+}
+
+// newRateLimitRepository picks where rate limit buckets live. The Mongo store
+// is shared by every API instance; the memory store is per instance.
+func newRateLimitRepository(cfg config.Config, database *mongo.Database) (interfaces.RateLimitRepository, error) {
+	switch cfg.RateLimitStore {
+	case "memory":
+		if cfg.HTTPPrefork {
+			return nil, errors.New("RATE_LIMIT_STORE=memory cannot be used with HTTP_PREFORK, since every child would keep its own limits; use mongo")
+		}
+		return repository.NewMemoryRateLimitRepository(), nil
+	case "mongo":
+		return repository.NewRateLimitRepository(database), nil
+	}
+	return nil, fmt.Errorf("unknown RATE_LIMIT_STORE %q", cfg.RateLimitStore)
 }
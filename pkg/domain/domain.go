@@ -11,6 +11,8 @@ type User struct {
 	Name     string             `json:"Name"`
 	Email    string             `json:"email"`
 	Password string             `json:"password"`
+	Role     string             `bson:"role" json:"role"`
+	Disabled bool               `bson:"disabled" json:"disabled"`
 }
 
 type Task struct {
@@ -20,3 +22,23 @@ type Task struct {
 	Description string             `json:"description"`
 	CreatedAt   time.Time          `json:"created_at"`
 }
+
+type Token struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID    string             `bson:"user_id" json:"user_id"`
+	Name      string             `bson:"name" json:"name"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	Scopes    []string           `bson:"scopes" json:"scopes"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	ExpiresAt *time.Time         `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+}
+
+type AuditLog struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	ActorID   string             `bson:"actor_id" json:"actor_id"`
+	Action    string             `bson:"action" json:"action"`
+	TargetID  string             `bson:"target_id,omitempty" json:"target_id,omitempty"`
+	Details   string             `bson:"details,omitempty" json:"details,omitempty"`
+	Outcome   string             `bson:"outcome,omitempty" json:"outcome,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -1,17 +1,20 @@
 package helper
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
-	"fmt"
-	"taskmanagementapi/pkg/config"
+	"strings"
 
 	"github.com/golang-jwt/jwt"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthUserClaims struct {
 	Id    string
 	Email string
+	Role  string
 	jwt.StandardClaims
 }
 
@@ -23,33 +26,23 @@ func GetTokenFromHeader(header string) string {
 	return header
 }
 
-func ExtractUserIDFromToken(tokenString string) (string, string, error) {
-	cfg, _ := config.LoadConfig()
-	token, err := jwt.ParseWithClaims(tokenString, &AuthUserClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("invalid signing method")
-		}
-		return []byte(cfg.JwtSecretKey), nil
-	})
-
-	if err != nil {
-		return "", "", err
-	}
+const accessTokenPrefix = "tma_"
 
-	claims, ok := token.Claims.(*AuthUserClaims)
-	if !ok {
-		return "", "", fmt.Errorf("invalid token claims")
+func GenerateAccessToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.New("error from generating access token")
 	}
+	return accessTokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
 
-	return claims.Id, claims.Email, nil
-
+// HashAccessToken returns the digest stored in place of a personal access
+// token. Tokens carry 256 bits of entropy, so a fast hash is sufficient.
+func HashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
-func PasswordHash(password string) (string, error) {
-	hashPassword, err := bcrypt.GenerateFromPassword([]byte(password), 10)
-	if err != nil {
-		return "", errors.New("error from password hashing")
-	}
-	hash := string(hashPassword)
-	return hash, nil
-}
\ No newline at end of file
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, accessTokenPrefix)
+}
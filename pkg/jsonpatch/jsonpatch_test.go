@@ -0,0 +1,73 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MergePatch(t *testing.T) {
+	testCases := map[string]struct {
+		doc, patch, want string
+	}{
+		"replace":          {doc: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		"add":              {doc: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		"remove":           {doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		"replace array":    {doc: `{"a":["b"]}`, patch: `{"a":["c"]}`, want: `{"a":["c"]}`},
+		"nested":           {doc: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, want: `{"a":{"b":"d"}}`},
+		"non-object patch": {doc: `{"a":"foo"}`, patch: `["c"]`, want: `["c"]`},
+		"object into null": {doc: `{"e":null}`, patch: `{"a":1}`, want: `{"a":1,"e":null}`},
+		"create nested":    {doc: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, want: `{"a":{"bb":{}}}`},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tc.doc), []byte(tc.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+
+	_, err := MergePatch([]byte(`{}`), []byte(`{`))
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+func Test_Apply(t *testing.T) {
+	testCases := map[string]struct {
+		doc, patch, want string
+		wantErr          error
+	}{
+		"add member":       {doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz","value":"qux"}]`, want: `{"baz":"qux","foo":"bar"}`},
+		"add array item":   {doc: `{"foo":["bar","baz"]}`, patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`, want: `{"foo":["bar","qux","baz"]}`},
+		"append":           {doc: `{"foo":["bar"]}`, patch: `[{"op":"add","path":"/foo/-","value":["abc"]}]`, want: `{"foo":["bar",["abc"]]}`},
+		"remove member":    {doc: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"remove","path":"/baz"}]`, want: `{"foo":"bar"}`},
+		"remove item":      {doc: `{"foo":["bar","qux","baz"]}`, patch: `[{"op":"remove","path":"/foo/1"}]`, want: `{"foo":["bar","baz"]}`},
+		"replace":          {doc: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"replace","path":"/baz","value":"boo"}]`, want: `{"baz":"boo","foo":"bar"}`},
+		"move":             {doc: `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, patch: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, want: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		"move item":        {doc: `{"foo":["all","grass","cows","eat"]}`, patch: `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, want: `{"foo":["all","cows","eat","grass"]}`},
+		"copy":             {doc: `{"a":{"b":1}}`, patch: `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`, want: `{"a":{"b":1},"c":{"b":2}}`},
+		"test passes":      {doc: `{"baz":"qux","foo":["a",2,"c"]}`, patch: `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, want: `{"baz":"qux","foo":["a",2,"c"]}`},
+		"escaped pointer":  {doc: `{"/":9,"~1":10}`, patch: `[{"op":"test","path":"/~01","value":10},{"op":"remove","path":"/~1"}]`, want: `{"~1":10}`},
+		"null value":       {doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/child","value":null}]`, want: `{"child":null,"foo":"bar"}`},
+		"test fails":       {doc: `{"baz":"qux"}`, patch: `[{"op":"test","path":"/baz","value":"bar"}]`, wantErr: ErrConflict},
+		"missing target":   {doc: `{"foo":"bar"}`, patch: `[{"op":"remove","path":"/baz"}]`, wantErr: ErrConflict},
+		"missing parent":   {doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz/bat","value":"qux"}]`, wantErr: ErrConflict},
+		"index too large":  {doc: `{"foo":["bar"]}`, patch: `[{"op":"add","path":"/foo/2","value":"qux"}]`, wantErr: ErrConflict},
+		"leading zero":     {doc: `{"foo":["bar","baz"]}`, patch: `[{"op":"remove","path":"/foo/01"}]`, wantErr: ErrInvalid},
+		"unknown op":       {doc: `{}`, patch: `[{"op":"merge","path":"/a","value":1}]`, wantErr: ErrInvalid},
+		"missing value":    {doc: `{}`, patch: `[{"op":"add","path":"/a"}]`, wantErr: ErrInvalid},
+		"move into itself": {doc: `{"a":{}}`, patch: `[{"op":"move","from":"/a","path":"/a/b"}]`, wantErr: ErrInvalid},
+		"not an array":     {doc: `{}`, patch: `{"op":"add"}`, wantErr: ErrInvalid},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Apply([]byte(tc.doc), []byte(tc.patch))
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
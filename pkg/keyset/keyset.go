@@ -0,0 +1,295 @@
+package keyset
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"taskmanagementapi/pkg/config"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	// RefreshInterval is how often Refresh should run.
+	RefreshInterval = 5 * time.Minute
+	reloadCooldown  = 30 * time.Second
+)
+
+type key struct {
+	id        string
+	algorithm string
+	private   interface{}
+	public    interface{}
+	createdAt time.Time
+}
+
+// KeySet signs and verifies the JWTs issued by this service.
+//
+// With HS256 every token is signed with JWT_SECRET_KEY. With RS256 or EdDSA
+// the private keys live in the signing_keys collection so that all instances
+// share them: the newest active key signs, and a key that has been rotated out
+// keeps verifying until every token it signed has expired.
+type KeySet struct {
+	algorithm   string
+	secret      []byte
+	rotateEvery time.Duration
+	retention   time.Duration
+	repository  interfaces.SigningKeyRepository
+
+	mu         sync.RWMutex
+	active     *key
+	keys       map[string]*key
+	lastReload time.Time
+}
+
+func New(ctx context.Context, cfg config.Config, repository interfaces.SigningKeyRepository) (*KeySet, error) {
+	ks := &KeySet{
+		algorithm:   cfg.JwtAlgorithm,
+		rotateEvery: cfg.JwtKeyRotationInterval,
+		retention:   cfg.JwtTokenTTL,
+		repository:  repository,
+		keys:        make(map[string]*key),
+	}
+	switch ks.algorithm {
+	case AlgorithmHS256:
+		if cfg.JwtSecretKey == "" {
+			return nil, errors.New("JWT_SECRET_KEY is required for HS256")
+		}
+		ks.secret = []byte(cfg.JwtSecretKey)
+		return ks, nil
+	case AlgorithmRS256, AlgorithmEdDSA:
+		if err := ks.Refresh(ctx); err != nil {
+			return nil, err
+		}
+		return ks, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALGORITHM %q", ks.algorithm)
+	}
+}
+
+func (ks *KeySet) TokenTTL() time.Duration {
+	return ks.retention
+}
+
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if ks.secret != nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.secret)
+	}
+	ks.mu.RLock()
+	active := ks.active
+	ks.mu.RUnlock()
+	if active == nil {
+		return "", errors.New("no active signing key")
+	}
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(active.algorithm), claims)
+	token.Header["kid"] = active.id
+	return token.SignedString(active.private)
+}
+
+func (ks *KeySet) Parse(ctx context.Context, tokenString string, claims jwt.Claims) error {
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if ks.secret != nil {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("invalid signing method")
+			}
+			return ks.secret, nil
+		}
+		kid, _ := token.Header["kid"].(string)
+		k := ks.lookup(ctx, kid)
+		if k == nil {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		if token.Method.Alg() != k.algorithm {
+			return nil, fmt.Errorf("invalid signing method")
+		}
+		return k.public, nil
+	})
+	return err
+}
+
+// lookup finds a verification key, reloading from the repository when the
+// kid is unknown because another instance may just have rotated.
+func (ks *KeySet) lookup(ctx context.Context, kid string) *key {
+	ks.mu.RLock()
+	k := ks.keys[kid]
+	stale := time.Since(ks.lastReload) > reloadCooldown
+	ks.mu.RUnlock()
+	if k != nil || !stale {
+		return k
+	}
+	if err := ks.load(ctx); err != nil {
+		return nil
+	}
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return ks.keys[kid]
+}
+
+func (ks *KeySet) JWKS() models.JWKS {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	set := models.JWKS{Keys: []models.JWK{}}
+	for _, k := range ks.keys {
+		jwk := models.JWK{KeyID: k.id, Use: "sig", Algorithm: k.algorithm}
+		switch pub := k.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
+
+// Refresh rotates in a new signing key when the active one is older than the
+// rotation interval, deletes keys whose tokens can no longer be valid and
+// reloads the set. A shared secret never rotates, so then there is nothing to
+// do.
+func (ks *KeySet) Refresh(ctx context.Context) error {
+	if ks.secret != nil {
+		return nil
+	}
+	now := time.Now().UTC()
+	if err := ks.repository.DeleteRetiredBefore(ctx, now.Add(-ks.retention)); err != nil {
+		return err
+	}
+	if err := ks.load(ctx); err != nil {
+		return err
+	}
+	ks.mu.RLock()
+	active := ks.active
+	ks.mu.RUnlock()
+	if active != nil && now.Sub(active.createdAt) < ks.rotateEvery {
+		return ks.repository.RetireKeys(ctx, active.createdAt, now)
+	}
+	return ks.Rotate(ctx)
+}
+
+// Rotate generates a new signing key and retires the previous ones.
+func (ks *KeySet) Rotate(ctx context.Context) error {
+	record, err := generate(ks.algorithm)
+	if err != nil {
+		return err
+	}
+	if err := ks.repository.InsertKey(ctx, record); err != nil {
+		return err
+	}
+	if err := ks.repository.RetireKeys(ctx, record.CreatedAt, record.CreatedAt); err != nil {
+		return err
+	}
+	return ks.load(ctx)
+}
+
+func (ks *KeySet) load(ctx context.Context) error {
+	records, err := ks.repository.GetKeys(ctx)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]*key, len(records))
+	var active *key
+	for _, record := range records {
+		k, err := parse(record)
+		if err != nil {
+			return err
+		}
+		keys[k.id] = k
+		if record.RetiredAt == nil && k.algorithm == ks.algorithm && (active == nil || newer(k, active)) {
+			active = k
+		}
+	}
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.active = active
+	ks.lastReload = time.Now()
+	ks.mu.Unlock()
+	return nil
+}
+
+// newer orders keys by creation time, breaking ties on the kid so that every
+// instance picks the same active key.
+func newer(a, b *key) bool {
+	if a.createdAt.Equal(b.createdAt) {
+		return a.id > b.id
+	}
+	return a.createdAt.After(b.createdAt)
+}
+
+func generate(algorithm string) (models.SigningKey, error) {
+	var private interface{}
+	switch algorithm {
+	case AlgorithmRS256:
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return models.SigningKey{}, err
+		}
+		private = rsaKey
+	case AlgorithmEdDSA:
+		_, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return models.SigningKey{}, err
+		}
+		private = edKey
+	default:
+		return models.SigningKey{}, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return models.SigningKey{}, err
+	}
+	kid := make([]byte, 8)
+	if _, err := rand.Read(kid); err != nil {
+		return models.SigningKey{}, err
+	}
+	return models.SigningKey{
+		KID:        hex.EncodeToString(kid),
+		Algorithm:  algorithm,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		CreatedAt:  time.Now().UTC().Truncate(time.Millisecond),
+	}, nil
+}
+
+func parse(record models.SigningKey) (*key, error) {
+	block, _ := pem.Decode([]byte(record.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", record.KID)
+	}
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	k := &key{id: record.KID, algorithm: record.Algorithm, private: private, createdAt: record.CreatedAt}
+	switch priv := private.(type) {
+	case *rsa.PrivateKey:
+		k.public = &priv.PublicKey
+	case ed25519.PrivateKey:
+		k.public = priv.Public()
+	default:
+		return nil, fmt.Errorf("signing key %s has unsupported type", record.KID)
+	}
+	return k, nil
+}
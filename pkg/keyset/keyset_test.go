@@ -0,0 +1,189 @@
+package keyset_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/config"
+	"taskmanagementapi/pkg/helper"
+	"taskmanagementapi/pkg/keyset"
+	"taskmanagementapi/pkg/repository/mock"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryKeys backs the mock repository with a slice so that rotation can be
+// observed across calls.
+type memoryKeys struct {
+	keys []models.SigningKey
+}
+
+func (m *memoryKeys) stub(repo *mock.MockSigningKeyRepository) {
+	repo.EXPECT().GetKeys(gomock.Any()).DoAndReturn(func(context.Context) ([]models.SigningKey, error) {
+		return append([]models.SigningKey(nil), m.keys...), nil
+	}).AnyTimes()
+	repo.EXPECT().InsertKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key models.SigningKey) error {
+		m.keys = append(m.keys, key)
+		return nil
+	}).AnyTimes()
+	repo.EXPECT().RetireKeys(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, createdBefore, at time.Time) error {
+		for i := range m.keys {
+			if m.keys[i].CreatedAt.Before(createdBefore) && m.keys[i].RetiredAt == nil {
+				retired := at
+				m.keys[i].RetiredAt = &retired
+			}
+		}
+		return nil
+	}).AnyTimes()
+	repo.EXPECT().DeleteRetiredBefore(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) error {
+		kept := m.keys[:0]
+		for _, key := range m.keys {
+			if key.RetiredAt == nil || !key.RetiredAt.Before(before) {
+				kept = append(kept, key)
+			}
+		}
+		m.keys = kept
+		return nil
+	}).AnyTimes()
+}
+
+func newKeySet(t *testing.T, algorithm string) (*keyset.KeySet, *memoryKeys) {
+	store := &memoryKeys{}
+	return newKeySetOn(t, algorithm, store), store
+}
+
+// newKeySetOn builds a key set over an existing store, like another instance
+// sharing the database.
+func newKeySetOn(t *testing.T, algorithm string, store *memoryKeys) *keyset.KeySet {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockSigningKeyRepository(ctrl)
+	store.stub(repo)
+	ks, err := keyset.New(context.Background(), config.Config{
+		JwtAlgorithm:           algorithm,
+		JwtTokenTTL:            24 * time.Hour,
+		JwtKeyRotationInterval: 720 * time.Hour,
+	}, repo)
+	require.NoError(t, err)
+	return ks
+}
+
+func sign(t *testing.T, ks *keyset.KeySet) string {
+	token, err := ks.Sign(&helper.AuthUserClaims{
+		Id:             "user1",
+		Email:          "arun@example.com",
+		StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(time.Hour).Unix()},
+	})
+	require.NoError(t, err)
+	return token
+}
+
+func TestSignAndParse(t *testing.T) {
+	for _, algorithm := range []string{keyset.AlgorithmRS256, keyset.AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			ks, store := newKeySet(t, algorithm)
+			require.Len(t, store.keys, 1)
+
+			token := sign(t, ks)
+			header, _, _ := new(jwt.Parser).ParseUnverified(token, &helper.AuthUserClaims{})
+			assert.Equal(t, algorithm, header.Header["alg"])
+			assert.Equal(t, store.keys[0].KID, header.Header["kid"])
+
+			claims := &helper.AuthUserClaims{}
+			require.NoError(t, ks.Parse(context.Background(), token, claims))
+			assert.Equal(t, "user1", claims.Id)
+
+			jwks := ks.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, store.keys[0].KID, jwks.Keys[0].KeyID)
+			assert.Equal(t, algorithm, jwks.Keys[0].Algorithm)
+		})
+	}
+}
+
+func TestRotationKeepsOldKeysValid(t *testing.T) {
+	ks, store := newKeySet(t, keyset.AlgorithmRS256)
+	oldToken := sign(t, ks)
+	oldKID := store.keys[0].KID
+
+	require.NoError(t, ks.Rotate(context.Background()))
+	newToken := sign(t, ks)
+
+	header, _, _ := new(jwt.Parser).ParseUnverified(newToken, &helper.AuthUserClaims{})
+	assert.NotEqual(t, oldKID, header.Header["kid"])
+	assert.NoError(t, ks.Parse(context.Background(), oldToken, &helper.AuthUserClaims{}))
+	assert.NoError(t, ks.Parse(context.Background(), newToken, &helper.AuthUserClaims{}))
+	assert.Len(t, ks.JWKS().Keys, 2)
+
+	// Once the retired key has outlived the token TTL it is dropped.
+	expired := time.Now().Add(-25 * time.Hour)
+	store.keys[0].RetiredAt = &expired
+	require.NoError(t, ks.Refresh(context.Background()))
+	assert.Len(t, ks.JWKS().Keys, 1)
+	assert.Error(t, ks.Parse(context.Background(), oldToken, &helper.AuthUserClaims{}))
+	assert.NoError(t, ks.Parse(context.Background(), newToken, &helper.AuthUserClaims{}))
+}
+
+func TestRefreshRotatesStaleKey(t *testing.T) {
+	ks, store := newKeySet(t, keyset.AlgorithmEdDSA)
+	store.keys[0].CreatedAt = time.Now().Add(-721 * time.Hour)
+
+	require.NoError(t, ks.Refresh(context.Background()))
+
+	require.Len(t, store.keys, 2)
+	assert.NotNil(t, store.keys[0].RetiredAt)
+	assert.Nil(t, store.keys[1].RetiredAt)
+}
+
+func TestConcurrentRotationKeepsNewestKey(t *testing.T) {
+	first, store := newKeySet(t, keyset.AlgorithmRS256)
+	second := newKeySetOn(t, keyset.AlgorithmRS256, store)
+
+	// The second instance rotates in a key that is newer than the one the
+	// first is about to create, and the first retires keys afterwards.
+	require.NoError(t, second.Rotate(context.Background()))
+	newest := &store.keys[len(store.keys)-1]
+	newest.CreatedAt = newest.CreatedAt.Add(time.Minute)
+	require.NoError(t, first.Rotate(context.Background()))
+
+	assert.Nil(t, store.keys[1].RetiredAt)
+
+	require.NoError(t, first.Refresh(context.Background()))
+	require.NoError(t, second.Refresh(context.Background()))
+	for _, ks := range []*keyset.KeySet{first, second} {
+		header, _, _ := new(jwt.Parser).ParseUnverified(sign(t, ks), &helper.AuthUserClaims{})
+		assert.Equal(t, store.keys[1].KID, header.Header["kid"])
+	}
+	for _, key := range store.keys {
+		assert.Equal(t, key.KID != store.keys[1].KID, key.RetiredAt != nil, key.KID)
+	}
+}
+
+func TestRejectsForeignTokens(t *testing.T) {
+	ks, _ := newKeySet(t, keyset.AlgorithmRS256)
+	other, _ := newKeySet(t, keyset.AlgorithmRS256)
+
+	assert.Error(t, ks.Parse(context.Background(), sign(t, other), &helper.AuthUserClaims{}))
+
+	hmac, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &helper.AuthUserClaims{Id: "user1"}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	assert.Error(t, ks.Parse(context.Background(), hmac, &helper.AuthUserClaims{}))
+}
+
+func TestHS256(t *testing.T) {
+	ks, err := keyset.New(context.Background(), config.Config{JwtAlgorithm: keyset.AlgorithmHS256, JwtSecretKey: "secret", JwtTokenTTL: time.Hour}, nil)
+	require.NoError(t, err)
+
+	claims := &helper.AuthUserClaims{}
+	assert.NoError(t, ks.Parse(context.Background(), sign(t, ks), claims))
+	assert.Equal(t, "user1", claims.Id)
+	assert.Empty(t, ks.JWKS().Keys)
+
+	_, err = keyset.New(context.Background(), config.Config{JwtAlgorithm: keyset.AlgorithmHS256}, nil)
+	assert.Error(t, err)
+	_, err = keyset.New(context.Background(), config.Config{JwtAlgorithm: "none"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"taskmanagementapi/pkg/logging"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "warn")
+	require.NoError(t, err)
+
+	logger.Info("dropped")
+	logger.Warn("kept", "n", 1)
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "WARN", line["level"])
+	assert.Equal(t, "kept", line["msg"])
+	assert.Equal(t, float64(1), line["n"])
+
+	_, err = logging.New(&buf, "verbose")
+	assert.EqualError(t, err, `unknown LOG_LEVEL "verbose"`)
+}
+
+func TestContext(t *testing.T) {
+	assert.Same(t, slog.Default(), logging.FromContext(context.Background()))
+
+	var buf bytes.Buffer
+	logger, _ := logging.New(&buf, "info")
+	ctx := logging.WithContext(context.Background(), logger.With("request_id", "r1"))
+	ctx = logging.With(ctx, "user_id", "u1")
+	logging.FromContext(ctx).Info("hello")
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "r1", line["request_id"])
+	assert.Equal(t, "u1", line["user_id"])
+}
@@ -0,0 +1,23 @@
+package mailer
+
+import "log/slog"
+
+// Mailer delivers transactional email such as address verification links.
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+type logMailer struct {
+	logger *slog.Logger
+}
+
+// NewLogMailer returns a Mailer that writes messages to the log instead of
+// delivering them. It is the default until an SMTP transport is configured.
+func NewLogMailer(logger *slog.Logger) Mailer {
+	return &logMailer{logger: logger.With("component", "mailer")}
+}
+
+func (m *logMailer) Send(to, subject, body string) error {
+	m.logger.Info("mail", "to", to, "subject", subject, "body", body)
+	return nil
+}
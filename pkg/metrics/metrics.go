@@ -0,0 +1,87 @@
+// Package metrics holds the Prometheus collectors the service exports on
+// /metrics and the helpers that update them.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Registry is what /metrics serves. It is separate from the client's global
+// registry so that only collectors registered here are exported.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests handled, by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time to handle HTTP requests, by method, route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	mongoDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mongodb_command_duration_seconds",
+		Help:    "Time MongoDB took to run commands, by collection and command.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"collection", "command"})
+
+	mongoErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mongodb_command_errors_total",
+		Help: "MongoDB commands that failed, by collection and command.",
+	}, []string{"collection", "command"})
+
+	authAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_attempts_total",
+		Help: "Authentication attempts, by method and result.",
+	}, []string{"method", "result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration, mongoDuration, mongoErrors, authAttempts,
+	)
+}
+
+// The methods counted by AuthAttempt.
+const (
+	AuthPassword    = "password"
+	AuthOIDC        = "oidc"
+	AuthJWT         = "jwt"
+	AuthAccessToken = "access_token"
+)
+
+// ObserveRequest records a handled request. route is the route pattern, such
+// as /v1/tasks/:id, so that the number of series stays bounded.
+func ObserveRequest(method, route string, status int, duration time.Duration) {
+	labels := prometheus.Labels{"method": method, "route": route, "status": strconv.Itoa(status)}
+	httpRequests.With(labels).Inc()
+	httpDuration.With(labels).Observe(duration.Seconds())
+}
+
+// AuthAttempt counts a sign-in or a credential check on an authenticated
+// request; err is the outcome.
+func AuthAttempt(method string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	authAttempts.WithLabelValues(method, result).Inc()
+}
+
+// ObserveCommand records a MongoDB command that the driver reported as
+// finished.
+func ObserveCommand(collection, command string, duration time.Duration, failed bool) {
+	mongoDuration.WithLabelValues(collection, command).Observe(duration.Seconds())
+	if failed {
+		mongoErrors.WithLabelValues(collection, command).Inc()
+	}
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveRequest(t *testing.T) {
+	before := testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/v1/tasks/:id", "404"))
+
+	ObserveRequest("GET", "/v1/tasks/:id", 404, 20*time.Millisecond)
+
+	assert.Equal(t, before+1, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/v1/tasks/:id", "404")))
+}
+
+func TestAuthAttempt(t *testing.T) {
+	success := testutil.ToFloat64(authAttempts.WithLabelValues(AuthPassword, "success"))
+	failure := testutil.ToFloat64(authAttempts.WithLabelValues(AuthPassword, "failure"))
+
+	AuthAttempt(AuthPassword, nil)
+	AuthAttempt(AuthPassword, errors.New("wrong password"))
+	AuthAttempt(AuthPassword, errors.New("wrong password"))
+
+	assert.Equal(t, success+1, testutil.ToFloat64(authAttempts.WithLabelValues(AuthPassword, "success")))
+	assert.Equal(t, failure+2, testutil.ToFloat64(authAttempts.WithLabelValues(AuthPassword, "failure")))
+}
+
+func TestObserveCommand(t *testing.T) {
+	errorsBefore := testutil.ToFloat64(mongoErrors.WithLabelValues("tasks", "insert"))
+	countBefore := sampleCount(t, "tasks", "insert")
+
+	ObserveCommand("tasks", "insert", time.Millisecond, false)
+	ObserveCommand("tasks", "insert", time.Millisecond, true)
+
+	assert.Equal(t, errorsBefore+1, testutil.ToFloat64(mongoErrors.WithLabelValues("tasks", "insert")))
+	assert.Equal(t, countBefore+2, sampleCount(t, "tasks", "insert"))
+}
+
+func sampleCount(t *testing.T, labels ...string) uint64 {
+	var m dto.Metric
+	assert.NoError(t, mongoDuration.WithLabelValues(labels...).(prometheus.Histogram).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
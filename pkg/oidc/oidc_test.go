@@ -0,0 +1,94 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"taskmanagementapi/pkg/config"
+	"taskmanagementapi/pkg/oidc"
+	"taskmanagementapi/pkg/oidc/oidctest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T) (*oidc.Client, *oidctest.Provider) {
+	provider, err := oidctest.NewProvider("client", "secret")
+	require.NoError(t, err)
+	t.Cleanup(provider.Close)
+	client := oidc.NewClient(config.Config{
+		OIDCIssuer:       provider.Issuer(),
+		OIDCClientID:     "client",
+		OIDCClientSecret: "secret",
+		OIDCRedirectURL:  "http://localhost:3000/user/oidc/callback",
+	})
+	return client, provider
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	client, provider := newClient(t)
+
+	authURL, err := client.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, provider.Issuer()+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	assert.Equal(t, oidc.CodeChallenge("verifier"), u.Query().Get("code_challenge"))
+	assert.Equal(t, "state", u.Query().Get("state"))
+	assert.Equal(t, "nonce", u.Query().Get("nonce"))
+}
+
+func TestExchange(t *testing.T) {
+	user := oidctest.User{Subject: "sub-1", Email: "arun@example.com", EmailVerified: true, Name: "Arun"}
+
+	t.Run("valid code", func(t *testing.T) {
+		client, provider := newClient(t)
+		authURL, err := client.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+		require.NoError(t, err)
+		code, _, err := provider.Authorize(authURL, user)
+		require.NoError(t, err)
+
+		claims, err := client.Exchange(context.Background(), code, "verifier", "nonce")
+
+		require.NoError(t, err)
+		assert.Equal(t, "sub-1", claims.Subject)
+		assert.Equal(t, "arun@example.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+	})
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		client, provider := newClient(t)
+		authURL, err := client.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+		require.NoError(t, err)
+		code, _, err := provider.Authorize(authURL, user)
+		require.NoError(t, err)
+
+		_, err = client.Exchange(context.Background(), code, "other-verifier", "nonce")
+
+		assert.ErrorIs(t, err, oidc.ErrRejected)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		client, provider := newClient(t)
+		authURL, err := client.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+		require.NoError(t, err)
+		code, _, err := provider.Authorize(authURL, user)
+		require.NoError(t, err)
+
+		_, err = client.Exchange(context.Background(), code, "verifier", "other-nonce")
+
+		assert.EqualError(t, err, "id token nonce mismatch")
+		assert.ErrorIs(t, err, oidc.ErrRejected)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		client := oidc.NewClient(config.Config{})
+
+		_, err := client.Exchange(context.Background(), "code", "verifier", "nonce")
+
+		assert.EqualError(t, err, "oidc login is not configured")
+		assert.NotErrorIs(t, err, oidc.ErrRejected)
+	})
+}
@@ -0,0 +1,160 @@
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const prefixLength = 5
+
+// RangeSource returns the SHA-1 suffixes of breached passwords whose hash
+// starts with prefix.
+type RangeSource interface {
+	Range(prefix string) ([]string, error)
+}
+
+// Corpus is a breached-password list searched in place. The full Pwned
+// Passwords list is tens of gigabytes, so it is never read into memory:
+// every lookup binary-searches the file, which must be sorted by hash.
+type Corpus struct {
+	path string
+	file *os.File
+	// start is the offset of the first hash, after any header comments.
+	start int64
+	size  int64
+}
+
+// LoadCorpus opens a file in the Pwned Passwords SHA-1 format, ordered by
+// hash: one hex hash per line, optionally followed by ":count". Blank lines
+// and lines starting with # are allowed before the first hash. Only the
+// header and the first hash are checked here, since reading the whole file
+// would make startup as slow as loading it; a malformed line found later
+// fails the lookup that reaches it.
+func LoadCorpus(path string) (*Corpus, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	corpus := &Corpus{path: path, file: file, size: info.Size()}
+	reader := bufio.NewReader(file)
+	line := 0
+	for {
+		text, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			file.Close()
+			return nil, err
+		}
+		line++
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			corpus.start += int64(len(text))
+			if err == io.EOF {
+				return corpus, nil
+			}
+			continue
+		}
+		if _, ok := parseHash(trimmed); !ok {
+			file.Close()
+			return nil, fmt.Errorf("%s:%d: not a SHA-1 hash", path, line)
+		}
+		return corpus, nil
+	}
+}
+
+func (c *Corpus) Range(prefix string) ([]string, error) {
+	prefix = strings.ToUpper(prefix)
+
+	// Find the first line whose hash is not below prefix. The search is over
+	// byte offsets; each probe reads the first line starting at or after mid.
+	lo, hi := c.start, c.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		hash, err := c.firstHashFrom(mid)
+		if err != nil {
+			return nil, err
+		}
+		if hash == "" || hash >= prefix {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	reader, err := c.readerFrom(lo)
+	if err != nil {
+		return nil, err
+	}
+	var suffixes []string
+	for {
+		hash, err := c.next(reader)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(hash, prefix) {
+			return suffixes, nil
+		}
+		suffixes = append(suffixes, hash[prefixLength:])
+	}
+}
+
+// firstHashFrom returns the hash on the first line starting at or after
+// offset, or "" past the last line.
+func (c *Corpus) firstHashFrom(offset int64) (string, error) {
+	reader, err := c.readerFrom(offset)
+	if err != nil {
+		return "", err
+	}
+	return c.next(reader)
+}
+
+// readerFrom returns a reader positioned at the first line starting at or
+// after offset.
+func (c *Corpus) readerFrom(offset int64) (*bufio.Reader, error) {
+	if offset <= c.start {
+		return bufio.NewReader(io.NewSectionReader(c.file, c.start, c.size-c.start)), nil
+	}
+	// Reading from the byte before offset skips the rest of the line it is
+	// on, or only that byte when it ends a line.
+	reader := bufio.NewReader(io.NewSectionReader(c.file, offset-1, c.size-offset+1))
+	if _, err := reader.ReadString('\n'); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// next reads the hash on the following line, or "" at the end of the file.
+func (c *Corpus) next(reader *bufio.Reader) (string, error) {
+	text, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	text = strings.TrimSpace(text)
+	if text == "" && err == io.EOF {
+		return "", nil
+	}
+	hash, ok := parseHash(text)
+	if !ok {
+		return "", fmt.Errorf("%s: %q is not a SHA-1 hash", c.path, text)
+	}
+	return hash, nil
+}
+
+// parseHash takes the hash from a "HASH" or "HASH:count" line and upper-cases
+// it, so that files in either case sort and compare the same.
+func parseHash(line string) (string, bool) {
+	hash, _, _ := strings.Cut(line, ":")
+	hash = strings.ToUpper(hash)
+	if len(hash) != 40 || strings.Trim(hash, "0123456789ABCDEF") != "" {
+		return "", false
+	}
+	return hash, true
+}
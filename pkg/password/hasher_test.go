@@ -0,0 +1,86 @@
+package password_test
+
+import (
+	"strings"
+	"taskmanagementapi/pkg/config"
+	"taskmanagementapi/pkg/password"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var fastArgon2 = password.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1}
+
+func Test_HasherRoundTrip(t *testing.T) {
+	hashers := map[string]*password.Hasher{
+		"argon2id": {Algorithm: password.Argon2id, Argon2: fastArgon2},
+		"bcrypt":   {Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost},
+	}
+	for name, hasher := range hashers {
+		t.Run(name, func(t *testing.T) {
+			hash, err := hasher.Hash("correct horse")
+			require.NoError(t, err)
+
+			match, rehash, err := hasher.Verify("correct horse", hash)
+			assert.NoError(t, err)
+			assert.True(t, match)
+			assert.False(t, rehash)
+
+			match, _, err = hasher.Verify("wrong horse", hash)
+			assert.NoError(t, err)
+			assert.False(t, match)
+		})
+	}
+}
+
+func Test_HasherRehash(t *testing.T) {
+	bcryptHasher := &password.Hasher{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost}
+	argonHasher := &password.Hasher{Algorithm: password.Argon2id, Argon2: fastArgon2}
+
+	bcryptHash, err := bcryptHasher.Hash("secret-pass")
+	require.NoError(t, err)
+	argonHash, err := argonHasher.Hash("secret-pass")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(argonHash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	testData := map[string]struct {
+		hasher *password.Hasher
+		hash   string
+		want   bool
+	}{
+		"bcrypt under argon2id":     {hasher: argonHasher, hash: bcryptHash, want: true},
+		"argon2id under bcrypt":     {hasher: bcryptHasher, hash: argonHash, want: true},
+		"bcrypt cost raised":        {hasher: &password.Hasher{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost + 1}, hash: bcryptHash, want: true},
+		"argon2id memory raised":    {hasher: &password.Hasher{Algorithm: password.Argon2id, Argon2: password.Argon2Params{Memory: 2048, Iterations: 1, Parallelism: 1}}, hash: argonHash, want: true},
+		"argon2id with same params": {hasher: argonHasher, hash: argonHash, want: false},
+		"bcrypt with same cost":     {hasher: bcryptHasher, hash: bcryptHash, want: false},
+	}
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			match, rehash, err := test.hasher.Verify("secret-pass", test.hash)
+			assert.NoError(t, err)
+			assert.True(t, match)
+			assert.Equal(t, test.want, rehash)
+		})
+	}
+}
+
+func Test_HasherUnknownFormat(t *testing.T) {
+	hasher := &password.Hasher{Algorithm: password.Argon2id, Argon2: fastArgon2}
+	_, _, err := hasher.Verify("secret", "plaintext")
+	assert.ErrorIs(t, err, password.ErrUnknownHashFormat)
+}
+
+func Test_NewHasher(t *testing.T) {
+	_, err := password.NewHasher(config.Config{PasswordHashAlgorithm: "md5"})
+	assert.EqualError(t, err, `unsupported password hash algorithm "md5"`)
+
+	_, err = password.NewHasher(config.Config{PasswordHashAlgorithm: password.Bcrypt, BcryptCost: 2})
+	assert.EqualError(t, err, "bcrypt cost must be between 4 and 31")
+
+	hasher, err := password.NewHasher(config.Config{PasswordHashAlgorithm: password.Argon2id, Argon2Memory: 65536, Argon2Iterations: 3, Argon2Parallelism: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, password.Argon2Params{Memory: 65536, Iterations: 3, Parallelism: 2}, hasher.Argon2)
+}
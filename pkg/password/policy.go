@@ -0,0 +1,169 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"taskmanagementapi/pkg/config"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minPersonalLength keeps short fragments such as initials from rejecting
+// otherwise good passwords.
+const minPersonalLength = 3
+
+// bcryptMaxBytes is as much of a password as bcrypt reads; it refuses to hash
+// anything longer.
+const bcryptMaxBytes = 72
+
+// Policy decides whether a new password is acceptable. MaxLength counts
+// characters; MaxBytes, when set, also limits the UTF-8 length for hashers
+// that only take so many bytes.
+type Policy struct {
+	MinLength        int
+	MaxLength        int
+	MaxBytes         int
+	RequireUpper     bool
+	RequireLower     bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	DisallowPersonal bool
+	Breached         RangeSource
+}
+
+// NewPolicy builds the policy from config and opens the breached-password
+// corpus when PASSWORD_BREACHED_FILE is set. With bcrypt, passwords are also
+// limited to the 72 bytes it hashes.
+func NewPolicy(cfg config.Config) (*Policy, error) {
+	policy := &Policy{
+		MinLength:        cfg.PasswordMinLength,
+		MaxLength:        cfg.PasswordMaxLength,
+		RequireUpper:     cfg.PasswordRequireUpper,
+		RequireLower:     cfg.PasswordRequireLower,
+		RequireDigit:     cfg.PasswordRequireDigit,
+		RequireSymbol:    cfg.PasswordRequireSymbol,
+		DisallowPersonal: cfg.PasswordDisallowPersonal,
+	}
+	if cfg.PasswordHashAlgorithm == Bcrypt {
+		policy.MaxBytes = bcryptMaxBytes
+	}
+	if cfg.PasswordBreachedFile != "" {
+		corpus, err := LoadCorpus(cfg.PasswordBreachedFile)
+		if err != nil {
+			return nil, err
+		}
+		policy.Breached = corpus
+	}
+	return policy, nil
+}
+
+// Violation lists every rule a password breaks.
+type Violation struct {
+	Problems []string
+}
+
+func (v *Violation) Error() string {
+	return "password " + strings.Join(v.Problems, ", ")
+}
+
+// Check returns a *Violation listing every rule the password breaks, or
+// another error when the password could not be checked, such as a failed
+// read of the breached corpus. personal holds values such as the email and
+// name that must not appear in it.
+func (p *Policy) Check(password string, personal ...string) error {
+	var problems []string
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		problems = append(problems, fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		problems = append(problems, fmt.Sprintf("must be at most %d characters", p.MaxLength))
+	} else if p.MaxBytes > 0 && len(password) > p.MaxBytes {
+		// Non-ASCII characters take several bytes, so a password within
+		// MaxLength can still be too long for the hasher.
+		problems = append(problems, fmt.Sprintf("must be at most %d bytes", p.MaxBytes))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		problems = append(problems, "must contain an upper-case letter")
+	}
+	if p.RequireLower && !lower {
+		problems = append(problems, "must contain a lower-case letter")
+	}
+	if p.RequireDigit && !digit {
+		problems = append(problems, "must contain a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		problems = append(problems, "must contain a symbol")
+	}
+
+	if p.DisallowPersonal && containsPersonal(password, personal) {
+		problems = append(problems, "must not contain your name or email")
+	}
+
+	if p.Breached != nil {
+		breached, err := isBreached(p.Breached, password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			problems = append(problems, "has appeared in a data breach")
+		}
+	}
+
+	if len(problems) > 0 {
+		return &Violation{Problems: problems}
+	}
+	return nil
+}
+
+// containsPersonal checks the whole value and each of its words, so that
+// both "akhil@example.com" and "akhil" are caught.
+func containsPersonal(password string, personal []string) bool {
+	lowered := strings.ToLower(password)
+	for _, value := range personal {
+		value = strings.ToLower(value)
+		parts := strings.FieldsFunc(value, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, part := range append(parts, value) {
+			if utf8.RuneCountInString(part) >= minPersonalLength && strings.Contains(lowered, part) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isBreached looks the password up by the first five hex characters of its
+// SHA-1 hash, the same k-anonymity scheme the Pwned Passwords range API uses,
+// so that a remote source can replace the file without changing callers.
+func isBreached(source RangeSource, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	suffixes, err := source.Range(hash[:prefixLength])
+	if err != nil {
+		return false, err
+	}
+	for _, suffix := range suffixes {
+		if suffix == hash[prefixLength:] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,184 @@
+package password_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"taskmanagementapi/pkg/config"
+	"taskmanagementapi/pkg/password"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const prefixLength = 5
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func Test_PolicyCheck(t *testing.T) {
+	policy := &password.Policy{
+		MinLength:        8,
+		MaxLength:        16,
+		RequireUpper:     true,
+		RequireLower:     true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		DisallowPersonal: true,
+	}
+
+	testData := map[string]struct {
+		password string
+		wantErr  string
+	}{
+		"valid": {
+			password: "Tr0ub4dor&3",
+		},
+		"length counts runes": {
+			password: "Ünïcødé1!",
+		},
+		"too short": {
+			password: "Ab1!",
+			wantErr:  "password must be at least 8 characters",
+		},
+		"too long": {
+			password: "Abcdefgh1!Abcdefgh",
+			wantErr:  "password must be at most 16 characters",
+		},
+		"missing classes": {
+			password: "abcdefgh",
+			wantErr:  "password must contain an upper-case letter, must contain a digit, must contain a symbol",
+		},
+		"contains email local part": {
+			password: "Xx-Akhil-99",
+			wantErr:  "password must not contain your name or email",
+		},
+		"contains surname": {
+			password: "Raj#2024ok",
+			wantErr:  "password must not contain your name or email",
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			err := policy.Check(test.password, "akhil.r@example.com", "Akhil Raj")
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+// failingSource stands in for a breached corpus that cannot be read.
+type failingSource struct{}
+
+func (failingSource) Range(string) ([]string, error) {
+	return nil, errors.New("read error")
+}
+
+func Test_PolicyViolation(t *testing.T) {
+	policy := &password.Policy{MinLength: 8, RequireDigit: true}
+	var violation *password.Violation
+	require.ErrorAs(t, policy.Check("short"), &violation)
+	assert.Equal(t, []string{"must be at least 8 characters", "must contain a digit"}, violation.Problems)
+
+	policy = &password.Policy{MinLength: 8, Breached: failingSource{}}
+	err := policy.Check("correct horse battery")
+	assert.EqualError(t, err, "read error")
+	assert.False(t, errors.As(err, &violation))
+}
+
+func Test_PolicyShortNameParts(t *testing.T) {
+	policy := &password.Policy{MinLength: 8, DisallowPersonal: true}
+	assert.NoError(t, policy.Check("mellow-river-42", "Li Mo"))
+}
+
+func Test_PolicyBcryptByteLimit(t *testing.T) {
+	policy, err := password.NewPolicy(config.Config{PasswordMinLength: 8, PasswordMaxLength: 64, PasswordHashAlgorithm: password.Bcrypt})
+	require.NoError(t, err)
+
+	// 40 characters, but 80 bytes: bcrypt would only hash the first 72.
+	assert.EqualError(t, policy.Check(strings.Repeat("é", 40)), "password must be at most 72 bytes")
+	assert.NoError(t, policy.Check(strings.Repeat("é", 36)))
+
+	policy, err = password.NewPolicy(config.Config{PasswordMinLength: 8, PasswordMaxLength: 64, PasswordHashAlgorithm: password.Argon2id})
+	require.NoError(t, err)
+	assert.NoError(t, policy.Check(strings.Repeat("é", 40)))
+}
+
+func Test_BreachedCorpus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	hashes := []string{sha1Hex("password123") + ":2254650", strings.ToLower(sha1Hex("letmein99"))}
+	sort.Slice(hashes, func(i, j int) bool { return strings.ToUpper(hashes[i]) < strings.ToUpper(hashes[j]) })
+	contents := "# sample\n\n" + strings.Join(hashes, "\n") + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+
+	policy, err := password.NewPolicy(config.Config{PasswordMinLength: 8, PasswordBreachedFile: path})
+	require.NoError(t, err)
+
+	assert.EqualError(t, policy.Check("password123"), "password has appeared in a data breach")
+	assert.EqualError(t, policy.Check("letmein99"), "password has appeared in a data breach")
+	assert.NoError(t, policy.Check("correct horse battery"))
+}
+
+func Test_CorpusRange(t *testing.T) {
+	// Enough hashes that several share a prefix and the search has to probe
+	// the middle of lines.
+	var lines []string
+	for i := 0; i < 2000; i++ {
+		lines = append(lines, fmt.Sprintf("%s:%d", sha1Hex(fmt.Sprint("breached", i)), i))
+	}
+	shared := sha1Hex("breached0")[:prefixLength]
+	lines = append(lines, shared+strings.Repeat("0", 35), shared+strings.Repeat("F", 35))
+	sort.Strings(lines)
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")), 0o600))
+
+	corpus, err := password.LoadCorpus(path)
+	require.NoError(t, err)
+	want := make(map[string][]string)
+	for _, line := range lines {
+		want[line[:prefixLength]] = append(want[line[:prefixLength]], line[prefixLength:40])
+	}
+	for prefix, suffixes := range want {
+		got, err := corpus.Range(strings.ToLower(prefix))
+		require.NoError(t, err)
+		assert.Equal(t, suffixes, got, prefix)
+	}
+	for _, prefix := range []string{"00000", "FFFFF", "7FFFF"} {
+		if _, ok := want[prefix]; !ok {
+			got, err := corpus.Range(prefix)
+			require.NoError(t, err)
+			assert.Empty(t, got, prefix)
+		}
+	}
+}
+
+func Test_LoadCorpusRejectsGarbage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte("not-a-hash\n"), 0o600))
+
+	_, err := password.LoadCorpus(path)
+	assert.EqualError(t, err, path+":1: not a SHA-1 hash")
+}
+
+func Test_CorpusRangeRejectsGarbage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("0", 40)+"\nnot-a-hash\n"), 0o600))
+
+	// Only the first hash is checked when the file is opened.
+	corpus, err := password.LoadCorpus(path)
+	require.NoError(t, err)
+	_, err = corpus.Range("FFFFF")
+	assert.EqualError(t, err, path+`: "not-a-hash" is not a SHA-1 hash`)
+}
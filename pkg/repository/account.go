@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var errAccountNotFound = errors.New("account not found")
+
+type AccountRepository struct {
+	Client                *mongo.Client
+	UserCollection        *mongo.Collection
+	TaskCollection        *mongo.Collection
+	TokenCollection       *mongo.Collection
+	SessionCollection     *mongo.Collection
+	PreferenceCollection  *mongo.Collection
+	AttemptCollection     *mongo.Collection
+	IdempotencyCollection *mongo.Collection
+	RateLimitCollection   *mongo.Collection
+	TombstoneCollection   *mongo.Collection
+}
+
+func NewAccountRepository(db *mongo.Database) interfaces.AccountRepository {
+	return &AccountRepository{
+		Client:                db.Client(),
+		UserCollection:        db.Collection("users"),
+		TaskCollection:        db.Collection("tasks"),
+		TokenCollection:       db.Collection("tokens"),
+		SessionCollection:     db.Collection("sessions"),
+		PreferenceCollection:  db.Collection("preferences"),
+		AttemptCollection:     db.Collection("login_attempts"),
+		IdempotencyCollection: db.Collection("idempotency_keys"),
+		RateLimitCollection:   db.Collection("rate_limits"),
+		TombstoneCollection:   db.Collection("deleted_users"),
+	}
+}
+
+// DeleteAccount removes the user together with everything keyed by their id
+// and leaves a tombstone, all in one transaction so that a failure never
+// leaves orphaned tasks behind. It reports false when the user doesn't exist.
+// Idempotency keys and per-user rate limit buckets are keyed by strings that
+// embed the user id, "<user id>:<key>" and "<scope>:user:<user id>"; buckets
+// in the memory store are left to expire.
+func (ar *AccountRepository) DeleteAccount(ctx context.Context, userID, email string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, errors.New("invalid ObjectID format")
+	}
+	session, err := ar.Client.StartSession()
+	if err != nil {
+		return false, err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (interface{}, error) {
+		result, err := ar.UserCollection.DeleteOne(ctx, bson.M{"_id": objID})
+		if err != nil {
+			return nil, err
+		}
+		if result.DeletedCount == 0 {
+			return nil, errAccountNotFound
+		}
+		tasks, err := ar.TaskCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+		if err != nil {
+			return nil, err
+		}
+		tokens, err := ar.TokenCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := ar.SessionCollection.DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+			return nil, err
+		}
+		if _, err := ar.PreferenceCollection.DeleteOne(ctx, bson.M{"user_id": userID}); err != nil {
+			return nil, err
+		}
+		if _, err := ar.AttemptCollection.DeleteOne(ctx, bson.M{"key": "email:" + strings.ToLower(email)}); err != nil {
+			return nil, err
+		}
+		// userID is a valid ObjectID, so it holds no regex metacharacters.
+		if _, err := ar.IdempotencyCollection.DeleteMany(ctx, bson.M{"_id": primitive.Regex{Pattern: "^" + userID + ":"}}); err != nil {
+			return nil, err
+		}
+		if _, err := ar.RateLimitCollection.DeleteMany(ctx, bson.M{"_id": primitive.Regex{Pattern: "^[^:]*:user:" + userID + "$"}}); err != nil {
+			return nil, err
+		}
+		tombstone := models.UserTombstone{
+			UserID:        userID,
+			DeletedAt:     time.Now().UTC(),
+			TasksDeleted:  tasks.DeletedCount,
+			TokensDeleted: tokens.DeletedCount,
+		}
+		return ar.TombstoneCollection.InsertOne(ctx, tombstone)
+	})
+	if errors.Is(err, errAccountNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestDeleteAccount(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	deleted := func(n int32) bson.D {
+		return bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: n}}
+	}
+
+	mt.Run("cascades and commits", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			deleted(1),
+			deleted(3),
+			deleted(1),
+			deleted(2),
+			deleted(1),
+			deleted(0),
+			deleted(2),
+			deleted(1),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+		)
+		ar := repository.NewAccountRepository(mt.Client.Database("test"))
+
+		found, err := ar.DeleteAccount(context.TODO(), "6705824f80a09eb0313f0e42", "John@Example.com")
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		started := mt.GetAllStartedEvents()
+		if assert.Len(t, started, 10) {
+			assert.Equal(t, "users", started[0].Command.Lookup("delete").StringValue())
+			assert.Equal(t, "tasks", started[1].Command.Lookup("delete").StringValue())
+			assert.Equal(t, "tokens", started[2].Command.Lookup("delete").StringValue())
+			assert.Equal(t, "sessions", started[3].Command.Lookup("delete").StringValue())
+			assert.Equal(t, "preferences", started[4].Command.Lookup("delete").StringValue())
+			assert.Equal(t, "login_attempts", started[5].Command.Lookup("delete").StringValue())
+			assert.Equal(t, "idempotency_keys", started[6].Command.Lookup("delete").StringValue())
+			pattern, _ := started[6].Command.Lookup("deletes").Array().Index(0).Value().Document().Lookup("q", "_id").Regex()
+			assert.Equal(t, "^6705824f80a09eb0313f0e42:", pattern)
+			assert.Equal(t, "rate_limits", started[7].Command.Lookup("delete").StringValue())
+			pattern, _ = started[7].Command.Lookup("deletes").Array().Index(0).Value().Document().Lookup("q", "_id").Regex()
+			assert.Equal(t, "^[^:]*:user:6705824f80a09eb0313f0e42$", pattern)
+			assert.Equal(t, "deleted_users", started[8].Command.Lookup("insert").StringValue())
+			assert.Equal(t, "commitTransaction", started[9].CommandName)
+		}
+	})
+
+	mt.Run("user not found aborts", func(mt *mtest.T) {
+		mt.AddMockResponses(deleted(0), mtest.CreateSuccessResponse())
+		ar := repository.NewAccountRepository(mt.Client.Database("test"))
+
+		found, err := ar.DeleteAccount(context.TODO(), "6705824f80a09eb0313f0e42", "john@example.com")
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+		started := mt.GetAllStartedEvents()
+		assert.Equal(t, "abortTransaction", started[len(started)-1].CommandName)
+	})
+
+	mt.Run("invalid ObjectID format", func(mt *mtest.T) {
+		ar := repository.NewAccountRepository(mt.Client.Database("test"))
+
+		_, err := ar.DeleteAccount(context.TODO(), "invalid_id", "john@example.com")
+
+		assert.EqualError(t, err, "invalid ObjectID format")
+	})
+}
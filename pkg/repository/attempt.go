@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LoginAttemptRepository struct {
+	AttemptCollection *mongo.Collection
+}
+
+func NewLoginAttemptRepository(db *mongo.Database) interfaces.LoginAttemptRepository {
+	return &LoginAttemptRepository{AttemptCollection: db.Collection("login_attempts")}
+}
+
+func (ar *LoginAttemptRepository) GetAttempt(ctx context.Context, key string) (models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	err := ar.AttemptCollection.FindOne(ctx, bson.M{"key": key}).Decode(&attempt)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.LoginAttempt{Key: key}, nil
+		}
+		return models.LoginAttempt{}, err
+	}
+	return attempt, nil
+}
+
+// RecordFailure atomically increments the failure counter for key and
+// returns the updated record. A counter whose last failure is older than
+// window starts again from one.
+func (ar *LoginAttemptRepository) RecordFailure(ctx context.Context, key string, window time.Duration) (models.LoginAttempt, error) {
+	now := time.Now().UTC()
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"key": key,
+			"failures": bson.M{"$cond": bson.A{
+				bson.M{"$lt": bson.A{bson.M{"$ifNull": bson.A{"$updated_at", time.Time{}}}, now.Add(-window)}},
+				1,
+				bson.M{"$add": bson.A{"$failures", 1}},
+			}},
+			"updated_at": now,
+		}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var attempt models.LoginAttempt
+	err := ar.AttemptCollection.FindOneAndUpdate(ctx, bson.M{"key": key}, update, opts).Decode(&attempt)
+	if err != nil {
+		return models.LoginAttempt{}, err
+	}
+	return attempt, nil
+}
+
+func (ar *LoginAttemptRepository) LockUntil(ctx context.Context, key string, until time.Time) error {
+	_, err := ar.AttemptCollection.UpdateOne(ctx, bson.M{"key": key}, bson.M{"$set": bson.M{"locked_until": until}})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ar *LoginAttemptRepository) ResetAttempts(ctx context.Context, key string) error {
+	_, err := ar.AttemptCollection.DeleteOne(ctx, bson.M{"key": key})
+	if err != nil {
+		return err
+	}
+	return nil
+}
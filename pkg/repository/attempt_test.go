@@ -0,0 +1,59 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestGetAttempt(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("no attempts recorded", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.login_attempts", mtest.FirstBatch))
+		ar := repository.NewLoginAttemptRepository(mt.Client.Database("test"))
+
+		attempt, err := ar.GetAttempt(context.TODO(), "email:a@b.c")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "email:a@b.c", attempt.Key)
+		assert.Zero(t, attempt.Failures)
+		assert.True(t, attempt.LockedUntil.IsZero())
+	})
+}
+
+func TestRecordFailure(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("returns updated counter", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: bson.D{
+				{Key: "key", Value: "ip:10.0.0.1"},
+				{Key: "failures", Value: 3},
+				{Key: "updated_at", Value: time.Now()},
+			}},
+		})
+		ar := repository.NewLoginAttemptRepository(mt.Client.Database("test"))
+
+		attempt, err := ar.RecordFailure(context.TODO(), "ip:10.0.0.1", 15*time.Minute)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempt.Failures)
+	})
+
+	mt.Run("error during FindOneAndUpdate", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+			Code:    1,
+			Message: "update error",
+		}))
+		ar := repository.NewLoginAttemptRepository(mt.Client.Database("test"))
+
+		_, err := ar.RecordFailure(context.TODO(), "ip:10.0.0.1", 15*time.Minute)
+
+		assert.EqualError(t, err, "update error")
+	})
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditRepository struct {
+	AuditCollection *mongo.Collection
+}
+
+func NewAuditRepository(db *mongo.Database) interfaces.AuditRepository {
+	return &AuditRepository{AuditCollection: db.Collection("audit_logs")}
+}
+
+func (ar *AuditRepository) InsertAuditLog(ctx context.Context, log models.AuditLog) (string, error) {
+	newLog := bson.M{
+		"actor_id":   log.ActorID,
+		"action":     log.Action,
+		"target_id":  log.TargetID,
+		"details":    log.Details,
+		"outcome":    log.Outcome,
+		"created_at": log.CreatedAt,
+	}
+	result, err := ar.AuditCollection.InsertOne(ctx, newLog)
+	if err != nil {
+		return "", err
+	}
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", errors.New("invalid inserted id")
+	}
+	return id.Hex(), nil
+}
+
+// SetAuditOutcome records how the action of a pending entry ended. Only a
+// pending entry is updated, so an outcome is never overwritten.
+func (ar *AuditRepository) SetAuditOutcome(ctx context.Context, id, outcome string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return interfaces.ErrNotFound
+	}
+	filter := bson.M{"_id": objID, "outcome": models.AuditPending}
+	result, err := ar.AuditCollection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"outcome": outcome}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return interfaces.ErrNotFound
+	}
+	return nil
+}
+
+func (ar *AuditRepository) GetAuditLogs(ctx context.Context, page, limit int) ([]models.AuditLog, error) {
+	opts := options.Find().
+		SetSort(bson.M{"created_at": -1}).
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit))
+	cursor, err := ar.AuditCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var logs []models.AuditLog
+	for cursor.Next(ctx) {
+		var log models.AuditLog
+		if err := cursor.Decode(&log); err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+	return logs, cursor.Err()
+}
@@ -0,0 +1,95 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestInsertAuditLog(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("successfully insert audit log", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		ar := repository.NewAuditRepository(mt.Client.Database("test"))
+
+		id, err := ar.InsertAuditLog(context.TODO(), models.AuditLog{
+			ActorID:   "admin",
+			Action:    models.AuditDisableUser,
+			TargetID:  "user1",
+			Outcome:   models.AuditPending,
+			CreatedAt: time.Now(),
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, id)
+		document := mt.GetStartedEvent().Command.Lookup("documents").Array().Index(0).Value().Document()
+		assert.Equal(t, models.AuditPending, document.Lookup("outcome").StringValue())
+	})
+
+	mt.Run("error during InsertOne operation", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+			Code:    1,
+			Message: "insert error",
+		}))
+		ar := repository.NewAuditRepository(mt.Client.Database("test"))
+
+		_, err := ar.InsertAuditLog(context.TODO(), models.AuditLog{ActorID: "admin"})
+
+		assert.EqualError(t, err, "insert error")
+	})
+}
+
+func TestSetAuditOutcome(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("updates pending entry", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+		ar := repository.NewAuditRepository(mt.Client.Database("test"))
+
+		err := ar.SetAuditOutcome(context.TODO(), primitive.NewObjectID().Hex(), models.AuditSucceeded)
+
+		assert.NoError(t, err)
+		filter := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("q").Document()
+		assert.Equal(t, models.AuditPending, filter.Lookup("outcome").StringValue())
+	})
+
+	mt.Run("entry no longer pending", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}})
+		ar := repository.NewAuditRepository(mt.Client.Database("test"))
+
+		err := ar.SetAuditOutcome(context.TODO(), primitive.NewObjectID().Hex(), models.AuditFailed)
+
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+}
+
+func TestGetAuditLogs(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("returns logs", func(mt *mtest.T) {
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(1, "test.audit_logs", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: id},
+				{Key: "actor_id", Value: "admin"},
+				{Key: "action", Value: models.AuditViewTasks},
+				{Key: "target_id", Value: "user1"},
+			}),
+			mtest.CreateCursorResponse(0, "test.audit_logs", mtest.NextBatch),
+		)
+		ar := repository.NewAuditRepository(mt.Client.Database("test"))
+
+		logs, err := ar.GetAuditLogs(context.TODO(), 1, 20)
+
+		assert.NoError(t, err)
+		assert.Len(t, logs, 1)
+		assert.Equal(t, id.Hex(), logs[0].ID)
+		assert.Equal(t, models.AuditViewTasks, logs[0].Action)
+	})
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type HealthRepository struct {
+	Client *mongo.Client
+}
+
+func NewHealthRepository(db *mongo.Database) interfaces.HealthRepository {
+	return &HealthRepository{Client: db.Client()}
+}
+
+// Ping checks that the primary, which every write goes to, is reachable.
+func (hr *HealthRepository) Ping(ctx context.Context) error {
+	return hr.Client.Ping(ctx, readpref.Primary())
+}
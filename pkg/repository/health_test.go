@@ -0,0 +1,28 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestPing(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("primary reachable", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		hr := repository.NewHealthRepository(mt.Client.Database("test"))
+
+		assert.NoError(t, hr.Ping(context.Background()))
+	})
+
+	mt.Run("ping fails", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Message: "unauthorized"}))
+		hr := repository.NewHealthRepository(mt.Client.Database("test"))
+
+		assert.Error(t, hr.Ping(context.Background()))
+	})
+}
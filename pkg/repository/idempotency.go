@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IdempotencyRepository struct {
+	IdempotencyCollection *mongo.Collection
+}
+
+func NewIdempotencyRepository(db *mongo.Database) interfaces.IdempotencyRepository {
+	return &IdempotencyRepository{IdempotencyCollection: db.Collection("idempotency_keys")}
+}
+
+// Claim atomically reserves id for a new request with the given fingerprint.
+// It reports true when the caller now owns the key, either because it was
+// unused or because the previous record had expired. Otherwise it returns the
+// existing record. Using id as _id makes concurrent first claims safe: the
+// loser gets a duplicate key error and is told the key is in use.
+//
+// The claim only holds for lease, so that a key whose request never completed
+// or released it, for example because the process died, can be retried.
+func (ir *IdempotencyRepository) Claim(ctx context.Context, id, fingerprint string, lease time.Duration) (models.IdempotencyRecord, bool, error) {
+	now := time.Now().UTC()
+	expired := bson.M{"$lt": bson.A{bson.M{"$ifNull": bson.A{"$expires_at", time.Time{}}}, now}}
+	pick := func(fresh interface{}, field string) bson.M {
+		return bson.M{"$cond": bson.A{expired, fresh, "$" + field}}
+	}
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"fingerprint":  pick(fingerprint, "fingerprint"),
+			"status":       pick(0, "status"),
+			"content_type": pick("$$REMOVE", "content_type"),
+			"body":         pick("$$REMOVE", "body"),
+			"expires_at":   pick(now.Add(lease), "expires_at"),
+		}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+	var previous models.IdempotencyRecord
+	err := ir.IdempotencyCollection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return models.IdempotencyRecord{}, true, nil
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return models.IdempotencyRecord{Fingerprint: fingerprint}, false, nil
+	}
+	if err != nil {
+		return models.IdempotencyRecord{}, false, err
+	}
+	if previous.ExpiresAt.Before(now) {
+		return models.IdempotencyRecord{}, true, nil
+	}
+	return previous, false, nil
+}
+
+// Complete stores the response to replay and keeps it for ttl.
+func (ir *IdempotencyRepository) Complete(ctx context.Context, id string, record models.IdempotencyRecord, ttl time.Duration) error {
+	update := bson.M{"$set": bson.M{
+		"status":       record.Status,
+		"content_type": record.ContentType,
+		"body":         record.Body,
+		"expires_at":   time.Now().UTC().Add(ttl),
+	}}
+	_, err := ir.IdempotencyCollection.UpdateOne(ctx, bson.M{"_id": id, "status": 0}, update)
+	return err
+}
+
+// Release frees a key whose request failed, so that a retry runs again.
+func (ir *IdempotencyRepository) Release(ctx context.Context, id string) error {
+	_, err := ir.IdempotencyCollection.DeleteOne(ctx, bson.M{"_id": id, "status": 0})
+	return err
+}
+
+func (ir *IdempotencyRepository) DeleteExpired(ctx context.Context) error {
+	_, err := ir.IdempotencyCollection.DeleteMany(ctx, bson.M{"expires_at": bson.M{"$lt": time.Now().UTC()}})
+	return err
+}
@@ -0,0 +1,98 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestClaim(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("unused key is claimed", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: nil}})
+		ir := repository.NewIdempotencyRepository(mt.Client.Database("test"))
+
+		_, claimed, err := ir.Claim(context.TODO(), "user1:key", "fp", time.Minute)
+
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+	})
+
+	mt.Run("live key returns the stored response", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: bson.D{
+			{Key: "_id", Value: "user1:key"},
+			{Key: "fingerprint", Value: "fp"},
+			{Key: "status", Value: 201},
+			{Key: "body", Value: []byte(`{"message":"Task created"}`)},
+			{Key: "expires_at", Value: time.Now().Add(time.Hour)},
+		}}})
+		ir := repository.NewIdempotencyRepository(mt.Client.Database("test"))
+
+		record, claimed, err := ir.Claim(context.TODO(), "user1:key", "fp", time.Minute)
+
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+		assert.Equal(t, 201, record.Status)
+		assert.Equal(t, []byte(`{"message":"Task created"}`), record.Body)
+	})
+
+	mt.Run("expired key is claimed again", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: bson.D{
+			{Key: "_id", Value: "user1:key"},
+			{Key: "fingerprint", Value: "old"},
+			{Key: "status", Value: 201},
+			{Key: "expires_at", Value: time.Now().Add(-time.Hour)},
+		}}})
+		ir := repository.NewIdempotencyRepository(mt.Client.Database("test"))
+
+		_, claimed, err := ir.Claim(context.TODO(), "user1:key", "fp", time.Minute)
+
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+	})
+
+	mt.Run("concurrent first claim", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}))
+		ir := repository.NewIdempotencyRepository(mt.Client.Database("test"))
+
+		record, claimed, err := ir.Claim(context.TODO(), "user1:key", "fp", time.Minute)
+
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+		assert.Equal(t, "fp", record.Fingerprint)
+		assert.Zero(t, record.Status)
+	})
+}
+
+func TestCompleteIdempotencyKey(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("stores the response", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+		ir := repository.NewIdempotencyRepository(mt.Client.Database("test"))
+
+		err := ir.Complete(context.TODO(), "user1:key", models.IdempotencyRecord{Status: 201, ContentType: "application/json", Body: []byte("{}")}, 24*time.Hour)
+
+		assert.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, int32(0), update.Lookup("q", "status").Int32())
+		assert.Equal(t, int32(201), update.Lookup("u", "$set", "status").Int32())
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), update.Lookup("u", "$set", "expires_at").Time(), time.Minute)
+	})
+}
+
+func TestReleaseIdempotencyKey(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("deletes a pending key", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+		ir := repository.NewIdempotencyRepository(mt.Client.Database("test"))
+
+		assert.NoError(t, ir.Release(context.TODO(), "user1:key"))
+	})
+}
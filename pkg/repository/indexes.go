@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexes lists, per collection, the indexes behind the lookups the
+// repositories make on every request, and TTL indexes that let MongoDB delete
+// expired documents. A TTL of zero expires a document at the time in the
+// field.
+var indexes = map[string][]mongo.IndexModel{
+	"sessions": {
+		{Keys: bson.D{{Key: "jti", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	"tokens": {
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	},
+	"login_attempts": {
+		{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	"tasks": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	},
+	// Preferences are upserted by user_id; without a unique index two
+	// concurrent first saves would each insert a document.
+	"preferences": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	// The OIDC usecase rejects states older than ten minutes; this only
+	// removes logins that were abandoned.
+	"oidc_states": {
+		{Keys: bson.D{{Key: "state", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "created_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(3600)},
+	},
+	// Both are keyed by _id, which is always indexed.
+	"idempotency_keys": {
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	"rate_limits": {
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+}
+
+// CreateIndexes creates any of the indexes that are missing. Creating an
+// index that already exists is a no-op, so it is safe to run on every start.
+func CreateIndexes(ctx context.Context, db *mongo.Database) error {
+	for collection, models := range indexes {
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("create indexes on %s: %w", collection, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateIndexes(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("creates indexes on every collection", func(mt *mtest.T) {
+		for i := 0; i < 8; i++ {
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+		}
+
+		assert.NoError(t, repository.CreateIndexes(context.Background(), mt.Client.Database("test")))
+
+		created := map[string]bson.Raw{}
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			created[event.Command.Lookup("createIndexes").StringValue()] = event.Command.Lookup("indexes").Array()
+		}
+		assert.Len(t, created, 8)
+		session := created["sessions"].Index(0).Value().Document()
+		assert.Equal(t, int32(1), session.Lookup("key", "jti").Int32())
+		assert.True(t, session.Lookup("unique").Boolean())
+		preferences := created["preferences"].Index(0).Value().Document()
+		assert.Equal(t, int32(1), preferences.Lookup("key", "user_id").Int32())
+		assert.True(t, preferences.Lookup("unique").Boolean())
+		ttl := created["idempotency_keys"].Index(0).Value().Document()
+		assert.Equal(t, int32(0), ttl.Lookup("expireAfterSeconds").Int32())
+	})
+
+	mt.Run("reports the failing collection", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 86, Message: "index key specs conflict"}))
+
+		err := repository.CreateIndexes(context.Background(), mt.Client.Database("test"))
+
+		assert.ErrorContains(t, err, "create indexes on")
+	})
+}
@@ -0,0 +1,7 @@
+package interfaces
+
+import "context"
+
+type AccountRepository interface {
+	DeleteAccount(context.Context, string, string) (bool, error)
+}
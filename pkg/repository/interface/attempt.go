@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+type LoginAttemptRepository interface {
+	GetAttempt(context.Context, string) (models.LoginAttempt, error)
+	RecordFailure(context.Context, string, time.Duration) (models.LoginAttempt, error)
+	LockUntil(context.Context, string, time.Time) error
+	ResetAttempts(context.Context, string) error
+}
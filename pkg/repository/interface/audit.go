@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type AuditRepository interface {
+	InsertAuditLog(context.Context, models.AuditLog) (string, error)
+	SetAuditOutcome(context.Context, string, string) error
+	GetAuditLogs(context.Context, int, int) ([]models.AuditLog, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import "errors"
+
+var (
+	// ErrNotFound is returned by lookups of a single document that matched
+	// nothing.
+	ErrNotFound = errors.New("document not found")
+	// ErrNotExecuted marks the writes an ordered bulk write skipped after an
+	// earlier one failed.
+	ErrNotExecuted = errors.New("not executed")
+	// ErrConflict is returned by conditional writes when the document no
+	// longer has the values the write was computed from.
+	ErrConflict = errors.New("document was modified")
+)
@@ -0,0 +1,7 @@
+package interfaces
+
+import "context"
+
+type HealthRepository interface {
+	Ping(context.Context) error
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+type IdempotencyRepository interface {
+	Claim(context.Context, string, string, time.Duration) (models.IdempotencyRecord, bool, error)
+	Complete(context.Context, string, models.IdempotencyRecord, time.Duration) error
+	Release(context.Context, string) error
+	DeleteExpired(context.Context) error
+}
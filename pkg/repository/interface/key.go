@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+type SigningKeyRepository interface {
+	GetKeys(context.Context) ([]models.SigningKey, error)
+	InsertKey(context.Context, models.SigningKey) error
+	RetireKeys(context.Context, time.Time, time.Time) error
+	DeleteRetiredBefore(context.Context, time.Time) error
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type OIDCRepository interface {
+	InsertState(context.Context, models.OIDCState) error
+	ConsumeState(context.Context, string) (models.OIDCState, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type PreferenceRepository interface {
+	GetPreferences(context.Context, string) (models.Preferences, error)
+	UpsertPreferences(context.Context, string, models.Preferences) error
+}
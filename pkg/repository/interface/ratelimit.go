@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type RateLimitRepository interface {
+	Take(context.Context, string, models.RateLimit) (models.RateLimitResult, error)
+	DeleteExpired(context.Context) error
+}
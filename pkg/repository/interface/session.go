@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+type SessionRepository interface {
+	InsertSession(context.Context, models.NewSession) error
+	FindSessionByJTI(context.Context, string) (models.Session, error)
+	GetSessions(context.Context, string) ([]models.Session, error)
+	DeleteSession(context.Context, string, string) (bool, error)
+	DeleteSessionByJTI(context.Context, string) error
+	DeleteSessionsByUser(context.Context, string) error
+	TouchSessions(context.Context, map[string]time.Time) error
+}
@@ -1,13 +1,19 @@
 package interfaces
 
-import "taskmanagementapi/pkg/utils/models"
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
 
 type TaskRepository interface {
-	CheckUserIDExist(string) (bool, error)
-	InsertTask(models.CreateTask, string) error
-	GetTasks(string) ([]models.TaskDetails, error)
-	CheckTaskIDExist(string) (bool, error)
-	GetTask(string, string) (models.TaskDetails, error)
-	Update(string, string, models.CreateTask) error
-	DeleteTask(string, string) error
+	CheckUserIDExist(context.Context, string) (bool, error)
+	InsertTask(context.Context, models.CreateTask, string) (string, error)
+	GetTasks(context.Context, string) ([]models.TaskDetails, error)
+	ListTasks(context.Context, string, models.TaskQuery) ([]models.TaskDetails, error)
+	GetTask(context.Context, string, string) (models.TaskDetails, error)
+	Update(context.Context, string, string, models.CreateTask) error
+	PatchTask(context.Context, string, string, models.TaskChanges) error
+	FindTaskIDs(context.Context, string, []string) ([]string, error)
+	BulkWriteTasks(context.Context, string, []models.TaskWrite, bool) ([]models.TaskWriteResult, error)
+	DeleteTask(context.Context, string, string) error
 }
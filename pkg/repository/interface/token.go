@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type TokenRepository interface {
+	InsertToken(context.Context, models.NewToken) (string, error)
+	GetTokens(context.Context, string) ([]models.TokenDetails, error)
+	DeleteToken(context.Context, string, string) (bool, error)
+	FindTokenByHash(context.Context, string) (models.TokenAuth, error)
+	DeleteTokensByUser(context.Context, string) error
+}
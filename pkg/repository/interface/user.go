@@ -1,10 +1,26 @@
 package interfaces
 
-import "taskmanagementapi/pkg/utils/models"
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
 
 type UserRepository interface {
-	CheckUserExistsByEmail(string) (bool, error)
-	UserSignUp(models.UserSignup) error
-	FindUserDetailsByEmail(string) (models.UserDetails, error)
-	GenerateJwtToken(user models.UserDetails) (string, error)
+	CheckUserExistsByEmail(context.Context, string) (bool, error)
+	UserSignUp(context.Context, models.UserSignup) error
+	FindUserDetailsByEmail(context.Context, string) (models.UserDetails, error)
+	GenerateJwtToken(models.UserDetails, string) (string, time.Time, error)
+	FindUserByID(context.Context, string) (models.UserDetails, error)
+	ListUsers(context.Context, models.UserFilter) ([]models.UserSummary, error)
+	SetUserDisabled(context.Context, string, bool) (bool, error)
+	UpdatePassword(context.Context, string, string) (bool, error)
+	RehashPassword(context.Context, string, string, string) (bool, error)
+	UpdateRole(context.Context, string, string) (bool, error)
+	FindUserByIdentity(context.Context, string, string) (models.UserDetails, error)
+	LinkIdentity(context.Context, string, string, string) error
+	CreateOIDCUser(context.Context, models.OIDCUser) (string, error)
+	UpdateName(context.Context, string, string) (bool, error)
+	SetPendingEmail(context.Context, string, models.EmailVerification) (bool, error)
+	ConfirmEmail(context.Context, string, string) (bool, error)
 }
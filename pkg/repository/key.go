@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type SigningKeyRepository struct {
+	KeyCollection *mongo.Collection
+}
+
+func NewSigningKeyRepository(db *mongo.Database) interfaces.SigningKeyRepository {
+	return &SigningKeyRepository{KeyCollection: db.Collection("signing_keys")}
+}
+
+func (kr *SigningKeyRepository) GetKeys(ctx context.Context) ([]models.SigningKey, error) {
+	cursor, err := kr.KeyCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []models.SigningKey
+	for cursor.Next(ctx) {
+		var key models.SigningKey
+		if err := cursor.Decode(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, cursor.Err()
+}
+
+func (kr *SigningKeyRepository) InsertKey(ctx context.Context, key models.SigningKey) error {
+	newKey := bson.M{
+		"kid":         key.KID,
+		"alg":         key.Algorithm,
+		"private_key": key.PrivateKey,
+		"created_at":  key.CreatedAt,
+	}
+	_, err := kr.KeyCollection.InsertOne(ctx, newKey)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RetireKeys marks every active key created before createdBefore as retired.
+// Instances rotating at the same time each retire only keys older than their
+// own, so the newest key stays active on all of them. Retired keys no longer
+// sign tokens but still verify them until they are deleted.
+func (kr *SigningKeyRepository) RetireKeys(ctx context.Context, createdBefore, at time.Time) error {
+	filter := bson.M{
+		"created_at": bson.M{"$lt": createdBefore},
+		"retired_at": bson.M{"$exists": false},
+	}
+	_, err := kr.KeyCollection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"retired_at": at}})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (kr *SigningKeyRepository) DeleteRetiredBefore(ctx context.Context, before time.Time) error {
+	_, err := kr.KeyCollection.DeleteMany(ctx, bson.M{"retired_at": bson.M{"$lt": before}})
+	if err != nil {
+		return err
+	}
+	return nil
+}
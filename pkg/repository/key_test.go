@@ -0,0 +1,79 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestGetKeys(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("returns active and retired keys", func(mt *mtest.T) {
+		retired := time.Now().UTC().Truncate(time.Millisecond)
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(1, "test.signing_keys", mtest.FirstBatch,
+				bson.D{{Key: "kid", Value: "a"}, {Key: "alg", Value: "RS256"}, {Key: "private_key", Value: "pem"}},
+				bson.D{{Key: "kid", Value: "b"}, {Key: "alg", Value: "RS256"}, {Key: "private_key", Value: "pem"}, {Key: "retired_at", Value: retired}},
+			),
+			mtest.CreateCursorResponse(0, "test.signing_keys", mtest.NextBatch),
+		)
+		kr := repository.NewSigningKeyRepository(mt.Client.Database("test"))
+
+		keys, err := kr.GetKeys(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Len(t, keys, 2)
+		assert.Nil(t, keys[0].RetiredAt)
+		if assert.NotNil(t, keys[1].RetiredAt) {
+			assert.True(t, retired.Equal(*keys[1].RetiredAt))
+		}
+	})
+}
+
+func TestInsertKey(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("successfully insert key", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		kr := repository.NewSigningKeyRepository(mt.Client.Database("test"))
+
+		err := kr.InsertKey(context.TODO(), models.SigningKey{KID: "a", Algorithm: "RS256", PrivateKey: "pem", CreatedAt: time.Now()})
+
+		assert.NoError(t, err)
+	})
+
+	mt.Run("error during InsertOne operation", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "insert error"}))
+		kr := repository.NewSigningKeyRepository(mt.Client.Database("test"))
+
+		err := kr.InsertKey(context.TODO(), models.SigningKey{KID: "a"})
+
+		assert.EqualError(t, err, "insert error")
+	})
+}
+
+func TestRetireKeys(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("successfully retire keys", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+		kr := repository.NewSigningKeyRepository(mt.Client.Database("test"))
+
+		err := kr.RetireKeys(context.TODO(), time.Now(), time.Now())
+
+		assert.NoError(t, err)
+	})
+
+	mt.Run("error during UpdateMany operation", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "update error"}))
+		kr := repository.NewSigningKeyRepository(mt.Client.Database("test"))
+
+		err := kr.RetireKeys(context.TODO(), time.Now(), time.Now())
+
+		assert.EqualError(t, err, "update error")
+	})
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\repository\interface\key.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSigningKeyRepository is a mock of SigningKeyRepository interface.
+type MockSigningKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeyRepositoryMockRecorder
+}
+
+// MockSigningKeyRepositoryMockRecorder is the mock recorder for MockSigningKeyRepository.
+type MockSigningKeyRepositoryMockRecorder struct {
+	mock *MockSigningKeyRepository
+}
+
+// NewMockSigningKeyRepository creates a new mock instance.
+func NewMockSigningKeyRepository(ctrl *gomock.Controller) *MockSigningKeyRepository {
+	mock := &MockSigningKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockSigningKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeyRepository) EXPECT() *MockSigningKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteRetiredBefore mocks base method.
+func (m *MockSigningKeyRepository) DeleteRetiredBefore(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRetiredBefore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRetiredBefore indicates an expected call of DeleteRetiredBefore.
+func (mr *MockSigningKeyRepositoryMockRecorder) DeleteRetiredBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRetiredBefore", reflect.TypeOf((*MockSigningKeyRepository)(nil).DeleteRetiredBefore), arg0, arg1)
+}
+
+// GetKeys mocks base method.
+func (m *MockSigningKeyRepository) GetKeys(arg0 context.Context) ([]models.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeys", arg0)
+	ret0, _ := ret[0].([]models.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeys indicates an expected call of GetKeys.
+func (mr *MockSigningKeyRepositoryMockRecorder) GetKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeys", reflect.TypeOf((*MockSigningKeyRepository)(nil).GetKeys), arg0)
+}
+
+// InsertKey mocks base method.
+func (m *MockSigningKeyRepository) InsertKey(arg0 context.Context, arg1 models.SigningKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertKey indicates an expected call of InsertKey.
+func (mr *MockSigningKeyRepositoryMockRecorder) InsertKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertKey", reflect.TypeOf((*MockSigningKeyRepository)(nil).InsertKey), arg0, arg1)
+}
+
+// RetireKeys mocks base method.
+func (m *MockSigningKeyRepository) RetireKeys(arg0 context.Context, arg1, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetireKeys", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetireKeys indicates an expected call of RetireKeys.
+func (mr *MockSigningKeyRepositoryMockRecorder) RetireKeys(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetireKeys", reflect.TypeOf((*MockSigningKeyRepository)(nil).RetireKeys), arg0, arg1, arg2)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\repository\interface\preference.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPreferenceRepository is a mock of PreferenceRepository interface.
+type MockPreferenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferenceRepositoryMockRecorder
+}
+
+// MockPreferenceRepositoryMockRecorder is the mock recorder for MockPreferenceRepository.
+type MockPreferenceRepositoryMockRecorder struct {
+	mock *MockPreferenceRepository
+}
+
+// NewMockPreferenceRepository creates a new mock instance.
+func NewMockPreferenceRepository(ctrl *gomock.Controller) *MockPreferenceRepository {
+	mock := &MockPreferenceRepository{ctrl: ctrl}
+	mock.recorder = &MockPreferenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferenceRepository) EXPECT() *MockPreferenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockPreferenceRepository) GetPreferences(arg0 context.Context, arg1 string) (models.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", arg0, arg1)
+	ret0, _ := ret[0].(models.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockPreferenceRepositoryMockRecorder) GetPreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockPreferenceRepository)(nil).GetPreferences), arg0, arg1)
+}
+
+// UpsertPreferences mocks base method.
+func (m *MockPreferenceRepository) UpsertPreferences(arg0 context.Context, arg1 string, arg2 models.Preferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPreferences", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertPreferences indicates an expected call of UpsertPreferences.
+func (mr *MockPreferenceRepositoryMockRecorder) UpsertPreferences(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPreferences", reflect.TypeOf((*MockPreferenceRepository)(nil).UpsertPreferences), arg0, arg1, arg2)
+}
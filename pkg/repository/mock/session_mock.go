@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\repository\interface\session.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSessionRepository is a mock of SessionRepository interface.
+type MockSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepositoryMockRecorder
+}
+
+// MockSessionRepositoryMockRecorder is the mock recorder for MockSessionRepository.
+type MockSessionRepositoryMockRecorder struct {
+	mock *MockSessionRepository
+}
+
+// NewMockSessionRepository creates a new mock instance.
+func NewMockSessionRepository(ctrl *gomock.Controller) *MockSessionRepository {
+	mock := &MockSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteSession mocks base method.
+func (m *MockSessionRepository) DeleteSession(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockSessionRepositoryMockRecorder) DeleteSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockSessionRepository)(nil).DeleteSession), arg0, arg1, arg2)
+}
+
+// DeleteSessionByJTI mocks base method.
+func (m *MockSessionRepository) DeleteSessionByJTI(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionByJTI", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionByJTI indicates an expected call of DeleteSessionByJTI.
+func (mr *MockSessionRepositoryMockRecorder) DeleteSessionByJTI(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionByJTI", reflect.TypeOf((*MockSessionRepository)(nil).DeleteSessionByJTI), arg0, arg1)
+}
+
+// DeleteSessionsByUser mocks base method.
+func (m *MockSessionRepository) DeleteSessionsByUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionsByUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionsByUser indicates an expected call of DeleteSessionsByUser.
+func (mr *MockSessionRepositoryMockRecorder) DeleteSessionsByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionsByUser", reflect.TypeOf((*MockSessionRepository)(nil).DeleteSessionsByUser), arg0, arg1)
+}
+
+// FindSessionByJTI mocks base method.
+func (m *MockSessionRepository) FindSessionByJTI(arg0 context.Context, arg1 string) (models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSessionByJTI", arg0, arg1)
+	ret0, _ := ret[0].(models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSessionByJTI indicates an expected call of FindSessionByJTI.
+func (mr *MockSessionRepositoryMockRecorder) FindSessionByJTI(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSessionByJTI", reflect.TypeOf((*MockSessionRepository)(nil).FindSessionByJTI), arg0, arg1)
+}
+
+// GetSessions mocks base method.
+func (m *MockSessionRepository) GetSessions(arg0 context.Context, arg1 string) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockSessionRepositoryMockRecorder) GetSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockSessionRepository)(nil).GetSessions), arg0, arg1)
+}
+
+// InsertSession mocks base method.
+func (m *MockSessionRepository) InsertSession(arg0 context.Context, arg1 models.NewSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertSession indicates an expected call of InsertSession.
+func (mr *MockSessionRepositoryMockRecorder) InsertSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSession", reflect.TypeOf((*MockSessionRepository)(nil).InsertSession), arg0, arg1)
+}
+
+// TouchSessions mocks base method.
+func (m *MockSessionRepository) TouchSessions(arg0 context.Context, arg1 map[string]time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSessions indicates an expected call of TouchSessions.
+func (mr *MockSessionRepositoryMockRecorder) TouchSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSessions", reflect.TypeOf((*MockSessionRepository)(nil).TouchSessions), arg0, arg1)
+}
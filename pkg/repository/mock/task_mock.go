@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 	models "taskmanagementapi/pkg/utils/models"
 
@@ -34,104 +35,149 @@ func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CheckTaskIDExist mocks base method.
-func (m *MockTaskRepository) CheckTaskIDExist(arg0 string) (bool, error) {
+// BulkWriteTasks mocks base method.
+func (m *MockTaskRepository) BulkWriteTasks(arg0 context.Context, arg1 string, arg2 []models.TaskWrite, arg3 bool) ([]models.TaskWriteResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckTaskIDExist", arg0)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "BulkWriteTasks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.TaskWriteResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CheckTaskIDExist indicates an expected call of CheckTaskIDExist.
-func (mr *MockTaskRepositoryMockRecorder) CheckTaskIDExist(arg0 interface{}) *gomock.Call {
+// BulkWriteTasks indicates an expected call of BulkWriteTasks.
+func (mr *MockTaskRepositoryMockRecorder) BulkWriteTasks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTaskIDExist", reflect.TypeOf((*MockTaskRepository)(nil).CheckTaskIDExist), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkWriteTasks", reflect.TypeOf((*MockTaskRepository)(nil).BulkWriteTasks), arg0, arg1, arg2, arg3)
 }
 
 // CheckUserIDExist mocks base method.
-func (m *MockTaskRepository) CheckUserIDExist(arg0 string) (bool, error) {
+func (m *MockTaskRepository) CheckUserIDExist(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckUserIDExist", arg0)
+	ret := m.ctrl.Call(m, "CheckUserIDExist", arg0, arg1)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CheckUserIDExist indicates an expected call of CheckUserIDExist.
-func (mr *MockTaskRepositoryMockRecorder) CheckUserIDExist(arg0 interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) CheckUserIDExist(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserIDExist", reflect.TypeOf((*MockTaskRepository)(nil).CheckUserIDExist), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserIDExist", reflect.TypeOf((*MockTaskRepository)(nil).CheckUserIDExist), arg0, arg1)
 }
 
 // DeleteTask mocks base method.
-func (m *MockTaskRepository) DeleteTask(arg0, arg1 string) error {
+func (m *MockTaskRepository) DeleteTask(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskRepositoryMockRecorder) DeleteTask(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) DeleteTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTask), arg0, arg1, arg2)
+}
+
+// FindTaskIDs mocks base method.
+func (m *MockTaskRepository) FindTaskIDs(arg0 context.Context, arg1 string, arg2 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTaskIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTaskIDs indicates an expected call of FindTaskIDs.
+func (mr *MockTaskRepositoryMockRecorder) FindTaskIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTaskIDs", reflect.TypeOf((*MockTaskRepository)(nil).FindTaskIDs), arg0, arg1, arg2)
 }
 
 // GetTask mocks base method.
-func (m *MockTaskRepository) GetTask(arg0, arg1 string) (models.TaskDetails, error) {
+func (m *MockTaskRepository) GetTask(arg0 context.Context, arg1, arg2 string) (models.TaskDetails, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetTask", arg0, arg1, arg2)
 	ret0, _ := ret[0].(models.TaskDetails)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTask indicates an expected call of GetTask.
-func (mr *MockTaskRepositoryMockRecorder) GetTask(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) GetTask(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskRepository)(nil).GetTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskRepository)(nil).GetTask), arg0, arg1, arg2)
 }
 
 // GetTasks mocks base method.
-func (m *MockTaskRepository) GetTasks(arg0 string) ([]models.TaskDetails, error) {
+func (m *MockTaskRepository) GetTasks(arg0 context.Context, arg1 string) ([]models.TaskDetails, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", arg0)
+	ret := m.ctrl.Call(m, "GetTasks", arg0, arg1)
 	ret0, _ := ret[0].([]models.TaskDetails)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasks indicates an expected call of GetTasks.
-func (mr *MockTaskRepositoryMockRecorder) GetTasks(arg0 interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) GetTasks(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTaskRepository)(nil).GetTasks), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTaskRepository)(nil).GetTasks), arg0, arg1)
 }
 
 // InsertTask mocks base method.
-func (m *MockTaskRepository) InsertTask(arg0 models.CreateTask, arg1 string) error {
+func (m *MockTaskRepository) InsertTask(arg0 context.Context, arg1 models.CreateTask, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertTask indicates an expected call of InsertTask.
+func (mr *MockTaskRepositoryMockRecorder) InsertTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTask", reflect.TypeOf((*MockTaskRepository)(nil).InsertTask), arg0, arg1, arg2)
+}
+
+// ListTasks mocks base method.
+func (m *MockTaskRepository) ListTasks(arg0 context.Context, arg1 string, arg2 models.TaskQuery) ([]models.TaskDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.TaskDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockTaskRepositoryMockRecorder) ListTasks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskRepository)(nil).ListTasks), arg0, arg1, arg2)
+}
+
+// PatchTask mocks base method.
+func (m *MockTaskRepository) PatchTask(arg0 context.Context, arg1, arg2 string, arg3 models.TaskChanges) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertTask", arg0, arg1)
+	ret := m.ctrl.Call(m, "PatchTask", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertTask indicates an expected call of InsertTask.
-func (mr *MockTaskRepositoryMockRecorder) InsertTask(arg0, arg1 interface{}) *gomock.Call {
+// PatchTask indicates an expected call of PatchTask.
+func (mr *MockTaskRepositoryMockRecorder) PatchTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTask", reflect.TypeOf((*MockTaskRepository)(nil).InsertTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTask", reflect.TypeOf((*MockTaskRepository)(nil).PatchTask), arg0, arg1, arg2, arg3)
 }
 
 // Update mocks base method.
-func (m *MockTaskRepository) Update(arg0, arg1 string, arg2 models.CreateTask) error {
+func (m *MockTaskRepository) Update(arg0 context.Context, arg1, arg2 string, arg3 models.CreateTask) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTaskRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskRepository)(nil).Update), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskRepository)(nil).Update), arg0, arg1, arg2, arg3)
 }
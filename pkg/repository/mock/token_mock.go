@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\repository\interface\token.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokenRepository is a mock of TokenRepository interface.
+type MockTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRepositoryMockRecorder
+}
+
+// MockTokenRepositoryMockRecorder is the mock recorder for MockTokenRepository.
+type MockTokenRepositoryMockRecorder struct {
+	mock *MockTokenRepository
+}
+
+// NewMockTokenRepository creates a new mock instance.
+func NewMockTokenRepository(ctrl *gomock.Controller) *MockTokenRepository {
+	mock := &MockTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRepository) EXPECT() *MockTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteToken mocks base method.
+func (m *MockTokenRepository) DeleteToken(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteToken indicates an expected call of DeleteToken.
+func (mr *MockTokenRepositoryMockRecorder) DeleteToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockTokenRepository)(nil).DeleteToken), arg0, arg1, arg2)
+}
+
+// DeleteTokensByUser mocks base method.
+func (m *MockTokenRepository) DeleteTokensByUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTokensByUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTokensByUser indicates an expected call of DeleteTokensByUser.
+func (mr *MockTokenRepositoryMockRecorder) DeleteTokensByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTokensByUser", reflect.TypeOf((*MockTokenRepository)(nil).DeleteTokensByUser), arg0, arg1)
+}
+
+// FindTokenByHash mocks base method.
+func (m *MockTokenRepository) FindTokenByHash(arg0 context.Context, arg1 string) (models.TokenAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTokenByHash", arg0, arg1)
+	ret0, _ := ret[0].(models.TokenAuth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTokenByHash indicates an expected call of FindTokenByHash.
+func (mr *MockTokenRepositoryMockRecorder) FindTokenByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTokenByHash", reflect.TypeOf((*MockTokenRepository)(nil).FindTokenByHash), arg0, arg1)
+}
+
+// GetTokens mocks base method.
+func (m *MockTokenRepository) GetTokens(arg0 context.Context, arg1 string) ([]models.TokenDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokens", arg0, arg1)
+	ret0, _ := ret[0].([]models.TokenDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokens indicates an expected call of GetTokens.
+func (mr *MockTokenRepositoryMockRecorder) GetTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokens", reflect.TypeOf((*MockTokenRepository)(nil).GetTokens), arg0, arg1)
+}
+
+// InsertToken mocks base method.
+func (m *MockTokenRepository) InsertToken(arg0 context.Context, arg1 models.NewToken) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertToken", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertToken indicates an expected call of InsertToken.
+func (mr *MockTokenRepositoryMockRecorder) InsertToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertToken", reflect.TypeOf((*MockTokenRepository)(nil).InsertToken), arg0, arg1)
+}
@@ -5,8 +5,10 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 	models "taskmanagementapi/pkg/utils/models"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,60 +37,240 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 }
 
 // CheckUserExistsByEmail mocks base method.
-func (m *MockUserRepository) CheckUserExistsByEmail(arg0 string) (bool, error) {
+func (m *MockUserRepository) CheckUserExistsByEmail(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckUserExistsByEmail", arg0)
+	ret := m.ctrl.Call(m, "CheckUserExistsByEmail", arg0, arg1)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CheckUserExistsByEmail indicates an expected call of CheckUserExistsByEmail.
-func (mr *MockUserRepositoryMockRecorder) CheckUserExistsByEmail(arg0 interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) CheckUserExistsByEmail(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserExistsByEmail", reflect.TypeOf((*MockUserRepository)(nil).CheckUserExistsByEmail), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserExistsByEmail", reflect.TypeOf((*MockUserRepository)(nil).CheckUserExistsByEmail), arg0, arg1)
+}
+
+// ConfirmEmail mocks base method.
+func (m *MockUserRepository) ConfirmEmail(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmail", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEmail indicates an expected call of ConfirmEmail.
+func (mr *MockUserRepositoryMockRecorder) ConfirmEmail(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmail", reflect.TypeOf((*MockUserRepository)(nil).ConfirmEmail), arg0, arg1, arg2)
+}
+
+// CreateOIDCUser mocks base method.
+func (m *MockUserRepository) CreateOIDCUser(arg0 context.Context, arg1 models.OIDCUser) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOIDCUser", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOIDCUser indicates an expected call of CreateOIDCUser.
+func (mr *MockUserRepositoryMockRecorder) CreateOIDCUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOIDCUser", reflect.TypeOf((*MockUserRepository)(nil).CreateOIDCUser), arg0, arg1)
+}
+
+// FindUserByID mocks base method.
+func (m *MockUserRepository) FindUserByID(arg0 context.Context, arg1 string) (models.UserDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByID", arg0, arg1)
+	ret0, _ := ret[0].(models.UserDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByID indicates an expected call of FindUserByID.
+func (mr *MockUserRepositoryMockRecorder) FindUserByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByID", reflect.TypeOf((*MockUserRepository)(nil).FindUserByID), arg0, arg1)
+}
+
+// FindUserByIdentity mocks base method.
+func (m *MockUserRepository) FindUserByIdentity(arg0 context.Context, arg1, arg2 string) (models.UserDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByIdentity", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.UserDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByIdentity indicates an expected call of FindUserByIdentity.
+func (mr *MockUserRepositoryMockRecorder) FindUserByIdentity(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByIdentity", reflect.TypeOf((*MockUserRepository)(nil).FindUserByIdentity), arg0, arg1, arg2)
 }
 
 // FindUserDetailsByEmail mocks base method.
-func (m *MockUserRepository) FindUserDetailsByEmail(arg0 string) (models.UserDetails, error) {
+func (m *MockUserRepository) FindUserDetailsByEmail(arg0 context.Context, arg1 string) (models.UserDetails, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindUserDetailsByEmail", arg0)
+	ret := m.ctrl.Call(m, "FindUserDetailsByEmail", arg0, arg1)
 	ret0, _ := ret[0].(models.UserDetails)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindUserDetailsByEmail indicates an expected call of FindUserDetailsByEmail.
-func (mr *MockUserRepositoryMockRecorder) FindUserDetailsByEmail(arg0 interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) FindUserDetailsByEmail(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserDetailsByEmail", reflect.TypeOf((*MockUserRepository)(nil).FindUserDetailsByEmail), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserDetailsByEmail", reflect.TypeOf((*MockUserRepository)(nil).FindUserDetailsByEmail), arg0, arg1)
 }
 
 // GenerateJwtToken mocks base method.
-func (m *MockUserRepository) GenerateJwtToken(user models.UserDetails) (string, error) {
+func (m *MockUserRepository) GenerateJwtToken(arg0 models.UserDetails, arg1 string) (string, time.Time, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateJwtToken", user)
+	ret := m.ctrl.Call(m, "GenerateJwtToken", arg0, arg1)
 	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateJwtToken indicates an expected call of GenerateJwtToken.
+func (mr *MockUserRepositoryMockRecorder) GenerateJwtToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateJwtToken", reflect.TypeOf((*MockUserRepository)(nil).GenerateJwtToken), arg0, arg1)
+}
+
+// LinkIdentity mocks base method.
+func (m *MockUserRepository) LinkIdentity(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIdentity", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkIdentity indicates an expected call of LinkIdentity.
+func (mr *MockUserRepositoryMockRecorder) LinkIdentity(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIdentity", reflect.TypeOf((*MockUserRepository)(nil).LinkIdentity), arg0, arg1, arg2, arg3)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserRepository) ListUsers(arg0 context.Context, arg1 models.UserFilter) ([]models.UserSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].([]models.UserSummary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GenerateJwtToken indicates an expected call of GenerateJwtToken.
-func (mr *MockUserRepositoryMockRecorder) GenerateJwtToken(user interface{}) *gomock.Call {
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserRepositoryMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserRepository)(nil).ListUsers), arg0, arg1)
+}
+
+// RehashPassword mocks base method.
+func (m *MockUserRepository) RehashPassword(arg0 context.Context, arg1, arg2, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RehashPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RehashPassword indicates an expected call of RehashPassword.
+func (mr *MockUserRepositoryMockRecorder) RehashPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RehashPassword", reflect.TypeOf((*MockUserRepository)(nil).RehashPassword), arg0, arg1, arg2, arg3)
+}
+
+// SetPendingEmail mocks base method.
+func (m *MockUserRepository) SetPendingEmail(arg0 context.Context, arg1 string, arg2 models.EmailVerification) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPendingEmail", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPendingEmail indicates an expected call of SetPendingEmail.
+func (mr *MockUserRepositoryMockRecorder) SetPendingEmail(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingEmail", reflect.TypeOf((*MockUserRepository)(nil).SetPendingEmail), arg0, arg1, arg2)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockUserRepository) SetUserDisabled(arg0 context.Context, arg1 string, arg2 bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDisabled", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockUserRepositoryMockRecorder) SetUserDisabled(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockUserRepository)(nil).SetUserDisabled), arg0, arg1, arg2)
+}
+
+// UpdateName mocks base method.
+func (m *MockUserRepository) UpdateName(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateName indicates an expected call of UpdateName.
+func (mr *MockUserRepositoryMockRecorder) UpdateName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateName", reflect.TypeOf((*MockUserRepository)(nil).UpdateName), arg0, arg1, arg2)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), arg0, arg1, arg2)
+}
+
+// UpdateRole mocks base method.
+func (m *MockUserRepository) UpdateRole(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockUserRepositoryMockRecorder) UpdateRole(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateJwtToken", reflect.TypeOf((*MockUserRepository)(nil).GenerateJwtToken), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockUserRepository)(nil).UpdateRole), arg0, arg1, arg2)
 }
 
 // UserSignUp mocks base method.
-func (m *MockUserRepository) UserSignUp(arg0 models.UserSignup) error {
+func (m *MockUserRepository) UserSignUp(arg0 context.Context, arg1 models.UserSignup) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserSignUp", arg0)
+	ret := m.ctrl.Call(m, "UserSignUp", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UserSignUp indicates an expected call of UserSignUp.
-func (mr *MockUserRepositoryMockRecorder) UserSignUp(arg0 interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) UserSignUp(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSignUp", reflect.TypeOf((*MockUserRepository)(nil).UserSignUp), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSignUp", reflect.TypeOf((*MockUserRepository)(nil).UserSignUp), arg0, arg1)
 }
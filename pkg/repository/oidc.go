@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type OIDCRepository struct {
+	StateCollection *mongo.Collection
+}
+
+func NewOIDCRepository(db *mongo.Database) interfaces.OIDCRepository {
+	return &OIDCRepository{StateCollection: db.Collection("oidc_states")}
+}
+
+func (or *OIDCRepository) InsertState(ctx context.Context, state models.OIDCState) error {
+	newState := bson.M{
+		"state":      state.State,
+		"verifier":   state.Verifier,
+		"nonce":      state.Nonce,
+		"created_at": state.CreatedAt,
+	}
+	_, err := or.StateCollection.InsertOne(ctx, newState)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ConsumeState deletes and returns the pending login for state, so that each
+// authorization response can only be redeemed once.
+func (or *OIDCRepository) ConsumeState(ctx context.Context, state string) (models.OIDCState, error) {
+	var result models.OIDCState
+	err := or.StateCollection.FindOneAndDelete(ctx, bson.M{"state": state}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.OIDCState{}, nil
+		}
+		return models.OIDCState{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestConsumeState(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("state found", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: bson.D{
+				{Key: "state", Value: "s"},
+				{Key: "verifier", Value: "v"},
+				{Key: "nonce", Value: "n"},
+			}},
+		})
+		or := repository.NewOIDCRepository(mt.Client.Database("test"))
+
+		state, err := or.ConsumeState(context.TODO(), "s")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.OIDCState{State: "s", Verifier: "v", Nonce: "n"}, state)
+	})
+
+	mt.Run("state not found", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: nil}})
+		or := repository.NewOIDCRepository(mt.Client.Database("test"))
+
+		state, err := or.ConsumeState(context.TODO(), "s")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.OIDCState{}, state)
+	})
+}
+
+func TestInsertState(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("successfully insert state", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		or := repository.NewOIDCRepository(mt.Client.Database("test"))
+
+		err := or.InsertState(context.TODO(), models.OIDCState{State: "s", CreatedAt: time.Now()})
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PreferenceRepository struct {
+	PreferenceCollection *mongo.Collection
+}
+
+func NewPreferenceRepository(db *mongo.Database) interfaces.PreferenceRepository {
+	return &PreferenceRepository{PreferenceCollection: db.Collection("preferences")}
+}
+
+func (pr *PreferenceRepository) GetPreferences(ctx context.Context, userID string) (models.Preferences, error) {
+	var preferences models.Preferences
+	err := pr.PreferenceCollection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&preferences)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Preferences{}, nil
+		}
+		return models.Preferences{}, err
+	}
+	return preferences, nil
+}
+
+func (pr *PreferenceRepository) UpsertPreferences(ctx context.Context, userID string, preferences models.Preferences) error {
+	document := bson.M{
+		"user_id":         userID,
+		"timezone":        preferences.Timezone,
+		"locale":          preferences.Locale,
+		"week_start":      preferences.WeekStart,
+		"default_sort":    preferences.DefaultSort,
+		"page_size":       preferences.PageSize,
+		"default_project": preferences.DefaultProject,
+	}
+	opts := options.Replace().SetUpsert(true)
+	_, err := pr.PreferenceCollection.ReplaceOne(ctx, bson.M{"user_id": userID}, document, opts)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestGetPreferences(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("preferences found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.preferences", mtest.FirstBatch, bson.D{
+			{Key: "user_id", Value: "user1"},
+			{Key: "timezone", Value: "Asia/Kolkata"},
+			{Key: "locale", Value: "en-IN"},
+			{Key: "week_start", Value: "sunday"},
+			{Key: "default_sort", Value: "title"},
+			{Key: "page_size", Value: 50},
+		}))
+		pr := repository.NewPreferenceRepository(mt.Client.Database("test"))
+
+		preferences, err := pr.GetPreferences(context.TODO(), "user1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.Preferences{
+			Timezone:    "Asia/Kolkata",
+			Locale:      "en-IN",
+			WeekStart:   "sunday",
+			DefaultSort: "title",
+			PageSize:    50,
+		}, preferences)
+	})
+
+	mt.Run("no preferences saved", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.preferences", mtest.FirstBatch))
+		pr := repository.NewPreferenceRepository(mt.Client.Database("test"))
+
+		preferences, err := pr.GetPreferences(context.TODO(), "user1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.Preferences{}, preferences)
+	})
+}
+
+func TestUpsertPreferences(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("upserts by user", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "upserted", Value: bson.A{bson.D{{Key: "index", Value: 0}, {Key: "_id", Value: "x"}}}}})
+		pr := repository.NewPreferenceRepository(mt.Client.Database("test"))
+
+		err := pr.UpsertPreferences(context.TODO(), "user1", models.DefaultPreferences())
+
+		assert.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		assert.True(t, update.Lookup("upsert").Boolean())
+		assert.Equal(t, "user1", update.Lookup("q", "user_id").StringValue())
+	})
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"sync"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// rateLimitResult describes a bucket left with tokens after a request.
+func rateLimitResult(limit models.RateLimit, tokens float64, allowed bool) models.RateLimitResult {
+	result := models.RateLimitResult{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(limit.Burst) - tokens) * float64(limit.Every)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(limit.Every))
+	}
+	return result
+}
+
+// bucketLifetime is how long an idle bucket takes to refill; after that it is
+// the same as no bucket and can be dropped.
+func bucketLifetime(limit models.RateLimit) time.Duration {
+	return time.Duration(limit.Burst) * limit.Every
+}
+
+// RateLimitRepository keeps buckets in Mongo so that every API instance
+// shares them.
+type RateLimitRepository struct {
+	RateLimitCollection *mongo.Collection
+}
+
+func NewRateLimitRepository(db *mongo.Database) interfaces.RateLimitRepository {
+	return &RateLimitRepository{RateLimitCollection: db.Collection("rate_limits")}
+}
+
+// Take refills and debits the bucket for key in a single atomic update.
+func (rr *RateLimitRepository) Take(ctx context.Context, key string, limit models.RateLimit) (models.RateLimitResult, error) {
+	now := time.Now().UTC()
+	burst := float64(limit.Burst)
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"tokens": bson.M{"$min": bson.A{burst, bson.M{"$add": bson.A{
+				bson.M{"$ifNull": bson.A{"$tokens", burst}},
+				bson.M{"$divide": bson.A{
+					bson.M{"$subtract": bson.A{now, bson.M{"$ifNull": bson.A{"$updated_at", now}}}},
+					float64(limit.Every.Milliseconds()),
+				}},
+			}}}},
+		}},
+		bson.M{"$set": bson.M{"allowed": bson.M{"$gte": bson.A{"$tokens", 1}}}},
+		bson.M{"$set": bson.M{
+			"tokens":     bson.M{"$cond": bson.A{"$allowed", bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}},
+			"updated_at": now,
+			"expires_at": now.Add(bucketLifetime(limit)),
+		}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var bucket struct {
+		Tokens  float64 `bson:"tokens"`
+		Allowed bool    `bson:"allowed"`
+	}
+	err := rr.RateLimitCollection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&bucket)
+	if err != nil {
+		return models.RateLimitResult{}, err
+	}
+	return rateLimitResult(limit, bucket.Tokens, bucket.Allowed), nil
+}
+
+func (rr *RateLimitRepository) DeleteExpired(ctx context.Context) error {
+	_, err := rr.RateLimitCollection.DeleteMany(ctx, bson.M{"expires_at": bson.M{"$lt": time.Now().UTC()}})
+	return err
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	expiresAt time.Time
+}
+
+// MemoryRateLimitRepository keeps buckets in process memory. Each API
+// instance then enforces the limits on its own.
+type MemoryRateLimitRepository struct {
+	mu      sync.Mutex
+	buckets map[string]memoryBucket
+}
+
+func NewMemoryRateLimitRepository() interfaces.RateLimitRepository {
+	return &MemoryRateLimitRepository{buckets: make(map[string]memoryBucket)}
+}
+
+func (mr *MemoryRateLimitRepository) Take(ctx context.Context, key string, limit models.RateLimit) (models.RateLimitResult, error) {
+	now := time.Now()
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	bucket, ok := mr.buckets[key]
+	if !ok {
+		bucket = memoryBucket{tokens: float64(limit.Burst), updatedAt: now}
+	}
+	bucket.tokens = math.Min(float64(limit.Burst), bucket.tokens+float64(now.Sub(bucket.updatedAt))/float64(limit.Every))
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+	bucket.updatedAt = now
+	bucket.expiresAt = now.Add(bucketLifetime(limit))
+	mr.buckets[key] = bucket
+	return rateLimitResult(limit, bucket.tokens, allowed), nil
+}
+
+func (mr *MemoryRateLimitRepository) DeleteExpired(ctx context.Context) error {
+	now := time.Now()
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+	for key, bucket := range mr.buckets {
+		if bucket.expiresAt.Before(now) {
+			delete(mr.buckets, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestMemoryRateLimit(t *testing.T) {
+	limit := models.RateLimit{Burst: 2, Every: time.Hour}
+	rr := repository.NewMemoryRateLimitRepository()
+
+	first, err := rr.Take(context.TODO(), "k", limit)
+	require.NoError(t, err)
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 2, first.Limit)
+	assert.Equal(t, 1, first.Remaining)
+
+	second, _ := rr.Take(context.TODO(), "k", limit)
+	assert.True(t, second.Allowed)
+	assert.Equal(t, 0, second.Remaining)
+	assert.InDelta(t, float64(2*time.Hour), float64(second.Reset), float64(time.Second))
+
+	third, _ := rr.Take(context.TODO(), "k", limit)
+	assert.False(t, third.Allowed)
+	assert.InDelta(t, float64(time.Hour), float64(third.RetryAfter), float64(time.Second))
+
+	other, _ := rr.Take(context.TODO(), "other", limit)
+	assert.True(t, other.Allowed, "buckets are per key")
+}
+
+func TestMemoryRateLimitRefills(t *testing.T) {
+	limit := models.RateLimit{Burst: 1, Every: 20 * time.Millisecond}
+	rr := repository.NewMemoryRateLimitRepository()
+
+	result, _ := rr.Take(context.TODO(), "k", limit)
+	assert.True(t, result.Allowed)
+	result, _ = rr.Take(context.TODO(), "k", limit)
+	assert.False(t, result.Allowed)
+
+	time.Sleep(30 * time.Millisecond)
+	result, _ = rr.Take(context.TODO(), "k", limit)
+	assert.True(t, result.Allowed)
+
+	time.Sleep(30 * time.Millisecond)
+	assert.NoError(t, rr.DeleteExpired(context.TODO()))
+}
+
+func TestRateLimitTake(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	limit := models.RateLimit{Burst: 10, Every: 6 * time.Second}
+
+	mt.Run("allowed", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: bson.D{
+			{Key: "_id", Value: "tasks:user:u1"},
+			{Key: "tokens", Value: 4.5},
+			{Key: "allowed", Value: true},
+		}}})
+		rr := repository.NewRateLimitRepository(mt.Client.Database("test"))
+
+		result, err := rr.Take(context.TODO(), "tasks:user:u1", limit)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.RateLimitResult{Allowed: true, Limit: 10, Remaining: 4, Reset: 33 * time.Second}, result)
+	})
+
+	mt.Run("rejected", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: bson.D{
+			{Key: "_id", Value: "tasks:user:u1"},
+			{Key: "tokens", Value: 0.5},
+			{Key: "allowed", Value: false},
+		}}})
+		rr := repository.NewRateLimitRepository(mt.Client.Database("test"))
+
+		result, err := rr.Take(context.TODO(), "tasks:user:u1", limit)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 3*time.Second, result.RetryAfter)
+	})
+
+	mt.Run("error during FindOneAndUpdate", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "update error"}))
+		rr := repository.NewRateLimitRepository(mt.Client.Database("test"))
+
+		_, err := rr.Take(context.TODO(), "tasks:user:u1", limit)
+
+		assert.EqualError(t, err, "update error")
+	})
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SessionRepository struct {
+	SessionCollection *mongo.Collection
+}
+
+func NewSessionRepository(db *mongo.Database) interfaces.SessionRepository {
+	return &SessionRepository{SessionCollection: db.Collection("sessions")}
+}
+
+func (sr *SessionRepository) InsertSession(ctx context.Context, session models.NewSession) error {
+	newSession := bson.M{
+		"jti":          session.JTI,
+		"user_id":      session.UserID,
+		"user_agent":   session.UserAgent,
+		"ip":           session.IP,
+		"created_at":   session.CreatedAt,
+		"last_seen_at": session.CreatedAt,
+		"expires_at":   session.ExpiresAt,
+	}
+	_, err := sr.SessionCollection.InsertOne(ctx, newSession)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sr *SessionRepository) FindSessionByJTI(ctx context.Context, jti string) (models.Session, error) {
+	var session models.Session
+	err := sr.SessionCollection.FindOne(ctx, bson.M{"jti": jti}).Decode(&session)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Session{}, nil
+		}
+		return models.Session{}, err
+	}
+	return session, nil
+}
+
+func (sr *SessionRepository) GetSessions(ctx context.Context, userID string) ([]models.Session, error) {
+	filter := bson.M{
+		"user_id":    userID,
+		"expires_at": bson.M{"$gt": time.Now().UTC()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+	cursor, err := sr.SessionCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []models.Session
+	for cursor.Next(ctx) {
+		var session models.Session
+		if err := cursor.Decode(&session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, cursor.Err()
+}
+
+func (sr *SessionRepository) DeleteSession(ctx context.Context, userID, sessionID string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return false, errors.New("invalid ObjectID format")
+	}
+	filter := bson.M{
+		"user_id": userID,
+		"_id":     objID,
+	}
+	result, err := sr.SessionCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (sr *SessionRepository) DeleteSessionByJTI(ctx context.Context, jti string) error {
+	_, err := sr.SessionCollection.DeleteOne(ctx, bson.M{"jti": jti})
+	return err
+}
+
+func (sr *SessionRepository) DeleteSessionsByUser(ctx context.Context, userID string) error {
+	_, err := sr.SessionCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+// TouchSessions writes a batch of last-seen times keyed by jti in a single
+// round trip. $max keeps an older batch from moving a session backwards.
+func (sr *SessionRepository) TouchSessions(ctx context.Context, seen map[string]time.Time) error {
+	if len(seen) == 0 {
+		return nil
+	}
+	writes := make([]mongo.WriteModel, 0, len(seen))
+	for jti, at := range seen {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"jti": jti}).
+			SetUpdate(bson.M{"$max": bson.M{"last_seen_at": at}}))
+	}
+	_, err := sr.SessionCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
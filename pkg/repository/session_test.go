@@ -0,0 +1,102 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestFindSessionByJTI(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("session found", func(mt *mtest.T) {
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.sessions", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: id},
+			{Key: "jti", Value: "jti1"},
+			{Key: "user_id", Value: "user1"},
+		}))
+		sr := repository.NewSessionRepository(mt.Client.Database("test"))
+
+		session, err := sr.FindSessionByJTI(context.TODO(), "jti1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, id.Hex(), session.ID)
+		assert.Equal(t, "user1", session.UserID)
+	})
+
+	mt.Run("session not found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.sessions", mtest.FirstBatch))
+		sr := repository.NewSessionRepository(mt.Client.Database("test"))
+
+		session, err := sr.FindSessionByJTI(context.TODO(), "jti1")
+
+		assert.NoError(t, err)
+		assert.Empty(t, session.ID)
+	})
+}
+
+func TestDeleteSession(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("session deleted", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}})
+		sr := repository.NewSessionRepository(mt.Client.Database("test"))
+
+		deleted, err := sr.DeleteSession(context.TODO(), "user1", primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+	})
+
+	mt.Run("invalid ObjectID format", func(mt *mtest.T) {
+		sr := repository.NewSessionRepository(mt.Client.Database("test"))
+
+		_, err := sr.DeleteSession(context.TODO(), "user1", "invalid_id")
+
+		assert.EqualError(t, err, "invalid ObjectID format")
+	})
+}
+
+func TestDeleteSessionByJTI(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("deletes by jti", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}})
+		sr := repository.NewSessionRepository(mt.Client.Database("test"))
+
+		assert.NoError(t, sr.DeleteSessionByJTI(context.TODO(), "jti1"))
+		deletes := mt.GetStartedEvent().Command.Lookup("deletes").Array().Index(0).Value().Document()
+		assert.Equal(t, "jti1", deletes.Lookup("q", "jti").StringValue())
+	})
+}
+
+func TestTouchSessions(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("one bulk write for the batch", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 2}, {Key: "nModified", Value: 2}})
+		sr := repository.NewSessionRepository(mt.Client.Database("test"))
+
+		err := sr.TouchSessions(context.TODO(), map[string]time.Time{"jti1": time.Now(), "jti2": time.Now()})
+
+		assert.NoError(t, err)
+		started := mt.GetAllStartedEvents()
+		if assert.Len(t, started, 1) {
+			updates, err := started[0].Command.Lookup("updates").Array().Values()
+			assert.NoError(t, err)
+			assert.Len(t, updates, 2)
+		}
+	})
+
+	mt.Run("empty batch is skipped", func(mt *mtest.T) {
+		sr := repository.NewSessionRepository(mt.Client.Database("test"))
+
+		err := sr.TouchSessions(context.TODO(), map[string]time.Time{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, mt.GetAllStartedEvents())
+	})
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
 	interfaces "taskmanagementapi/pkg/repository/interface"
 	"taskmanagementapi/pkg/utils/models"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TaskRepository struct {
@@ -22,19 +24,19 @@ func NewTaskRepository(db *mongo.Database) interfaces.TaskRepository {
 		UserCollection: db.Collection("users")}
 }
 
-func (repo *TaskRepository) CheckUserIDExist(userID string) (bool, error) {
+func (repo *TaskRepository) CheckUserIDExist(ctx context.Context, userID string) (bool, error) {
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return false, errors.New("invalid ObjectID format")
 	}
-	count, err := repo.UserCollection.CountDocuments(context.TODO(), bson.M{"_id": objID})
+	count, err := repo.UserCollection.CountDocuments(ctx, bson.M{"_id": objID})
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
-func (tk *TaskRepository) InsertTask(task models.CreateTask, userID string) error {
+func newTaskDocument(task models.CreateTask, userID string) bson.M {
 	currentTime := time.Now().Format(time.RFC3339)
 	newTask := bson.M{
 		"user_id":     userID,
@@ -42,22 +44,30 @@ func (tk *TaskRepository) InsertTask(task models.CreateTask, userID string) erro
 		"description": task.Description,
 		"created_at":  currentTime,
 	}
-	_, err := tk.TaskCollection.InsertOne(context.TODO(), newTask)
+	if task.Project != "" {
+		newTask["project"] = task.Project
+	}
+	return newTask
+}
+
+func (tk *TaskRepository) InsertTask(ctx context.Context, task models.CreateTask, userID string) (string, error) {
+	result, err := tk.TaskCollection.InsertOne(ctx, newTaskDocument(task, userID))
 	if err != nil {
-		return err
+		return "", err
 	}
-	return nil
+	objID, _ := result.InsertedID.(primitive.ObjectID)
+	return objID.Hex(), nil
 }
 
-func (tk *TaskRepository) GetTasks(userID string) ([]models.TaskDetails, error) {
-	cursor, err := tk.TaskCollection.Find(context.TODO(), bson.M{"user_id": userID})
+func (tk *TaskRepository) GetTasks(ctx context.Context, userID string) ([]models.TaskDetails, error) {
+	cursor, err := tk.TaskCollection.Find(ctx, bson.M{"user_id": userID})
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.TODO())
+	defer cursor.Close(ctx)
 
 	var tasks []models.TaskDetails
-	for cursor.Next(context.TODO()) {
+	for cursor.Next(ctx) {
 		var task models.TaskDetails
 		if err := cursor.Decode(&task); err != nil {
 			return nil, err
@@ -67,22 +77,41 @@ func (tk *TaskRepository) GetTasks(userID string) ([]models.TaskDetails, error)
 	return tasks, cursor.Err()
 }
 
-func (tk *TaskRepository) CheckTaskIDExist(taskID string) (bool, error) {
-	objID, err := primitive.ObjectIDFromHex(taskID)
-	if err != nil {
-		return false, errors.New("invalid ObjectID format")
+func (tk *TaskRepository) ListTasks(ctx context.Context, userID string, query models.TaskQuery) ([]models.TaskDetails, error) {
+	filter := bson.M{"user_id": userID}
+	if query.Project != "" {
+		filter["project"] = query.Project
+	}
+	field, order := strings.TrimPrefix(query.Sort, "-"), 1
+	if strings.HasPrefix(query.Sort, "-") {
+		order = -1
 	}
-	count, err := tk.TaskCollection.CountDocuments(context.TODO(), bson.M{"_id": objID})
+	opts := options.Find().
+		SetSort(bson.D{{Key: field, Value: order}, {Key: "_id", Value: order}}).
+		SetSkip(int64((query.Page - 1) * query.Limit)).
+		SetLimit(int64(query.Limit))
+	cursor, err := tk.TaskCollection.Find(ctx, filter, opts)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	return count > 0, nil
+	defer cursor.Close(ctx)
+
+	var tasks []models.TaskDetails
+	for cursor.Next(ctx) {
+		var task models.TaskDetails
+		if err := cursor.Decode(&task); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, cursor.Err()
 }
 
-func (tk *TaskRepository) GetTask(userID, taskID string) (models.TaskDetails, error) {
+func (tk *TaskRepository) GetTask(ctx context.Context, userID, taskID string) (models.TaskDetails, error) {
 	objID, err := primitive.ObjectIDFromHex(taskID)
 	if err != nil {
-		return models.TaskDetails{}, err
+		// No task has a malformed ID.
+		return models.TaskDetails{}, interfaces.ErrNotFound
 	}
 	filter := bson.M{
 		"user_id": userID,
@@ -90,54 +119,235 @@ func (tk *TaskRepository) GetTask(userID, taskID string) (models.TaskDetails, er
 	}
 	var task models.TaskDetails
 
-	err = tk.TaskCollection.FindOne(context.TODO(), filter).Decode(&task)
+	err = tk.TaskCollection.FindOne(ctx, filter).Decode(&task)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return models.TaskDetails{}, nil
+			return models.TaskDetails{}, interfaces.ErrNotFound
 		}
 		return models.TaskDetails{}, err
 	}
 	return task, nil
 }
 
-func (tk *TaskRepository) Update(userID, taskID string, task models.CreateTask) error {
+func (tk *TaskRepository) Update(ctx context.Context, userID, taskID string, task models.CreateTask) error {
 	objID, err := primitive.ObjectIDFromHex(taskID)
 	if err != nil {
-		return err
+		return interfaces.ErrNotFound
 	}
 	filter := bson.M{
 		"user_id": userID,
 		"_id":     objID,
 	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"title":       task.Title,
-			"description": task.Description,
-		},
-	}
-	_, err = tk.TaskCollection.UpdateOne(context.TODO(), filter, update)
+	result, err := tk.TaskCollection.UpdateOne(ctx, filter, taskUpdate(task))
 	if err != nil {
 		return err
 	}
+	if result.MatchedCount == 0 {
+		return interfaces.ErrNotFound
+	}
 	return nil
 }
 
-func (tk *TaskRepository) DeleteTask(userID, taskID string) error {
+func taskUpdate(task models.CreateTask) bson.M {
+	fields := bson.M{
+		"title":       task.Title,
+		"description": task.Description,
+	}
+	if task.Project != "" {
+		fields["project"] = task.Project
+	}
+	return bson.M{"$set": fields}
+}
+
+// PatchTask writes the changes only while the task still matches
+// changes.Expected, and returns ErrConflict once another write got there first.
+func (tk *TaskRepository) PatchTask(ctx context.Context, userID, taskID string, changes models.TaskChanges) error {
 	objID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return interfaces.ErrNotFound
+	}
+	// A missing project is stored by leaving the field out, which a null
+	// filter matches.
+	var project interface{}
+	if changes.Expected.Project != "" {
+		project = changes.Expected.Project
+	}
+	filter := bson.M{
+		"user_id":     userID,
+		"_id":         objID,
+		"title":       changes.Expected.Title,
+		"description": changes.Expected.Description,
+		"project":     project,
+	}
+	update := bson.M{}
+	if len(changes.Set) > 0 {
+		set := bson.M{}
+		for field, value := range changes.Set {
+			set[field] = value
+		}
+		update["$set"] = set
+	}
+	if len(changes.Unset) > 0 {
+		unset := bson.M{}
+		for _, field := range changes.Unset {
+			unset[field] = ""
+		}
+		update["$unset"] = unset
+	}
+	result, err := tk.TaskCollection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
+	if result.MatchedCount == 0 {
+		return interfaces.ErrConflict
+	}
+	return nil
+}
+
+func (tk *TaskRepository) DeleteTask(ctx context.Context, userID, taskID string) error {
+	objID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return interfaces.ErrNotFound
+	}
 	filter := bson.M{
 		"user_id": userID,
 		"_id":     objID,
 	}
-	result, err := tk.TaskCollection.DeleteOne(context.TODO(), filter)
+	result, err := tk.TaskCollection.DeleteOne(ctx, filter)
 	if err != nil {
 		return err
 	}
 	if result.DeletedCount == 0 {
+		return interfaces.ErrNotFound
+	}
+	return nil
+}
+
+// FindTaskIDs returns the IDs among taskIDs that belong to the user. Malformed
+// IDs are treated as missing.
+func (tk *TaskRepository) FindTaskIDs(ctx context.Context, userID string, taskIDs []string) ([]string, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		if objID, err := primitive.ObjectIDFromHex(taskID); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	if len(objIDs) == 0 {
+		return nil, nil
+	}
+	filter := bson.M{"user_id": userID, "_id": bson.M{"$in": objIDs}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	cursor, err := tk.TaskCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var found []string
+	for cursor.Next(ctx) {
+		var task struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&task); err != nil {
+			return nil, err
+		}
+		found = append(found, task.ID.Hex())
+	}
+	return found, cursor.Err()
+}
+
+// BulkWriteTasks runs the writes in one BulkWrite and reports each one.
+// Write errors are returned per item, as is ErrNotFound for an update or
+// delete that matched no task; any other failure is returned as err. Each
+// task may appear only once in writes.
+func (tk *TaskRepository) BulkWriteTasks(ctx context.Context, userID string, writes []models.TaskWrite, ordered bool) ([]models.TaskWriteResult, error) {
+	results := make([]models.TaskWriteResult, len(writes))
+	writeModels := make([]mongo.WriteModel, len(writes))
+	for i, write := range writes {
+		if write.Op == models.BulkCreate {
+			objID := primitive.NewObjectID()
+			document := newTaskDocument(write.Task, userID)
+			document["_id"] = objID
+			results[i].ID = objID.Hex()
+			writeModels[i] = mongo.NewInsertOneModel().SetDocument(document)
+			continue
+		}
+		objID, err := primitive.ObjectIDFromHex(write.ID)
+		if err != nil {
+			return nil, err
+		}
+		results[i].ID = write.ID
+		filter := bson.M{"user_id": userID, "_id": objID}
+		if write.Op == models.BulkUpdate {
+			writeModels[i] = mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(taskUpdate(write.Task))
+		} else {
+			writeModels[i] = mongo.NewDeleteOneModel().SetFilter(filter)
+		}
+	}
+	result, err := tk.TaskCollection.BulkWrite(ctx, writeModels, options.BulkWrite().SetOrdered(ordered))
+	var bulkErr mongo.BulkWriteException
+	if err != nil && (!errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil) {
+		return nil, err
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		results[writeErr.Index].Err = writeErr.WriteError
+		if ordered {
+			for i := writeErr.Index + 1; i < len(results); i++ {
+				results[i].Err = interfaces.ErrNotExecuted
+			}
+		}
+	}
+	if err := tk.markUnmatched(ctx, userID, writes, results, result); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// markUnmatched sets ErrNotFound on the updates and deletes that ran but
+// matched nothing, because the task was deleted after its owner was checked.
+// BulkWrite only counts matches for the whole batch, so when updates fall
+// short the tasks that still exist are looked up. A delete leaves nothing to
+// look up: when none matched, all of them are reported, and when some did the
+// others are indistinguishable but their tasks are gone all the same.
+func (tk *TaskRepository) markUnmatched(ctx context.Context, userID string, writes []models.TaskWrite, results []models.TaskWriteResult, result *mongo.BulkWriteResult) error {
+	if result == nil {
+		return nil
+	}
+	var updates, deletes []int
+	for i, write := range writes {
+		switch {
+		case results[i].Err != nil:
+		case write.Op == models.BulkUpdate:
+			updates = append(updates, i)
+		case write.Op == models.BulkDelete:
+			deletes = append(deletes, i)
+		}
+	}
+	if result.DeletedCount == 0 {
+		for _, i := range deletes {
+			results[i].Err = interfaces.ErrNotFound
+		}
+	}
+	if int(result.MatchedCount) >= len(updates) {
+		return nil
+	}
+	taskIDs := make([]string, len(updates))
+	for u, i := range updates {
+		taskIDs[u] = writes[i].ID
+	}
+	found, err := tk.FindTaskIDs(ctx, userID, taskIDs)
+	if err != nil {
 		return err
 	}
+	exists := make(map[string]bool, len(found))
+	for _, taskID := range found {
+		exists[taskID] = true
+	}
+	for _, i := range updates {
+		if !exists[writes[i].ID] {
+			results[i].Err = interfaces.ErrNotFound
+		}
+	}
 	return nil
 }
@@ -1,7 +1,9 @@
 package repository_test
 
 import (
+	"context"
 	"taskmanagementapi/pkg/repository"
+	interfaces "taskmanagementapi/pkg/repository/interface"
 	"taskmanagementapi/pkg/utils/models"
 	"testing"
 	"time"
@@ -25,7 +27,7 @@ func TestCheckUserIDExist(t *testing.T) {
 		)
 
 		userRepo := repository.NewTaskRepository(mt.Client.Database("test"))
-		exists, err := userRepo.CheckUserIDExist(userID.Hex())
+		exists, err := userRepo.CheckUserIDExist(context.TODO(), userID.Hex())
 
 		assert.NoError(t, err)
 		assert.True(t, exists)
@@ -39,14 +41,14 @@ func TestCheckUserIDExist(t *testing.T) {
 			}),
 		)
 		userRepo := repository.NewTaskRepository(mt.Client.Database("test"))
-		exists, err := userRepo.CheckUserIDExist(userID.Hex())
+		exists, err := userRepo.CheckUserIDExist(context.TODO(), userID.Hex())
 		assert.NoError(t, err)
 		assert.False(t, exists)
 	})
 
 	mt.Run("invalid ObjectID format", func(mt *mtest.T) {
 		userRepo := repository.NewTaskRepository(mt.Client.Database("test"))
-		_, err := userRepo.CheckUserIDExist("invalid_id")
+		_, err := userRepo.CheckUserIDExist(context.TODO(), "invalid_id")
 
 		assert.Error(t, err)
 		assert.Equal(t, "invalid ObjectID format", err.Error())
@@ -64,9 +66,10 @@ func TestInsertTask(t *testing.T) {
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
 
-		err := tk.InsertTask(task, userID)
+		taskID, err := tk.InsertTask(context.TODO(), task, userID)
 
 		assert.NoError(t, err)
+		assert.True(t, primitive.IsValidObjectID(taskID))
 	})
 
 	mt.Run("error during InsertOne operation", func(mt *mtest.T) {
@@ -82,7 +85,7 @@ func TestInsertTask(t *testing.T) {
 
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
 
-		err := tk.InsertTask(task, userID)
+		_, err := tk.InsertTask(context.TODO(), task, userID)
 
 		assert.EqualError(t, err, "duplicate key error")
 	})
@@ -128,7 +131,7 @@ func TestGetTasks(t *testing.T) {
 		)
 
 		taskRepo := repository.NewTaskRepository(mt.Client.Database("test"))
-		tasks, err := taskRepo.GetTasks(userID)
+		tasks, err := taskRepo.GetTasks(context.TODO(), userID)
 		for i := range tasks {
 			tasks[i].CreatedAt = tasks[i].CreatedAt.UTC().Truncate(time.Second)
 		}
@@ -149,7 +152,7 @@ func TestGetTasks(t *testing.T) {
 		)
 
 		taskRepo := repository.NewTaskRepository(mt.Client.Database("test"))
-		tasks, err := taskRepo.GetTasks(userID)
+		tasks, err := taskRepo.GetTasks(context.TODO(), userID)
 
 		assert.NoError(t, err)
 		assert.Len(t, tasks, 0)
@@ -159,51 +162,29 @@ func TestGetTasks(t *testing.T) {
 		invalidUserID := "invalid_user_id"
 
 		taskRepo := repository.NewTaskRepository(mt.Client.Database("test"))
-		tasks, err := taskRepo.GetTasks(invalidUserID)
+		tasks, err := taskRepo.GetTasks(context.TODO(), invalidUserID)
 
 		assert.Error(t, err)
 		assert.Nil(t, tasks)
 	})
 }
 
-func TestCheckTaskIDExist(t *testing.T) {
+func TestListTasks(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
-	mt.Run("valid ObjectID exists", func(mt *mtest.T) {
-		taskID := primitive.NewObjectID()
-		mt.AddMockResponses(
-			mtest.CreateCursorResponse(1, "test.tasks", mtest.FirstBatch, bson.D{
-				{Key: "n", Value: 1},
-				{Key: "_id", Value: taskID},
-			}),
-		)
 
-		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		exists, err := tk.CheckTaskIDExist(taskID.Hex())
-
-		assert.NoError(t, err)
-		assert.True(t, exists)
-	})
-
-	mt.Run("valid ObjectID does not exist", func(mt *mtest.T) {
-		taskID := primitive.NewObjectID()
-		mt.AddMockResponses(
-			mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch, bson.D{
-				{Key: "n", Value: 0},
-			}),
-		)
+	mt.Run("applies project, sort and page", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
 
-		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		exists, err := tk.CheckTaskIDExist(taskID.Hex())
+		taskRepo := repository.NewTaskRepository(mt.Client.Database("test"))
+		tasks, err := taskRepo.ListTasks(context.TODO(), "user1", models.TaskQuery{Sort: "-title", Page: 3, Limit: 10, Project: "home"})
 
 		assert.NoError(t, err)
-		assert.False(t, exists)
-	})
-
-	mt.Run("invalid ObjectID format", func(mt *mtest.T) {
-		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		_, err := tk.CheckTaskIDExist("invalid_id")
-		assert.Error(t, err)
-		assert.Equal(t, "invalid ObjectID format", err.Error())
+		assert.Len(t, tasks, 0)
+		command := mt.GetStartedEvent().Command
+		assert.Equal(t, "home", command.Lookup("filter", "project").StringValue())
+		assert.Equal(t, int32(-1), command.Lookup("sort", "title").Int32())
+		assert.Equal(t, int64(20), command.Lookup("skip").Int64())
+		assert.Equal(t, int64(10), command.Lookup("limit").Int64())
 	})
 }
 
@@ -227,7 +208,7 @@ func TestGetTask(t *testing.T) {
 		}))
 
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		retrievedTask, err := tk.GetTask(userID, task.ID)
+		retrievedTask, err := tk.GetTask(context.TODO(), userID, task.ID)
 
 		assert.NoError(t, err)
 		assert.Equal(t, task.ID, retrievedTask.ID)
@@ -240,17 +221,17 @@ func TestGetTask(t *testing.T) {
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
 
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		retrievedTask, err := tk.GetTask(userID, taskID)
+		retrievedTask, err := tk.GetTask(context.TODO(), userID, taskID)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
 		assert.Empty(t, retrievedTask)
 	})
 
 	mt.Run("invalid ObjectID format", func(mt *mtest.T) {
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		_, err := tk.GetTask("6705824f80a09eb0313f0e42", "invalid_id")
+		_, err := tk.GetTask(context.TODO(), "6705824f80a09eb0313f0e42", "invalid_id")
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
 	})
 }
 
@@ -265,10 +246,10 @@ func TestUpdate(t *testing.T) {
 			Description: "Updated description",
 		}
 
-		mt.AddMockResponses(mtest.CreateSuccessResponse()) 
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
 
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		err := tk.Update(userID, taskID, task)
+		err := tk.Update(context.TODO(), userID, taskID, task)
 
 		assert.NoError(t, err)
 	})
@@ -287,7 +268,7 @@ func TestUpdate(t *testing.T) {
 		}))
 
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		err := tk.Update(userID, taskID, task)
+		err := tk.Update(context.TODO(), userID, taskID, task)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "duplicate key error")
@@ -295,7 +276,155 @@ func TestUpdate(t *testing.T) {
 
 	mt.Run("invalid ObjectID format", func(mt *mtest.T) {
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		err := tk.Update("userID", "invalid_id", models.CreateTask{})
+		err := tk.Update(context.TODO(), "userID", "invalid_id", models.CreateTask{})
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+
+	mt.Run("task of another user", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}})
+
+		tk := repository.NewTaskRepository(mt.Client.Database("test"))
+		err := tk.Update(context.TODO(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), models.CreateTask{Title: "Task"})
+
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+}
+
+func TestPatchTask(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("sets and unsets changed fields", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		tk := repository.NewTaskRepository(mt.Client.Database("test"))
+		err := tk.PatchTask(context.TODO(), "user1", primitive.NewObjectID().Hex(), models.TaskChanges{
+			Set:      map[string]string{"title": "New title"},
+			Unset:    []string{"project"},
+			Expected: models.CreateTask{Title: "Task", Description: "Description", Project: "home"},
+		})
+		assert.NoError(t, err)
+
+		statement := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		filter := statement.Lookup("q").Document()
+		assert.Equal(t, "Task", filter.Lookup("title").StringValue())
+		assert.Equal(t, "home", filter.Lookup("project").StringValue())
+		update := statement.Lookup("u").Document()
+		assert.Equal(t, "New title", update.Lookup("$set", "title").StringValue())
+		assert.NoError(t, update.Lookup("$unset", "project").Validate())
+		assert.Error(t, update.Lookup("$set", "description").Validate())
+	})
+
+	mt.Run("task changed since it was read", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}))
+
+		tk := repository.NewTaskRepository(mt.Client.Database("test"))
+		err := tk.PatchTask(context.TODO(), "user1", primitive.NewObjectID().Hex(), models.TaskChanges{Set: map[string]string{"title": "New title"}})
+		assert.ErrorIs(t, err, interfaces.ErrConflict)
+		filter := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("q").Document()
+		assert.Equal(t, bson.TypeNull, filter.Lookup("project").Type)
+	})
+
+	mt.Run("invalid ObjectID format", func(mt *mtest.T) {
+		tk := repository.NewTaskRepository(mt.Client.Database("test"))
+		err := tk.PatchTask(context.TODO(), "user1", "invalid_id", models.TaskChanges{})
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+}
+
+func TestFindTaskIDs(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns owned IDs", func(mt *mtest.T) {
+		owned := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch, bson.D{{Key: "_id", Value: owned}}))
+
+		tk := repository.NewTaskRepository(mt.Client.Database("test"))
+		found, err := tk.FindTaskIDs(context.TODO(), "user1", []string{owned.Hex(), primitive.NewObjectID().Hex(), "invalid_id"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{owned.Hex()}, found)
+	})
+
+	mt.Run("only malformed IDs", func(mt *mtest.T) {
+		tk := repository.NewTaskRepository(mt.Client.Database("test"))
+		found, err := tk.FindTaskIDs(context.TODO(), "user1", []string{"invalid_id"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
+
+func TestBulkWriteTasks(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	task := models.CreateTask{Title: "Task", Description: "Description"}
+
+	mt.Run("all writes succeed", func(mt *mtest.T) {
+		taskID := primitive.NewObjectID().Hex()
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}),
+		)
+
+		tk := repository.NewTaskRepository(mt.Client.Database("test"))
+		results, err := tk.BulkWriteTasks(context.TODO(), "user1", []models.TaskWrite{
+			{Op: models.BulkCreate, Task: task},
+			{Op: models.BulkDelete, ID: taskID},
+		}, true)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.True(t, primitive.IsValidObjectID(results[0].ID))
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, taskID, results[1].ID)
+		assert.NoError(t, results[1].Err)
+	})
+
+	mt.Run("ordered write error skips the rest", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}),
+			mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 121, Message: "document failed validation"}),
+		)
+
+		tk := repository.NewTaskRepository(mt.Client.Database("test"))
+		results, err := tk.BulkWriteTasks(context.TODO(), "user1", []models.TaskWrite{
+			{Op: models.BulkCreate, Task: task},
+			{Op: models.BulkUpdate, ID: primitive.NewObjectID().Hex(), Task: task},
+			{Op: models.BulkDelete, ID: primitive.NewObjectID().Hex()},
+		}, true)
+
+		assert.NoError(t, err)
+		assert.NoError(t, results[0].Err)
+		assert.EqualError(t, results[1].Err, "document failed validation")
+		assert.ErrorIs(t, results[2].Err, interfaces.ErrNotExecuted)
+	})
+
+	mt.Run("writes that matched no task", func(mt *mtest.T) {
+		updated, missing, deleted := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}),
+			mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch, bson.D{{Key: "_id", Value: updated}}),
+		)
+
+		tk := repository.NewTaskRepository(mt.Client.Database("test"))
+		results, err := tk.BulkWriteTasks(context.TODO(), "user1", []models.TaskWrite{
+			{Op: models.BulkUpdate, ID: updated.Hex(), Task: task},
+			{Op: models.BulkUpdate, ID: missing.Hex(), Task: task},
+			{Op: models.BulkDelete, ID: deleted.Hex()},
+		}, true)
+
+		assert.NoError(t, err)
+		assert.NoError(t, results[0].Err)
+		assert.ErrorIs(t, results[1].Err, interfaces.ErrNotFound)
+		assert.ErrorIs(t, results[2].Err, interfaces.ErrNotFound)
+	})
+
+	mt.Run("command error", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "command failed"}))
+
+		tk := repository.NewTaskRepository(mt.Client.Database("test"))
+		_, err := tk.BulkWriteTasks(context.TODO(), "user1", []models.TaskWrite{{Op: models.BulkCreate, Task: task}}, false)
+
 		assert.Error(t, err)
 	})
 }
@@ -307,10 +436,10 @@ func TestDeleteTask(t *testing.T) {
 		taskID := primitive.NewObjectID().Hex()
 		userID := primitive.NewObjectID().Hex()
 
-		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}})
 
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		err := tk.DeleteTask(userID, taskID)
+		err := tk.DeleteTask(context.TODO(), userID, taskID)
 
 		assert.NoError(t, err)
 	})
@@ -319,17 +448,17 @@ func TestDeleteTask(t *testing.T) {
 		taskID := primitive.NewObjectID().Hex()
 		userID := primitive.NewObjectID().Hex()
 
-		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}})
 
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		err := tk.DeleteTask(userID, taskID)
+		err := tk.DeleteTask(context.TODO(), userID, taskID)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
 	})
 
 	mt.Run("invalid ObjectID format", func(mt *mtest.T) {
 		tk := repository.NewTaskRepository(mt.Client.Database("test"))
-		err := tk.DeleteTask("6705824f80a09eb0313f0e42", "invalid_id")
-		assert.Error(t, err)
+		err := tk.DeleteTask(context.TODO(), "6705824f80a09eb0313f0e42", "invalid_id")
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
 	})
 }
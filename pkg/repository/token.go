@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	"taskmanagementapi/pkg/utils/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TokenRepository struct {
+	TokenCollection *mongo.Collection
+}
+
+func NewTokenRepository(db *mongo.Database) interfaces.TokenRepository {
+	return &TokenRepository{TokenCollection: db.Collection("tokens")}
+}
+
+func (tr *TokenRepository) InsertToken(ctx context.Context, token models.NewToken) (string, error) {
+	newToken := bson.M{
+		"user_id":    token.UserID,
+		"name":       token.Name,
+		"token_hash": token.TokenHash,
+		"scopes":     token.Scopes,
+		"created_at": token.CreatedAt,
+	}
+	if token.ExpiresAt != nil {
+		newToken["expires_at"] = *token.ExpiresAt
+	}
+	result, err := tr.TokenCollection.InsertOne(ctx, newToken)
+	if err != nil {
+		return "", err
+	}
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", errors.New("invalid inserted id")
+	}
+	return id.Hex(), nil
+}
+
+func (tr *TokenRepository) GetTokens(ctx context.Context, userID string) ([]models.TokenDetails, error) {
+	cursor, err := tr.TokenCollection.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tokens []models.TokenDetails
+	for cursor.Next(ctx) {
+		var token models.TokenDetails
+		if err := cursor.Decode(&token); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, cursor.Err()
+}
+
+func (tr *TokenRepository) DeleteToken(ctx context.Context, userID, tokenID string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(tokenID)
+	if err != nil {
+		return false, errors.New("invalid ObjectID format")
+	}
+	filter := bson.M{
+		"user_id": userID,
+		"_id":     objID,
+	}
+	result, err := tr.TokenCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (tr *TokenRepository) FindTokenByHash(ctx context.Context, hash string) (models.TokenAuth, error) {
+	var token models.TokenAuth
+	err := tr.TokenCollection.FindOne(ctx, bson.M{"token_hash": hash}).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.TokenAuth{}, nil
+		}
+		return models.TokenAuth{}, err
+	}
+	return token, nil
+}
+
+func (tr *TokenRepository) DeleteTokensByUser(ctx context.Context, userID string) error {
+	_, err := tr.TokenCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
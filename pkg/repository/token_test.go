@@ -0,0 +1,106 @@
+package repository_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/repository"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestInsertToken(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("successfully insert token", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		tr := repository.NewTokenRepository(mt.Client.Database("test"))
+
+		id, err := tr.InsertToken(context.TODO(), models.NewToken{
+			UserID:    "user1",
+			Name:      "ci",
+			TokenHash: "hash",
+			Scopes:    []string{models.ScopeTasksRead},
+			CreatedAt: time.Now(),
+		})
+
+		assert.NoError(t, err)
+		_, err = primitive.ObjectIDFromHex(id)
+		assert.NoError(t, err)
+	})
+
+	mt.Run("error during InsertOne operation", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+			Code:    11000,
+			Message: "duplicate key error",
+		}))
+		tr := repository.NewTokenRepository(mt.Client.Database("test"))
+
+		_, err := tr.InsertToken(context.TODO(), models.NewToken{UserID: "user1", TokenHash: "hash"})
+
+		assert.EqualError(t, err, "duplicate key error")
+	})
+}
+
+func TestDeleteToken(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("token deleted", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}})
+		tr := repository.NewTokenRepository(mt.Client.Database("test"))
+
+		deleted, err := tr.DeleteToken(context.TODO(), "user1", primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+	})
+
+	mt.Run("token not found", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}})
+		tr := repository.NewTokenRepository(mt.Client.Database("test"))
+
+		deleted, err := tr.DeleteToken(context.TODO(), "user1", primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+	})
+
+	mt.Run("invalid ObjectID format", func(mt *mtest.T) {
+		tr := repository.NewTokenRepository(mt.Client.Database("test"))
+
+		_, err := tr.DeleteToken(context.TODO(), "user1", "invalid_id")
+
+		assert.EqualError(t, err, "invalid ObjectID format")
+	})
+}
+
+func TestFindTokenByHash(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("token found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.tokens", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "user_id", Value: "user1"},
+			{Key: "scopes", Value: bson.A{models.ScopeTasksRead}},
+		}))
+		tr := repository.NewTokenRepository(mt.Client.Database("test"))
+
+		auth, err := tr.FindTokenByHash(context.TODO(), "hash")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "user1", auth.UserID)
+		assert.Equal(t, []string{models.ScopeTasksRead}, auth.Scopes)
+		assert.Nil(t, auth.ExpiresAt)
+	})
+
+	mt.Run("token not found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tokens", mtest.FirstBatch))
+		tr := repository.NewTokenRepository(mt.Client.Database("test"))
+
+		auth, err := tr.FindTokenByHash(context.TODO(), "hash")
+
+		assert.NoError(t, err)
+		assert.Empty(t, auth.UserID)
+	})
+}
@@ -2,28 +2,34 @@ package repository
 
 import (
 	"context"
-	"taskmanagementapi/pkg/config"
+	"errors"
+	"regexp"
+	"taskmanagementapi/pkg/helper"
+	"taskmanagementapi/pkg/keyset"
 	interfaces "taskmanagementapi/pkg/repository/interface"
 	"taskmanagementapi/pkg/utils/models"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UserRepository struct {
 	UserCollection *mongo.Collection
+	KeySet         *keyset.KeySet
 }
 
-func NewUserRepository(db *mongo.Database) interfaces.UserRepository {
-	return &UserRepository{UserCollection: db.Collection("users")}
+func NewUserRepository(db *mongo.Database, keySet *keyset.KeySet) interfaces.UserRepository {
+	return &UserRepository{UserCollection: db.Collection("users"), KeySet: keySet}
 }
 
-func (ur *UserRepository) CheckUserExistsByEmail(email string) (bool, error) {
+func (ur *UserRepository) CheckUserExistsByEmail(ctx context.Context, email string) (bool, error) {
 	filter := bson.M{"email": email}
 	var result bson.M
-	err := ur.UserCollection.FindOne(context.TODO(), filter).Decode(&result)
+	err := ur.UserCollection.FindOne(ctx, filter).Decode(&result)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return false, nil
@@ -33,23 +39,24 @@ func (ur *UserRepository) CheckUserExistsByEmail(email string) (bool, error) {
 	return true, nil
 }
 
-func (ur *UserRepository) UserSignUp(user models.UserSignup) error {
+func (ur *UserRepository) UserSignUp(ctx context.Context, user models.UserSignup) error {
 	newUser := bson.M{
 		"name":     user.Name,
 		"email":    user.Email,
 		"password": user.Password,
+		"role":     models.RoleUser,
 	}
-	_, err := ur.UserCollection.InsertOne(context.TODO(), newUser)
+	_, err := ur.UserCollection.InsertOne(ctx, newUser)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (ur *UserRepository) FindUserDetailsByEmail(email string) (models.UserDetails, error) {
+func (ur *UserRepository) FindUserDetailsByEmail(ctx context.Context, email string) (models.UserDetails, error) {
 	filter := bson.M{"email": email}
 	var userDetails models.UserDetails
-	err := ur.UserCollection.FindOne(context.TODO(), filter).Decode(&userDetails)
+	err := ur.UserCollection.FindOne(ctx, filter).Decode(&userDetails)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return models.UserDetails{}, nil
@@ -59,37 +66,200 @@ func (ur *UserRepository) FindUserDetailsByEmail(email string) (models.UserDetai
 	return userDetails, nil
 }
 
-//GenerateJWTToken
-type AuthUserClaims struct {
-	Id    string
-	Email string
-	jwt.StandardClaims
+// GenerateJwtToken signs a token for user bound to the session jti and
+// returns it together with its expiry.
+func (ur *UserRepository) GenerateJwtToken(user models.UserDetails, jti string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ur.KeySet.TokenTTL())
+	claims := &helper.AuthUserClaims{
+		Id:    user.ID,
+		Email: user.Email,
+		Role:  user.Role,
+		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			ExpiresAt: expiresAt.Unix(),
+			IssuedAt:  now.Unix(),
+		},
+	}
+	tokenString, err := ur.KeySet.Sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return tokenString, expiresAt, nil
 }
 
-func (ur *UserRepository) GenerateJwtToken(user models.UserDetails) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
-	tokenString, err := GenerateTokenUser(user.ID, user.Email, expirationTime)
+func (ur *UserRepository) FindUserByID(ctx context.Context, userID string) (models.UserDetails, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		return "", err
+		return models.UserDetails{}, errors.New("invalid ObjectID format")
 	}
-	return tokenString, nil
+	var userDetails models.UserDetails
+	err = ur.UserCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&userDetails)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.UserDetails{}, nil
+		}
+		return models.UserDetails{}, err
+	}
+	return userDetails, nil
 }
 
-func GenerateTokenUser(userID string, email string, expirationTime time.Time) (string, error) {
-	cfg, _ := config.LoadConfig()
-	claims := &AuthUserClaims{
-		Id:    userID,
-		Email: email,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
-			IssuedAt:  time.Now().Unix(),
+func (ur *UserRepository) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.UserSummary, error) {
+	query := bson.M{}
+	if filter.Search != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(filter.Search), Options: "i"}
+		query["$or"] = bson.A{
+			bson.M{"name": pattern},
+			bson.M{"email": pattern},
+		}
+	}
+	opts := options.Find().
+		SetProjection(bson.M{"password": 0}).
+		SetSort(bson.M{"_id": 1}).
+		SetSkip(int64((filter.Page - 1) * filter.Limit)).
+		SetLimit(int64(filter.Limit))
+	cursor, err := ur.UserCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.UserSummary
+	for cursor.Next(ctx) {
+		var user models.UserSummary
+		if err := cursor.Decode(&user); err != nil {
+			return nil, err
+		}
+		if user.Role == "" {
+			user.Role = models.RoleUser
+		}
+		users = append(users, user)
+	}
+	return users, cursor.Err()
+}
+
+func (ur *UserRepository) updateUser(ctx context.Context, userID string, set bson.M) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, errors.New("invalid ObjectID format")
+	}
+	result, err := ur.UserCollection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": set})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (ur *UserRepository) SetUserDisabled(ctx context.Context, userID string, disabled bool) (bool, error) {
+	return ur.updateUser(ctx, userID, bson.M{"disabled": disabled})
+}
+
+// UpdatePassword also moves tokens_valid_after forward so that every JWT
+// issued before the change is rejected.
+func (ur *UserRepository) UpdatePassword(ctx context.Context, userID, password string) (bool, error) {
+	return ur.updateUser(ctx, userID, bson.M{"password": password, "tokens_valid_after": time.Now().UTC()})
+}
+
+// RehashPassword swaps in an upgraded hash of the same password. Sessions are
+// left alone, and the update is skipped if the password changed since oldHash
+// was read.
+func (ur *UserRepository) RehashPassword(ctx context.Context, userID, oldHash, newHash string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, errors.New("invalid ObjectID format")
+	}
+	filter := bson.M{"_id": objID, "password": oldHash}
+	result, err := ur.UserCollection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"password": newHash}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (ur *UserRepository) UpdateName(ctx context.Context, userID, name string) (bool, error) {
+	return ur.updateUser(ctx, userID, bson.M{"name": name})
+}
+
+func (ur *UserRepository) SetPendingEmail(ctx context.Context, userID string, verification models.EmailVerification) (bool, error) {
+	return ur.updateUser(ctx, userID, bson.M{"email_verification": verification})
+}
+
+// ConfirmEmail replaces the email with the pending one when tokenHash matches
+// an unexpired verification, and marks it verified.
+func (ur *UserRepository) ConfirmEmail(ctx context.Context, userID, tokenHash string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, errors.New("invalid ObjectID format")
+	}
+	filter := bson.M{
+		"_id":                           objID,
+		"email_verification.token_hash": tokenHash,
+		"email_verification.expires_at": bson.M{"$gt": time.Now().UTC()},
+	}
+	update := bson.A{
+		bson.M{"$set": bson.M{"email": "$email_verification.email", "email_verified": true}},
+		bson.M{"$unset": "email_verification"},
+	}
+	result, err := ur.UserCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (ur *UserRepository) UpdateRole(ctx context.Context, userID, role string) (bool, error) {
+	return ur.updateUser(ctx, userID, bson.M{"role": role})
+}
+
+func (ur *UserRepository) FindUserByIdentity(ctx context.Context, issuer, subject string) (models.UserDetails, error) {
+	filter := bson.M{"identities": bson.M{"$elemMatch": bson.M{"issuer": issuer, "subject": subject}}}
+	var userDetails models.UserDetails
+	err := ur.UserCollection.FindOne(ctx, filter).Decode(&userDetails)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.UserDetails{}, nil
+		}
+		return models.UserDetails{}, err
+	}
+	return userDetails, nil
+}
+
+func (ur *UserRepository) LinkIdentity(ctx context.Context, userID, issuer, subject string) error {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return errors.New("invalid ObjectID format")
+	}
+	update := bson.M{
+		"$addToSet": bson.M{
+			"identities": bson.M{"issuer": issuer, "subject": subject},
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(cfg.JwtSecretKey))
+	_, err = ur.UserCollection.UpdateOne(ctx, bson.M{"_id": objID}, update)
 	if err != nil {
-		return "", err
+		return err
 	}
-	return tokenString, nil
+	return nil
 }
 
+func (ur *UserRepository) CreateOIDCUser(ctx context.Context, user models.OIDCUser) (string, error) {
+	newUser := bson.M{
+		"name":     user.Name,
+		"email":    user.Email,
+		"password": "",
+		"role":     models.RoleUser,
+		// The provider verified the email before the account was created.
+		"email_verified": true,
+		"identities": bson.A{
+			bson.M{"issuer": user.Issuer, "subject": user.Subject},
+		},
+	}
+	result, err := ur.UserCollection.InsertOne(ctx, newUser)
+	if err != nil {
+		return "", err
+	}
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", errors.New("invalid inserted id")
+	}
+	return id.Hex(), nil
+}
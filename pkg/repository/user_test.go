@@ -1,6 +1,7 @@
 package repository_test
 
 import (
+	"context"
 	"testing"
 
 	"taskmanagementapi/pkg/repository"
@@ -18,8 +19,8 @@ func TestCheckUserExistsByEmail(t *testing.T) {
 			{Key: "_id", Value: "6705824f80a09eb0313f0e42"},
 			{Key: "email", Value: "test@example.com"},
 		}))
-		ur := repository.NewUserRepository(mt.Client.Database("test"))
-		result, err := ur.CheckUserExistsByEmail("test@example.com")
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+		result, err := ur.CheckUserExistsByEmail(context.TODO(), "test@example.com")
 		assert.True(t, result)
 		assert.NoError(t, err)
 	})
@@ -27,9 +28,9 @@ func TestCheckUserExistsByEmail(t *testing.T) {
 	mt.Run("user not found", func(mt *mtest.T) {
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.users", mtest.FirstBatch))
 
-		ur := repository.NewUserRepository(mt.Client.Database("test"))
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
 
-		result, err := ur.CheckUserExistsByEmail("nonexistent@example.com")
+		result, err := ur.CheckUserExistsByEmail(context.TODO(), "nonexistent@example.com")
 
 		assert.False(t, result)
 		assert.NoError(t, err)
@@ -41,9 +42,9 @@ func TestCheckUserExistsByEmail(t *testing.T) {
 			Message: "some error",
 		}))
 
-		ur := repository.NewUserRepository(mt.Client.Database("test"))
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
 
-		result, err := ur.CheckUserExistsByEmail("error@example.com")
+		result, err := ur.CheckUserExistsByEmail(context.TODO(), "error@example.com")
 
 		assert.False(t, result)
 		assert.EqualError(t, err, "some error")
@@ -54,13 +55,13 @@ func TestUserSignUp(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 	mt.Run("successful user sign-up", func(mt *mtest.T) {
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
-		ur := repository.NewUserRepository(mt.Client.Database("test"))
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
 		sampleUser := models.UserSignup{
 			Name:     "John Doe",
 			Email:    "johndoe@example.com",
 			Password: "password123",
 		}
-		err := ur.UserSignUp(sampleUser)
+		err := ur.UserSignUp(context.TODO(), sampleUser)
 		assert.NoError(t, err)
 	})
 
@@ -70,13 +71,13 @@ func TestUserSignUp(t *testing.T) {
 			Message: "insertion error",
 		}))
 
-		ur := repository.NewUserRepository(mt.Client.Database("test"))
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
 		sampleUser := models.UserSignup{
 			Name:     "John Doe",
 			Email:    "johndoe@example.com",
 			Password: "password123",
 		}
-		err := ur.UserSignUp(sampleUser)
+		err := ur.UserSignUp(context.TODO(), sampleUser)
 		assert.Error(t, err)
 		assert.EqualError(t, err, "insertion error")
 	})
@@ -92,8 +93,8 @@ func TestFindUserDetailsByEmail(t *testing.T) {
             {Key: "password", Value: "hashed_password"},
         }))
 
-        ur := repository.NewUserRepository(mt.Client.Database("test"))
-        userDetails, err := ur.FindUserDetailsByEmail("johndoe@example.com")
+        ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+        userDetails, err := ur.FindUserDetailsByEmail(context.TODO(), "johndoe@example.com")
         expectedUser := models.UserDetails{
             ID:       "6705824f80a09eb0313f0e42",
             Name:     "John Doe",
@@ -106,8 +107,8 @@ func TestFindUserDetailsByEmail(t *testing.T) {
 
     mt.Run("user not found by email", func(mt *mtest.T) {
         mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.users", mtest.FirstBatch))
-        ur := repository.NewUserRepository(mt.Client.Database("test"))
-        userDetails, err := ur.FindUserDetailsByEmail("nonexistent@example.com")
+        ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+        userDetails, err := ur.FindUserDetailsByEmail(context.TODO(), "nonexistent@example.com")
         assert.NoError(t, err)
         assert.Equal(t, models.UserDetails{}, userDetails)
     })
@@ -117,9 +118,104 @@ func TestFindUserDetailsByEmail(t *testing.T) {
             Code:    11000,
             Message: "some error",
         }))
-        ur := repository.NewUserRepository(mt.Client.Database("test"))
-        userDetails, err := ur.FindUserDetailsByEmail("error@example.com")
+        ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+        userDetails, err := ur.FindUserDetailsByEmail(context.TODO(), "error@example.com")
         assert.EqualError(t, err, "some error")
         assert.Equal(t, models.UserDetails{}, userDetails)
     })
-}
\ No newline at end of file
+}
+func TestListUsers(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("defaults missing role", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(1, "test.users", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: "6705824f80a09eb0313f0e42"},
+				{Key: "name", Value: "John Doe"},
+				{Key: "email", Value: "johndoe@example.com"},
+			}),
+			mtest.CreateCursorResponse(0, "test.users", mtest.NextBatch),
+		)
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+
+		users, err := ur.ListUsers(context.TODO(), models.UserFilter{Search: "john", Page: 1, Limit: 20})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.UserSummary{{
+			ID:    "6705824f80a09eb0313f0e42",
+			Name:  "John Doe",
+			Email: "johndoe@example.com",
+			Role:  models.RoleUser,
+		}}, users)
+	})
+}
+
+func TestSetUserDisabled(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("user matched", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+
+		found, err := ur.SetUserDisabled(context.TODO(), "6705824f80a09eb0313f0e42", true)
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+	})
+
+	mt.Run("invalid ObjectID format", func(mt *mtest.T) {
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+
+		_, err := ur.SetUserDisabled(context.TODO(), "invalid_id", true)
+
+		assert.EqualError(t, err, "invalid ObjectID format")
+	})
+}
+
+func TestConfirmEmail(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("token matched", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+
+		confirmed, err := ur.ConfirmEmail(context.TODO(), "6705824f80a09eb0313f0e42", "hash")
+
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+		set := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u").Array().Index(0).Value().Document().Lookup("$set").Document()
+		assert.True(t, set.Lookup("email_verified").Boolean())
+	})
+
+	mt.Run("token not matched", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}})
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+
+		confirmed, err := ur.ConfirmEmail(context.TODO(), "6705824f80a09eb0313f0e42", "hash")
+
+		assert.NoError(t, err)
+		assert.False(t, confirmed)
+	})
+}
+
+func TestRehashPassword(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("hash unchanged", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+
+		updated, err := ur.RehashPassword(context.TODO(), "6705824f80a09eb0313f0e42", "old", "new")
+
+		assert.NoError(t, err)
+		assert.True(t, updated)
+		filter := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("q").Document()
+		assert.Equal(t, "old", filter.Lookup("password").StringValue())
+	})
+
+	mt.Run("password changed meanwhile", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}})
+		ur := repository.NewUserRepository(mt.Client.Database("test"), nil)
+
+		updated, err := ur.RehashPassword(context.TODO(), "6705824f80a09eb0313f0e42", "old", "new")
+
+		assert.NoError(t, err)
+		assert.False(t, updated)
+	})
+}
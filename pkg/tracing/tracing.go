@@ -0,0 +1,117 @@
+// Package tracing sets up OpenTelemetry and holds the helpers the API,
+// usecase and database layers use to create spans.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"taskmanagementapi/pkg/config"
+	services "taskmanagementapi/pkg/usecase/interface"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serviceName = "taskmanagementapi"
+
+// Setup installs the W3C trace context propagator and, unless
+// TRACING_EXPORTER is none, a tracer provider that exports to OTLP over HTTP
+// or writes spans as JSON to stdout or a file. The returned function flushes
+// pending spans and must be called before the process exits.
+func Setup(ctx context.Context, cfg config.Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	closeOutput := func() error { return nil }
+	switch cfg.TracingExporter {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		otlp, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.TracingOTLPEndpoint))
+		if err != nil {
+			return nil, err
+		}
+		exporter = otlp
+	case "stdout":
+		var w io.Writer = os.Stdout
+		if cfg.TracingStdoutFile != "" {
+			file, err := os.OpenFile(cfg.TracingStdoutFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, err
+			}
+			w, closeOutput = file, file.Close
+		}
+		stdout, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, err
+		}
+		exporter = stdout
+	default:
+		return nil, fmt.Errorf("unknown TRACING_EXPORTER %q", cfg.TracingExporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return func(ctx context.Context) error {
+		return errors.Join(provider.Shutdown(ctx), closeOutput())
+	}, nil
+}
+
+// Start starts a span that is a child of any span in ctx.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(serviceName).Start(ctx, name, opts...)
+}
+
+// End records err on span and ends it. Only unexpected errors mark the span
+// as failed; typed usecase errors such as not found are the caller's fault.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		var typed *services.Error
+		if !errors.As(err, &typed) {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+// StartCommand starts a client span for a MongoDB command. Commands issued
+// outside a traced request, such as by background jobs, are not traced, and
+// ok is false.
+func StartCommand(ctx context.Context, database, collection, command string) (span trace.Span, ok bool) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil, false
+	}
+	name := command
+	if collection != "" {
+		name += " " + collection
+	}
+	_, span = Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "mongodb"),
+			attribute.String("db.namespace", database),
+			attribute.String("db.collection.name", collection),
+			attribute.String("db.operation.name", command),
+		),
+	)
+	return span, true
+}
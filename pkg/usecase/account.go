@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/password"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+type accountUseCase struct {
+	userRepository       interfaces.UserRepository
+	taskRepository       interfaces.TaskRepository
+	tokenRepository      interfaces.TokenRepository
+	preferenceRepository interfaces.PreferenceRepository
+	accountRepository    interfaces.AccountRepository
+	hasher               *password.Hasher
+}
+
+func NewAccountUseCase(userRepository interfaces.UserRepository, taskRepository interfaces.TaskRepository, tokenRepository interfaces.TokenRepository, preferenceRepository interfaces.PreferenceRepository, accountRepository interfaces.AccountRepository, hasher *password.Hasher) services.AccountUseCase {
+	return &accountUseCase{
+		userRepository:       userRepository,
+		taskRepository:       taskRepository,
+		tokenRepository:      tokenRepository,
+		preferenceRepository: preferenceRepository,
+		accountRepository:    accountRepository,
+		hasher:               hasher,
+	}
+}
+
+func (au *accountUseCase) ExportData(ctx context.Context, userID string) (models.UserExport, error) {
+	user, err := au.userRepository.FindUserByID(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, errors.New("error in find user details")
+	}
+	if user.ID == "" {
+		return models.UserExport{}, errUserNotFound
+	}
+	tasks, err := au.taskRepository.GetTasks(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, errors.New("error from get tasks")
+	}
+	tokens, err := au.tokenRepository.GetTokens(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, errors.New("error from get tokens")
+	}
+	preferences, err := loadPreferences(ctx, au.preferenceRepository, userID)
+	if err != nil {
+		return models.UserExport{}, err
+	}
+	if tasks == nil {
+		tasks = []models.TaskDetails{}
+	}
+	if tokens == nil {
+		tokens = []models.TokenDetails{}
+	}
+	role := user.Role
+	if role == "" {
+		role = models.RoleUser
+	}
+	return models.UserExport{
+		ExportedAt: time.Now().UTC(),
+		Profile: models.UserProfile{
+			ID:    user.ID,
+			Name:  user.Name,
+			Email: user.Email,
+			Role:  role,
+		},
+		Preferences: preferences,
+		Tasks:       tasks,
+		Tokens:      tokens,
+	}, nil
+}
+
+// DeleteAccount asks for the password again on accounts that have one;
+// accounts created through OIDC only have the session to go by.
+func (au *accountUseCase) DeleteAccount(ctx context.Context, userID string, confirm models.DeleteAccount) error {
+	user, err := au.userRepository.FindUserByID(ctx, userID)
+	if err != nil {
+		return errors.New("error in find user details")
+	}
+	if user.ID == "" {
+		return errUserNotFound
+	}
+	if user.Password != "" {
+		if match, _, err := au.hasher.Verify(confirm.Password, user.Password); err != nil || !match {
+			return services.ErrInvalidCredentials
+		}
+	}
+	deleted, err := au.accountRepository.DeleteAccount(ctx, userID, user.Email)
+	if err != nil {
+		return errors.New("error from delete account")
+	}
+	if !deleted {
+		return errUserNotFound
+	}
+	return nil
+}
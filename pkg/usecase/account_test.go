@@ -0,0 +1,105 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/usecase"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	mockRepository "taskmanagementapi/pkg/repository/mock"
+	services "taskmanagementapi/pkg/usecase/interface"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func Test_ExportData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	taskRepo := mockRepository.NewMockTaskRepository(ctrl)
+	tokenRepo := mockRepository.NewMockTokenRepository(ctrl)
+	preferenceRepo := mockRepository.NewMockPreferenceRepository(ctrl)
+	accountUseCase := usecase.NewAccountUseCase(userRepo, taskRepo, tokenRepo, preferenceRepo, nil, nil)
+
+	userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(models.UserDetails{ID: "user1", Name: "Akhil", Email: "akhil@example.com", Password: "hash"}, nil).Times(1)
+	taskRepo.EXPECT().GetTasks(gomock.Any(), "user1").Return([]models.TaskDetails{{ID: "task1", Title: "Title"}}, nil).Times(1)
+	tokenRepo.EXPECT().GetTokens(gomock.Any(), "user1").Return(nil, nil).Times(1)
+	preferenceRepo.EXPECT().GetPreferences(gomock.Any(), "user1").Return(models.Preferences{}, nil).Times(1)
+
+	export, err := accountUseCase.ExportData(context.Background(), "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.UserProfile{ID: "user1", Name: "Akhil", Email: "akhil@example.com", Role: models.RoleUser}, export.Profile)
+	assert.Equal(t, []models.TaskDetails{{ID: "task1", Title: "Title"}}, export.Tasks)
+	assert.Equal(t, []models.TokenDetails{}, export.Tokens)
+	assert.Equal(t, models.DefaultPreferences(), export.Preferences)
+	assert.False(t, export.ExportedAt.IsZero())
+
+	taskRepo.EXPECT().GetTasks(gomock.Any(), "user1").Return(nil, errors.New("db error")).Times(1)
+	userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(models.UserDetails{ID: "user1"}, nil).Times(1)
+	_, err = accountUseCase.ExportData(context.Background(), "user1")
+	assert.EqualError(t, err, "error from get tasks")
+}
+
+func Test_DeleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	accountRepo := mockRepository.NewMockAccountRepository(ctrl)
+	accountUseCase := usecase.NewAccountUseCase(userRepo, nil, nil, nil, accountRepo, testHasher)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	user := models.UserDetails{ID: "user1", Email: "akhil@example.com", Password: string(hash)}
+
+	testData := map[string]struct {
+		user     models.UserDetails
+		password string
+		stub     func(*mockRepository.MockAccountRepository)
+		wantErr  error
+	}{
+		"success": {
+			user:     user,
+			password: "password",
+			stub: func(repo *mockRepository.MockAccountRepository) {
+				repo.EXPECT().DeleteAccount(gomock.Any(), "user1", "akhil@example.com").Return(true, nil).Times(1)
+			},
+		},
+		"wrong password": {
+			user:     user,
+			password: "wrong",
+			stub:     func(repo *mockRepository.MockAccountRepository) {},
+			wantErr:  services.ErrInvalidCredentials,
+		},
+		"oidc account without password": {
+			user: models.UserDetails{ID: "user1", Email: "akhil@example.com"},
+			stub: func(repo *mockRepository.MockAccountRepository) {
+				repo.EXPECT().DeleteAccount(gomock.Any(), "user1", "akhil@example.com").Return(true, nil).Times(1)
+			},
+		},
+		"user does not exist": {
+			user:    models.UserDetails{},
+			stub:    func(repo *mockRepository.MockAccountRepository) {},
+			wantErr: services.NotFound("user_not_found", "user doesn't exist"),
+		},
+		"transaction failed": {
+			user:     user,
+			password: "password",
+			stub: func(repo *mockRepository.MockAccountRepository) {
+				repo.EXPECT().DeleteAccount(gomock.Any(), "user1", "akhil@example.com").Return(false, errors.New("db error")).Times(1)
+			},
+			wantErr: errors.New("error from delete account"),
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(test.user, nil).Times(1)
+			test.stub(accountRepo)
+			err := accountUseCase.DeleteAccount(context.Background(), "user1", models.DeleteAccount{Password: test.password})
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/logging"
+	"taskmanagementapi/pkg/password"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+type adminUseCase struct {
+	userRepository    interfaces.UserRepository
+	taskRepository    interfaces.TaskRepository
+	tokenRepository   interfaces.TokenRepository
+	sessionRepository interfaces.SessionRepository
+	auditRepository   interfaces.AuditRepository
+	passwordPolicy    *password.Policy
+	hasher            *password.Hasher
+}
+
+func NewAdminUseCase(userRepository interfaces.UserRepository, taskRepository interfaces.TaskRepository, tokenRepository interfaces.TokenRepository, sessionRepository interfaces.SessionRepository, auditRepository interfaces.AuditRepository, passwordPolicy *password.Policy, hasher *password.Hasher) services.AdminUseCase {
+	return &adminUseCase{
+		userRepository:    userRepository,
+		taskRepository:    taskRepository,
+		tokenRepository:   tokenRepository,
+		sessionRepository: sessionRepository,
+		auditRepository:   auditRepository,
+		passwordPolicy:    passwordPolicy,
+		hasher:            hasher,
+	}
+}
+
+// audit records a read once it has succeeded. Data an admin reads is only
+// returned once its audit entry is written.
+func (ad *adminUseCase) audit(ctx context.Context, actorID, action, targetID, details string) error {
+	_, err := ad.auditRepository.InsertAuditLog(ctx, models.AuditLog{
+		ActorID:   actorID,
+		Action:    action,
+		TargetID:  targetID,
+		Details:   details,
+		Outcome:   models.AuditSucceeded,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return errors.New("error from audit log")
+	}
+	return nil
+}
+
+// audited runs a change between writing its audit entry and recording how it
+// ended. The entry comes first, so that nothing is changed without one. If
+// the outcome cannot be recorded the entry stays pending, and the change is
+// still reported as it happened.
+func (ad *adminUseCase) audited(ctx context.Context, actorID, action, targetID, details string, change func() error) error {
+	id, err := ad.auditRepository.InsertAuditLog(ctx, models.AuditLog{
+		ActorID:   actorID,
+		Action:    action,
+		TargetID:  targetID,
+		Details:   details,
+		Outcome:   models.AuditPending,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return errors.New("error from audit log")
+	}
+	err = change()
+	outcome := models.AuditSucceeded
+	if err != nil {
+		outcome = models.AuditFailed
+	}
+	if outcomeErr := ad.auditRepository.SetAuditOutcome(ctx, id, outcome); outcomeErr != nil {
+		logging.FromContext(ctx).Error("record audit outcome", "audit_id", id, "outcome", outcome, "error", outcomeErr)
+	}
+	return err
+}
+
+func (ad *adminUseCase) ListUsers(ctx context.Context, actorID string, filter models.UserFilter) ([]models.UserSummary, error) {
+	users, err := ad.userRepository.ListUsers(ctx, filter)
+	if err != nil {
+		return []models.UserSummary{}, errors.New("error from list users")
+	}
+	if err := ad.audit(ctx, actorID, models.AuditListUsers, "", filter.Search); err != nil {
+		return []models.UserSummary{}, err
+	}
+	return users, nil
+}
+
+func (ad *adminUseCase) SetUserDisabled(ctx context.Context, actorID, userID string, disabled bool) error {
+	if actorID == userID {
+		return errOwnAccountStatus
+	}
+	action := models.AuditEnableUser
+	if disabled {
+		action = models.AuditDisableUser
+	}
+	return ad.audited(ctx, actorID, action, userID, "", func() error {
+		found, err := ad.userRepository.SetUserDisabled(ctx, userID, disabled)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errUserNotFound
+		}
+		if disabled {
+			if err := ad.tokenRepository.DeleteTokensByUser(ctx, userID); err != nil {
+				return errors.New("error from revoke tokens")
+			}
+			if err := ad.sessionRepository.DeleteSessionsByUser(ctx, userID); err != nil {
+				return errors.New("error from revoke sessions")
+			}
+		}
+		return nil
+	})
+}
+
+func (ad *adminUseCase) ResetPassword(ctx context.Context, actorID, userID string, reset models.ResetPassword) error {
+	user, err := ad.userRepository.FindUserByID(ctx, userID)
+	if err != nil {
+		return errors.New("error in find user details")
+	}
+	if user.ID == "" {
+		return errUserNotFound
+	}
+	if err := checkPassword(ad.passwordPolicy, reset.Password, user.Email, user.Name); err != nil {
+		return err
+	}
+	hashPassword, err := ad.hasher.Hash(reset.Password)
+	if err != nil {
+		return errors.New("error in hashing password")
+	}
+	return ad.audited(ctx, actorID, models.AuditResetPassword, userID, "", func() error {
+		found, err := ad.userRepository.UpdatePassword(ctx, userID, hashPassword)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errUserNotFound
+		}
+		if err := ad.tokenRepository.DeleteTokensByUser(ctx, userID); err != nil {
+			return errors.New("error from revoke tokens")
+		}
+		if err := ad.sessionRepository.DeleteSessionsByUser(ctx, userID); err != nil {
+			return errors.New("error from revoke sessions")
+		}
+		return nil
+	})
+}
+
+func (ad *adminUseCase) UpdateRole(ctx context.Context, actorID, userID string, role models.UpdateRole) error {
+	if actorID == userID {
+		return errOwnRole
+	}
+	return ad.audited(ctx, actorID, models.AuditUpdateRole, userID, role.Role, func() error {
+		found, err := ad.userRepository.UpdateRole(ctx, userID, role.Role)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errUserNotFound
+		}
+		return nil
+	})
+}
+
+func (ad *adminUseCase) GetUserTasks(ctx context.Context, actorID, userID string) ([]models.TaskDetails, error) {
+	exist, err := ad.taskRepository.CheckUserIDExist(ctx, userID)
+	if err != nil {
+		return []models.TaskDetails{}, err
+	}
+	if !exist {
+		return []models.TaskDetails{}, errUserNotFound
+	}
+	tasks, err := ad.taskRepository.GetTasks(ctx, userID)
+	if err != nil {
+		return []models.TaskDetails{}, errors.New("error from get tasks")
+	}
+	if err := ad.audit(ctx, actorID, models.AuditViewTasks, userID, ""); err != nil {
+		return []models.TaskDetails{}, err
+	}
+	return tasks, nil
+}
+
+func (ad *adminUseCase) GetAuditLogs(ctx context.Context, page, limit int) ([]models.AuditLog, error) {
+	logs, err := ad.auditRepository.GetAuditLogs(ctx, page, limit)
+	if err != nil {
+		return []models.AuditLog{}, errors.New("error from get audit logs")
+	}
+	return logs, nil
+}
@@ -0,0 +1,153 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/password"
+	"taskmanagementapi/pkg/usecase"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	mockRepository "taskmanagementapi/pkg/repository/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetUserDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	taskRepo := mockRepository.NewMockTaskRepository(ctrl)
+	tokenRepo := mockRepository.NewMockTokenRepository(ctrl)
+	sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+	auditRepo := mockRepository.NewMockAuditRepository(ctrl)
+	adminUseCase := usecase.NewAdminUseCase(userRepo, taskRepo, tokenRepo, sessionRepo, auditRepo, nil, nil)
+
+	testData := map[string]struct {
+		actorID  string
+		disabled bool
+		stub     func()
+		wantErr  error
+	}{
+		"disable revokes tokens and sessions": {
+			actorID:  "admin",
+			disabled: true,
+			stub: func() {
+				auditRepo.EXPECT().InsertAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, log models.AuditLog) (string, error) {
+					assert.Equal(t, models.AuditDisableUser, log.Action)
+					assert.Equal(t, "user1", log.TargetID)
+					assert.Equal(t, models.AuditPending, log.Outcome)
+					return "audit1", nil
+				}).Times(1)
+				userRepo.EXPECT().SetUserDisabled(gomock.Any(), "user1", true).Return(true, nil).Times(1)
+				tokenRepo.EXPECT().DeleteTokensByUser(gomock.Any(), "user1").Return(nil).Times(1)
+				sessionRepo.EXPECT().DeleteSessionsByUser(gomock.Any(), "user1").Return(nil).Times(1)
+				auditRepo.EXPECT().SetAuditOutcome(gomock.Any(), "audit1", models.AuditSucceeded).Return(nil).Times(1)
+			},
+		},
+		"enable": {
+			actorID:  "admin",
+			disabled: false,
+			stub: func() {
+				auditRepo.EXPECT().InsertAuditLog(gomock.Any(), gomock.Any()).Return("audit1", nil).Times(1)
+				userRepo.EXPECT().SetUserDisabled(gomock.Any(), "user1", false).Return(true, nil).Times(1)
+				auditRepo.EXPECT().SetAuditOutcome(gomock.Any(), "audit1", models.AuditSucceeded).Return(nil).Times(1)
+			},
+		},
+		"user does not exist": {
+			actorID:  "admin",
+			disabled: true,
+			stub: func() {
+				auditRepo.EXPECT().InsertAuditLog(gomock.Any(), gomock.Any()).Return("audit1", nil).Times(1)
+				userRepo.EXPECT().SetUserDisabled(gomock.Any(), "user1", true).Return(false, nil).Times(1)
+				auditRepo.EXPECT().SetAuditOutcome(gomock.Any(), "audit1", models.AuditFailed).Return(nil).Times(1)
+			},
+			wantErr: services.NotFound("user_not_found", "user doesn't exist"),
+		},
+		"audit failure stops the change": {
+			actorID:  "admin",
+			disabled: false,
+			stub: func() {
+				auditRepo.EXPECT().InsertAuditLog(gomock.Any(), gomock.Any()).Return("", errors.New("db error")).Times(1)
+			},
+			wantErr: errors.New("error from audit log"),
+		},
+		"outcome not recorded": {
+			actorID:  "admin",
+			disabled: false,
+			stub: func() {
+				auditRepo.EXPECT().InsertAuditLog(gomock.Any(), gomock.Any()).Return("audit1", nil).Times(1)
+				userRepo.EXPECT().SetUserDisabled(gomock.Any(), "user1", false).Return(true, nil).Times(1)
+				auditRepo.EXPECT().SetAuditOutcome(gomock.Any(), "audit1", models.AuditSucceeded).Return(errors.New("db error")).Times(1)
+			},
+		},
+		"own account": {
+			actorID:  "user1",
+			disabled: true,
+			stub:     func() {},
+			wantErr:  services.Forbidden("own_account", "cannot change own account status"),
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			test.stub()
+			err := adminUseCase.SetUserDisabled(context.Background(), test.actorID, "user1", test.disabled)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+func Test_AdminGetUserTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	taskRepo := mockRepository.NewMockTaskRepository(ctrl)
+	tokenRepo := mockRepository.NewMockTokenRepository(ctrl)
+	sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+	auditRepo := mockRepository.NewMockAuditRepository(ctrl)
+	adminUseCase := usecase.NewAdminUseCase(userRepo, taskRepo, tokenRepo, sessionRepo, auditRepo, nil, nil)
+
+	taskRepo.EXPECT().CheckUserIDExist(gomock.Any(), "user1").Return(true, nil).Times(1)
+	auditRepo.EXPECT().InsertAuditLog(gomock.Any(), gomock.Any()).Return("audit1", nil).Times(1)
+	taskRepo.EXPECT().GetTasks(gomock.Any(), "user1").Return([]models.TaskDetails{{ID: "t1"}}, nil).Times(1)
+	tasks, err := adminUseCase.GetUserTasks(context.Background(), "admin", "user1")
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	taskRepo.EXPECT().CheckUserIDExist(gomock.Any(), "user2").Return(false, nil).Times(1)
+	_, err = adminUseCase.GetUserTasks(context.Background(), "admin", "user2")
+	assert.Equal(t, services.NotFound("user_not_found", "user doesn't exist"), err)
+
+	taskRepo.EXPECT().CheckUserIDExist(gomock.Any(), "user1").Return(true, nil).Times(1)
+	taskRepo.EXPECT().GetTasks(gomock.Any(), "user1").Return([]models.TaskDetails{{ID: "t1"}}, nil).Times(1)
+	auditRepo.EXPECT().InsertAuditLog(gomock.Any(), gomock.Any()).Return("", errors.New("db error")).Times(1)
+	tasks, err = adminUseCase.GetUserTasks(context.Background(), "admin", "user1")
+	assert.EqualError(t, err, "error from audit log")
+	assert.Empty(t, tasks)
+}
+
+func Test_AdminResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	taskRepo := mockRepository.NewMockTaskRepository(ctrl)
+	tokenRepo := mockRepository.NewMockTokenRepository(ctrl)
+	sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+	auditRepo := mockRepository.NewMockAuditRepository(ctrl)
+	adminUseCase := usecase.NewAdminUseCase(userRepo, taskRepo, tokenRepo, sessionRepo, auditRepo, &password.Policy{MinLength: 8, DisallowPersonal: true}, testHasher)
+
+	auditRepo.EXPECT().InsertAuditLog(gomock.Any(), gomock.Any()).Return("audit1", nil).Times(1)
+	auditRepo.EXPECT().SetAuditOutcome(gomock.Any(), "audit1", models.AuditSucceeded).Return(nil).Times(1)
+	userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(models.UserDetails{ID: "user1", Name: "akhil", Email: "akhil@gmail.com"}, nil).Times(2)
+	userRepo.EXPECT().UpdatePassword(gomock.Any(), "user1", gomock.Not("newpassword")).Return(true, nil).Times(1)
+	tokenRepo.EXPECT().DeleteTokensByUser(gomock.Any(), "user1").Return(nil).Times(1)
+	sessionRepo.EXPECT().DeleteSessionsByUser(gomock.Any(), "user1").Return(nil).Times(1)
+	err := adminUseCase.ResetPassword(context.Background(), "admin", "user1", models.ResetPassword{Password: "newpassword"})
+	assert.NoError(t, err)
+
+	err = adminUseCase.ResetPassword(context.Background(), "admin", "user1", models.ResetPassword{Password: "akhil12345"})
+	assert.ErrorIs(t, err, services.ErrWeakPassword)
+}
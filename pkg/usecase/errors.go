@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+var (
+	errUserNotFound       = services.NotFound("user_not_found", "user doesn't exist")
+	errTaskNotFound       = services.NotFound("task_not_found", "task doesn't exist")
+	errTokenNotFound      = services.NotFound("token_not_found", "token doesn't exist")
+	errSessionNotFound    = services.NotFound("session_not_found", "session doesn't exist")
+	errOwnAccountStatus   = services.Forbidden("own_account", "cannot change own account status")
+	errOwnRole            = services.Forbidden("own_account", "cannot change own role")
+	errEmailTaken         = services.Conflict("email_taken", "user with this email is already exists")
+	errAccountDisabled    = services.Forbidden("account_disabled", "account is disabled")
+	errNotExecuted        = services.Conflict("not_executed", "not executed because an earlier operation failed")
+	errInvalidAccessToken = services.Unauthorized("invalid_token", "invalid access token")
+	errAccessTokenExpired = services.Unauthorized("invalid_token", "access token expired")
+	errSessionRevoked     = services.Unauthorized("invalid_token", "session has been revoked")
+	errAccountInactive    = services.Unauthorized("invalid_token", "account is not active")
+	errTokenRevoked       = services.Unauthorized("invalid_token", "token has been revoked")
+	errUnsupportedPatch   = services.InvalidInput("unsupported_patch", "patch must be "+models.MergePatchContentType+" or "+models.JSONPatchContentType)
+
+	errInvalidTimezone = services.InvalidInput("invalid_fields", "invalid timezone",
+		models.FieldError{Field: "timezone", Rule: "timezone", Message: "must be an IANA time zone name such as Europe/Berlin"})
+	errInvalidLocale = services.InvalidInput("invalid_fields", "invalid locale",
+		models.FieldError{Field: "locale", Rule: "bcp47", Message: "must be a BCP 47 language tag such as en-US"})
+)
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"taskmanagementapi/pkg/logging"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+const (
+	checkOK = "ok"
+
+	// staleAfter is how many intervals a worker may go without a
+	// successful run before the instance is taken out of rotation.
+	staleAfter = 2
+)
+
+type worker struct {
+	interval    time.Duration
+	lastSuccess time.Time
+}
+
+type healthUseCase struct {
+	healthRepository interfaces.HealthRepository
+	timeout          time.Duration
+
+	mu      sync.Mutex
+	workers map[string]*worker
+	running sync.WaitGroup
+}
+
+func NewHealthUseCase(repository interfaces.HealthRepository, timeout time.Duration) services.HealthUseCase {
+	return &healthUseCase{
+		healthRepository: repository,
+		timeout:          timeout,
+		workers:          make(map[string]*worker),
+	}
+}
+
+// Go runs a background worker under name, calling run every interval until
+// ctx is cancelled, and notes when run last succeeded. A worker that keeps
+// failing or hangs in run goes stale and takes the instance out of rotation.
+func (hu *healthUseCase) Go(ctx context.Context, name string, interval time.Duration, run func(context.Context) error) {
+	hu.mu.Lock()
+	// A worker that has not run yet is given its first intervals from now.
+	hu.workers[name] = &worker{interval: interval, lastSuccess: time.Now()}
+	hu.mu.Unlock()
+	hu.running.Add(1)
+	go func() {
+		defer hu.running.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := run(ctx); err != nil {
+					logging.FromContext(ctx).Error("background worker failed", "worker", name, "error", err)
+					continue
+				}
+				hu.succeeded(name)
+			}
+		}
+	}()
+}
+
+func (hu *healthUseCase) succeeded(name string) {
+	hu.mu.Lock()
+	defer hu.mu.Unlock()
+	hu.workers[name].lastSuccess = time.Now()
+}
+
+// Ready pings MongoDB, giving up after the configured timeout, and checks that
+// every worker has succeeded recently. Failures are logged but only
+// summarised in the result, since /readyz is unauthenticated.
+func (hu *healthUseCase) Ready(ctx context.Context) models.Readiness {
+	readiness := models.Readiness{Ready: true, Checks: map[string]string{"mongodb": checkOK}}
+	pingCtx, cancel := context.WithTimeout(ctx, hu.timeout)
+	defer cancel()
+	if err := hu.healthRepository.Ping(pingCtx); err != nil {
+		logging.FromContext(ctx).Warn("readiness check failed", "check", "mongodb", "error", err)
+		readiness.Ready = false
+		readiness.Checks["mongodb"] = "unreachable"
+	}
+
+	now := time.Now()
+	hu.mu.Lock()
+	defer hu.mu.Unlock()
+	for name, w := range hu.workers {
+		readiness.Checks[name] = checkOK
+		if now.Sub(w.lastSuccess) > staleAfter*w.interval {
+			logging.FromContext(ctx).Warn("readiness check failed", "check", name, "last_success", w.lastSuccess)
+			readiness.Ready = false
+			readiness.Checks[name] = "stale"
+		}
+	}
+	return readiness
+}
+
+// Wait blocks until every worker started by Go has returned, or until ctx is
+// done.
+func (hu *healthUseCase) Wait(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		hu.running.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,97 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"taskmanagementapi/pkg/usecase"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	mockRepository "taskmanagementapi/pkg/repository/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Ready(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	testCases := map[string]struct {
+		pingErr error
+		run     func(context.Context) error
+		want    models.Readiness
+	}{
+		"ready": {
+			run:  func(context.Context) error { return nil },
+			want: models.Readiness{Ready: true, Checks: map[string]string{"mongodb": "ok", "purge": "ok"}},
+		},
+		"mongodb unreachable": {
+			pingErr: errors.New("server selection timeout"),
+			run:     func(context.Context) error { return nil },
+			want:    models.Readiness{Checks: map[string]string{"mongodb": "unreachable", "purge": "ok"}},
+		},
+		"worker failing": {
+			run:  func(context.Context) error { return errors.New("db error") },
+			want: models.Readiness{Checks: map[string]string{"mongodb": "ok", "purge": "stale"}},
+		},
+		"worker hung": {
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			want: models.Readiness{Checks: map[string]string{"mongodb": "ok", "purge": "stale"}},
+		},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			healthRepo := mockRepository.NewMockHealthRepository(ctrl)
+			healthUseCase := usecase.NewHealthUseCase(healthRepo, time.Second)
+
+			healthRepo.EXPECT().Ping(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+				_, hasDeadline := ctx.Deadline()
+				assert.True(t, hasDeadline)
+				return test.pingErr
+			}).AnyTimes()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var runs atomic.Int32
+			healthUseCase.Go(ctx, "purge", interval, func(ctx context.Context) error {
+				runs.Add(1)
+				return test.run(ctx)
+			})
+
+			// A worker only counts as stale after a couple of intervals
+			// without a successful run.
+			assert.Eventually(t, func() bool { return runs.Load() > 0 }, time.Second, time.Millisecond)
+			time.Sleep(3 * interval)
+			assert.Equal(t, test.want, healthUseCase.Ready(context.Background()))
+		})
+	}
+}
+
+func Test_Wait(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	healthUseCase := usecase.NewHealthUseCase(mockRepository.NewMockHealthRepository(ctrl), time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	healthUseCase.Go(ctx, "sessions", time.Millisecond, func(context.Context) error { return nil })
+	cancel()
+	assert.NoError(t, healthUseCase.Wait(context.Background()))
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+	healthUseCase.Go(context.Background(), "stuck", time.Millisecond, func(context.Context) error {
+		<-stuck
+		return nil
+	})
+	deadline, cancelWait := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelWait()
+	assert.ErrorIs(t, healthUseCase.Wait(deadline), context.DeadlineExceeded)
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+const (
+	// IdempotencyPurgeInterval is how often Purge should run.
+	IdempotencyPurgeInterval = time.Hour
+	// idempotencyLease is how long a request has to finish before its key can
+	// be claimed again. It is well above the HTTP write timeout.
+	idempotencyLease = time.Minute
+)
+
+var (
+	errIdempotencyKeyReused   = services.Validation("idempotency_key_reused", "idempotency key was already used with a different request")
+	errIdempotencyKeyInFlight = services.Conflict("idempotency_key_in_use", "a request with this idempotency key is still being processed")
+)
+
+type idempotencyUseCase struct {
+	idempotencyRepository interfaces.IdempotencyRepository
+	ttl                   time.Duration
+}
+
+func NewIdempotencyUseCase(repository interfaces.IdempotencyRepository, ttl time.Duration) services.IdempotencyUseCase {
+	return &idempotencyUseCase{
+		idempotencyRepository: repository,
+		ttl:                   ttl,
+	}
+}
+
+// idempotencyID scopes keys to the user so that clients cannot collide.
+func idempotencyID(userID, key string) string {
+	return userID + ":" + key
+}
+
+// Begin claims key for a request with the given fingerprint. It returns the
+// stored response when the key was already used for the same request, and nil
+// when the caller should run the request and then call Complete or Release.
+func (iu *idempotencyUseCase) Begin(ctx context.Context, userID, key, fingerprint string) (*models.IdempotencyRecord, error) {
+	record, claimed, err := iu.idempotencyRepository.Claim(ctx, idempotencyID(userID, key), fingerprint, idempotencyLease)
+	if err != nil {
+		return nil, errors.New("error from claim idempotency key")
+	}
+	switch {
+	case claimed:
+		return nil, nil
+	case record.Fingerprint != fingerprint:
+		return nil, errIdempotencyKeyReused
+	case record.Status == 0:
+		return nil, errIdempotencyKeyInFlight
+	}
+	return &record, nil
+}
+
+// Complete stores the response for replay.
+func (iu *idempotencyUseCase) Complete(ctx context.Context, userID, key string, response models.IdempotencyRecord) error {
+	return iu.idempotencyRepository.Complete(ctx, idempotencyID(userID, key), response, iu.ttl)
+}
+
+func (iu *idempotencyUseCase) Release(ctx context.Context, userID, key string) error {
+	return iu.idempotencyRepository.Release(ctx, idempotencyID(userID, key))
+}
+
+// Purge deletes expired keys.
+func (iu *idempotencyUseCase) Purge(ctx context.Context) error {
+	return iu.idempotencyRepository.DeleteExpired(ctx)
+}
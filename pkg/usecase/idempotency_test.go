@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/usecase"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	mockRepository "taskmanagementapi/pkg/repository/mock"
+	services "taskmanagementapi/pkg/usecase/interface"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BeginIdempotentRequest(t *testing.T) {
+	stored := models.IdempotencyRecord{Fingerprint: "fp", Status: 201, Body: []byte("{}")}
+
+	testData := map[string]struct {
+		record  models.IdempotencyRecord
+		claimed bool
+		repoErr error
+		want    *models.IdempotencyRecord
+		wantErr error
+	}{
+		"new key":             {claimed: true},
+		"replay":              {record: stored, want: &stored},
+		"different request":   {record: models.IdempotencyRecord{Fingerprint: "other", Status: 201}, wantErr: services.Validation("idempotency_key_reused", "")},
+		"request in progress": {record: models.IdempotencyRecord{Fingerprint: "fp"}, wantErr: services.Conflict("idempotency_key_in_use", "")},
+		"repository error":    {repoErr: errors.New("db error"), wantErr: errors.New("error from claim idempotency key")},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			idempotencyRepo := mockRepository.NewMockIdempotencyRepository(ctrl)
+			idempotencyUseCase := usecase.NewIdempotencyUseCase(idempotencyRepo, time.Hour)
+
+			idempotencyRepo.EXPECT().Claim(gomock.Any(), "user1:key", "fp", time.Minute).Return(test.record, test.claimed, test.repoErr).Times(1)
+			record, err := idempotencyUseCase.Begin(context.Background(), "user1", "key", "fp")
+			if test.wantErr != nil {
+				assert.Nil(t, record)
+				if errors.Is(test.wantErr, services.ErrValidation) || errors.Is(test.wantErr, services.ErrConflict) {
+					assert.ErrorIs(t, err, test.wantErr)
+				} else {
+					assert.Equal(t, test.wantErr, err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, record)
+		})
+	}
+}
+
+func Test_CompleteAndReleaseIdempotentRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	idempotencyRepo := mockRepository.NewMockIdempotencyRepository(ctrl)
+	idempotencyUseCase := usecase.NewIdempotencyUseCase(idempotencyRepo, time.Hour)
+
+	response := models.IdempotencyRecord{Status: 201, Body: []byte("{}")}
+	idempotencyRepo.EXPECT().Complete(gomock.Any(), "user1:key", response, time.Hour).Return(errors.New("db error")).Times(1)
+	assert.Error(t, idempotencyUseCase.Complete(context.Background(), "user1", "key", response))
+
+	idempotencyRepo.EXPECT().Release(gomock.Any(), "user1:other").Return(nil).Times(1)
+	assert.NoError(t, idempotencyUseCase.Release(context.Background(), "user1", "other"))
+}
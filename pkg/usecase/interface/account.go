@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type AccountUseCase interface {
+	ExportData(context.Context, string) (models.UserExport, error)
+	DeleteAccount(context.Context, string, models.DeleteAccount) error
+}
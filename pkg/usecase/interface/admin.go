@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type AdminUseCase interface {
+	ListUsers(context.Context, string, models.UserFilter) ([]models.UserSummary, error)
+	SetUserDisabled(context.Context, string, string, bool) error
+	ResetPassword(context.Context, string, string, models.ResetPassword) error
+	UpdateRole(context.Context, string, string, models.UpdateRole) error
+	GetUserTasks(context.Context, string, string) ([]models.TaskDetails, error)
+	GetAuditLogs(context.Context, int, int) ([]models.AuditLog, error)
+}
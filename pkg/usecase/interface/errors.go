@@ -0,0 +1,82 @@
+package interfaces
+
+import "taskmanagementapi/pkg/utils/models"
+
+// Kind classifies a domain error so the API layer can pick a status code
+// without knowing which usecase produced it.
+type Kind int
+
+const (
+	KindNotFound Kind = iota + 1
+	KindConflict
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindTooManyRequests
+	// KindInvalidInput is for requests that could not be parsed or failed
+	// field validation, as opposed to KindValidation which covers input
+	// that is well-formed but not acceptable.
+	KindInvalidInput
+)
+
+// Error is a failure the client can act on. Code is a stable identifier for
+// programs to branch on; Message is meant for people and may change. Fields
+// lists the offending request fields, when there are any.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []models.FieldError
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports a match against the kind-only sentinels below, so that
+// errors.Is(err, ErrNotFound) holds for every not-found error, and against
+// any error with the same kind and code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Kind == e.Kind && (t.Code == "" || t.Code == e.Code)
+}
+
+var (
+	ErrNotFound     = &Error{Kind: KindNotFound, Message: "not found"}
+	ErrConflict     = &Error{Kind: KindConflict, Message: "conflict"}
+	ErrValidation   = &Error{Kind: KindValidation, Message: "validation failed"}
+	ErrUnauthorized = &Error{Kind: KindUnauthorized, Message: "unauthorized"}
+	ErrForbidden    = &Error{Kind: KindForbidden, Message: "forbidden"}
+	ErrInvalidInput = &Error{Kind: KindInvalidInput, Message: "invalid input"}
+)
+
+func NotFound(code, message string) error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func Conflict(code, message string) error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func Validation(code, message string) error {
+	return &Error{Kind: KindValidation, Code: code, Message: message}
+}
+
+func Unauthorized(code, message string) error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+func TooManyRequests(code, message string) error {
+	return &Error{Kind: KindTooManyRequests, Code: code, Message: message}
+}
+
+func InvalidInput(code, message string, fields ...models.FieldError) error {
+	return &Error{Kind: KindInvalidInput, Code: code, Message: message, Fields: fields}
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+type HealthUseCase interface {
+	Go(context.Context, string, time.Duration, func(context.Context) error)
+	Ready(context.Context) models.Readiness
+	Wait(context.Context) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type IdempotencyUseCase interface {
+	Begin(context.Context, string, string, string) (*models.IdempotencyRecord, error)
+	Complete(context.Context, string, string, models.IdempotencyRecord) error
+	Release(context.Context, string, string) error
+	Purge(context.Context) error
+}
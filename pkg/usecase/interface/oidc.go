@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type OIDCUseCase interface {
+	LoginURL(context.Context) (models.OIDCLogin, error)
+	Callback(context.Context, string, string, models.ClientInfo) (string, error)
+}
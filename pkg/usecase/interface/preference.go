@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type PreferenceUseCase interface {
+	GetPreferences(context.Context, string) (models.Preferences, error)
+	UpdatePreferences(context.Context, string, models.Preferences) (models.Preferences, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type RateLimitUseCase interface {
+	Allow(context.Context, string, models.RateLimit) models.RateLimitResult
+	Purge(context.Context) error
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type SessionUseCase interface {
+	GetSessions(context.Context, string, string) ([]models.Session, error)
+	RevokeSession(context.Context, string, string) error
+	SignOut(context.Context, string) error
+	Touch(string)
+	Flush(context.Context) error
+}
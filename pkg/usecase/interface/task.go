@@ -1,11 +1,16 @@
 package interfaces
 
-import "taskmanagementapi/pkg/utils/models"
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
 
 type TaskUseCase interface {
-	CreateTask(models.CreateTask, string) error
-	GetTasks(string) ([]models.TaskDetails, error)
-	GetTask(string, string) (models.TaskDetails, error)
-	UpdateTask(string,string,models.CreateTask)error
-	DeleteTask(string,string)error
+	CreateTask(context.Context, models.CreateTask, string) (string, error)
+	GetTasks(context.Context, string, models.TaskQuery) ([]models.TaskDetails, error)
+	GetTask(context.Context, string, string) (models.TaskDetails, error)
+	UpdateTask(context.Context, string,string,models.CreateTask)error
+	PatchTask(context.Context, string, string, models.TaskPatch) (models.TaskDetails, error)
+	BulkTasks(context.Context, string, models.BulkTaskRequest) ([]models.BulkTaskResult, error)
+	DeleteTask(context.Context, string,string)error
 }
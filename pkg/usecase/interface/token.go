@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"taskmanagementapi/pkg/helper"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+type TokenUseCase interface {
+	CreateToken(context.Context, string, models.CreateToken) (models.CreatedToken, error)
+	GetTokens(context.Context, string) ([]models.TokenDetails, error)
+	RevokeToken(context.Context, string, string) error
+	ValidateToken(context.Context, string) (models.TokenAuth, error)
+	ValidateJWT(context.Context, string) (*helper.AuthUserClaims, error)
+	GetJWKS() models.JWKS
+}
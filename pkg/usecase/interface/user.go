@@ -1,8 +1,21 @@
 package interfaces
 
-import "taskmanagementapi/pkg/utils/models"
+import (
+	"context"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+var (
+	ErrInvalidCredentials = &Error{Kind: KindUnauthorized, Code: "invalid_credentials", Message: "invalid credentials"}
+	ErrTooManyAttempts    = &Error{Kind: KindTooManyRequests, Code: "too_many_attempts", Message: "too many failed sign-in attempts, try again later"}
+	ErrWeakPassword       = &Error{Kind: KindValidation, Code: "weak_password", Message: "password does not meet the password policy"}
+)
 
 type UserUseCase interface {
-	UserSignUp(models.UserSignup) error
-	UserSignIn(models.UserSignIn) (string, error)
+	UserSignUp(context.Context, models.UserSignup) error
+	UserSignIn(context.Context, models.UserSignIn, models.ClientInfo) (string, error)
+	GetProfile(context.Context, string) (models.UserProfile, error)
+	UpdateProfile(context.Context, string, models.UpdateProfile) error
+	VerifyEmail(context.Context, string, models.VerifyEmail) error
+	ChangePassword(context.Context, string, models.ChangePassword, models.ClientInfo) (string, error)
 }
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\usecase\interface\account.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAccountUseCase is a mock of AccountUseCase interface.
+type MockAccountUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountUseCaseMockRecorder
+}
+
+// MockAccountUseCaseMockRecorder is the mock recorder for MockAccountUseCase.
+type MockAccountUseCaseMockRecorder struct {
+	mock *MockAccountUseCase
+}
+
+// NewMockAccountUseCase creates a new mock instance.
+func NewMockAccountUseCase(ctrl *gomock.Controller) *MockAccountUseCase {
+	mock := &MockAccountUseCase{ctrl: ctrl}
+	mock.recorder = &MockAccountUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountUseCase) EXPECT() *MockAccountUseCaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccountUseCase) DeleteAccount(arg0 context.Context, arg1 string, arg2 models.DeleteAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccountUseCaseMockRecorder) DeleteAccount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccountUseCase)(nil).DeleteAccount), arg0, arg1, arg2)
+}
+
+// ExportData mocks base method.
+func (m *MockAccountUseCase) ExportData(arg0 context.Context, arg1 string) (models.UserExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportData", arg0, arg1)
+	ret0, _ := ret[0].(models.UserExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportData indicates an expected call of ExportData.
+func (mr *MockAccountUseCaseMockRecorder) ExportData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportData", reflect.TypeOf((*MockAccountUseCase)(nil).ExportData), arg0, arg1)
+}
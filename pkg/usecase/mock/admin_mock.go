@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\usecase\interface\admin.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAdminUseCase is a mock of AdminUseCase interface.
+type MockAdminUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminUseCaseMockRecorder
+}
+
+// MockAdminUseCaseMockRecorder is the mock recorder for MockAdminUseCase.
+type MockAdminUseCaseMockRecorder struct {
+	mock *MockAdminUseCase
+}
+
+// NewMockAdminUseCase creates a new mock instance.
+func NewMockAdminUseCase(ctrl *gomock.Controller) *MockAdminUseCase {
+	mock := &MockAdminUseCase{ctrl: ctrl}
+	mock.recorder = &MockAdminUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminUseCase) EXPECT() *MockAdminUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLogs mocks base method.
+func (m *MockAdminUseCase) GetAuditLogs(arg0 context.Context, arg1, arg2 int) ([]models.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogs indicates an expected call of GetAuditLogs.
+func (mr *MockAdminUseCaseMockRecorder) GetAuditLogs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogs", reflect.TypeOf((*MockAdminUseCase)(nil).GetAuditLogs), arg0, arg1, arg2)
+}
+
+// GetUserTasks mocks base method.
+func (m *MockAdminUseCase) GetUserTasks(arg0 context.Context, arg1, arg2 string) ([]models.TaskDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTasks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.TaskDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTasks indicates an expected call of GetUserTasks.
+func (mr *MockAdminUseCaseMockRecorder) GetUserTasks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTasks", reflect.TypeOf((*MockAdminUseCase)(nil).GetUserTasks), arg0, arg1, arg2)
+}
+
+// ListUsers mocks base method.
+func (m *MockAdminUseCase) ListUsers(arg0 context.Context, arg1 string, arg2 models.UserFilter) ([]models.UserSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.UserSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAdminUseCaseMockRecorder) ListUsers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAdminUseCase)(nil).ListUsers), arg0, arg1, arg2)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAdminUseCase) ResetPassword(arg0 context.Context, arg1, arg2 string, arg3 models.ResetPassword) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAdminUseCaseMockRecorder) ResetPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAdminUseCase)(nil).ResetPassword), arg0, arg1, arg2, arg3)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockAdminUseCase) SetUserDisabled(arg0 context.Context, arg1, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDisabled", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockAdminUseCaseMockRecorder) SetUserDisabled(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockAdminUseCase)(nil).SetUserDisabled), arg0, arg1, arg2, arg3)
+}
+
+// UpdateRole mocks base method.
+func (m *MockAdminUseCase) UpdateRole(arg0 context.Context, arg1, arg2 string, arg3 models.UpdateRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockAdminUseCaseMockRecorder) UpdateRole(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockAdminUseCase)(nil).UpdateRole), arg0, arg1, arg2, arg3)
+}
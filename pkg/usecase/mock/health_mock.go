@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\usecase\interface\health.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHealthUseCase is a mock of HealthUseCase interface.
+type MockHealthUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthUseCaseMockRecorder
+}
+
+// MockHealthUseCaseMockRecorder is the mock recorder for MockHealthUseCase.
+type MockHealthUseCaseMockRecorder struct {
+	mock *MockHealthUseCase
+}
+
+// NewMockHealthUseCase creates a new mock instance.
+func NewMockHealthUseCase(ctrl *gomock.Controller) *MockHealthUseCase {
+	mock := &MockHealthUseCase{ctrl: ctrl}
+	mock.recorder = &MockHealthUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthUseCase) EXPECT() *MockHealthUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Go mocks base method.
+func (m *MockHealthUseCase) Go(arg0 context.Context, arg1 string, arg2 time.Duration, arg3 func(context.Context) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Go", arg0, arg1, arg2, arg3)
+}
+
+// Go indicates an expected call of Go.
+func (mr *MockHealthUseCaseMockRecorder) Go(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Go", reflect.TypeOf((*MockHealthUseCase)(nil).Go), arg0, arg1, arg2, arg3)
+}
+
+// Ready mocks base method.
+func (m *MockHealthUseCase) Ready(arg0 context.Context) models.Readiness {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", arg0)
+	ret0, _ := ret[0].(models.Readiness)
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockHealthUseCaseMockRecorder) Ready(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockHealthUseCase)(nil).Ready), arg0)
+}
+
+// Wait mocks base method.
+func (m *MockHealthUseCase) Wait(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockHealthUseCaseMockRecorder) Wait(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockHealthUseCase)(nil).Wait), arg0)
+}
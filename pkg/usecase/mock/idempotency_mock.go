@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\usecase\interface\idempotency.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIdempotencyUseCase is a mock of IdempotencyUseCase interface.
+type MockIdempotencyUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyUseCaseMockRecorder
+}
+
+// MockIdempotencyUseCaseMockRecorder is the mock recorder for MockIdempotencyUseCase.
+type MockIdempotencyUseCaseMockRecorder struct {
+	mock *MockIdempotencyUseCase
+}
+
+// NewMockIdempotencyUseCase creates a new mock instance.
+func NewMockIdempotencyUseCase(ctrl *gomock.Controller) *MockIdempotencyUseCase {
+	mock := &MockIdempotencyUseCase{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyUseCase) EXPECT() *MockIdempotencyUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIdempotencyUseCase) Begin(arg0 context.Context, arg1, arg2, arg3 string) (*models.IdempotencyRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.IdempotencyRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIdempotencyUseCaseMockRecorder) Begin(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdempotencyUseCase)(nil).Begin), arg0, arg1, arg2, arg3)
+}
+
+// Complete mocks base method.
+func (m *MockIdempotencyUseCase) Complete(arg0 context.Context, arg1, arg2 string, arg3 models.IdempotencyRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyUseCaseMockRecorder) Complete(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotencyUseCase)(nil).Complete), arg0, arg1, arg2, arg3)
+}
+
+// Purge mocks base method.
+func (m *MockIdempotencyUseCase) Purge(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockIdempotencyUseCaseMockRecorder) Purge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockIdempotencyUseCase)(nil).Purge), arg0)
+}
+
+// Release mocks base method.
+func (m *MockIdempotencyUseCase) Release(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyUseCaseMockRecorder) Release(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotencyUseCase)(nil).Release), arg0, arg1, arg2)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\usecase\interface\oidc.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOIDCUseCase is a mock of OIDCUseCase interface.
+type MockOIDCUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCUseCaseMockRecorder
+}
+
+// MockOIDCUseCaseMockRecorder is the mock recorder for MockOIDCUseCase.
+type MockOIDCUseCaseMockRecorder struct {
+	mock *MockOIDCUseCase
+}
+
+// NewMockOIDCUseCase creates a new mock instance.
+func NewMockOIDCUseCase(ctrl *gomock.Controller) *MockOIDCUseCase {
+	mock := &MockOIDCUseCase{ctrl: ctrl}
+	mock.recorder = &MockOIDCUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCUseCase) EXPECT() *MockOIDCUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Callback mocks base method.
+func (m *MockOIDCUseCase) Callback(arg0 context.Context, arg1, arg2 string, arg3 models.ClientInfo) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Callback", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Callback indicates an expected call of Callback.
+func (mr *MockOIDCUseCaseMockRecorder) Callback(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Callback", reflect.TypeOf((*MockOIDCUseCase)(nil).Callback), arg0, arg1, arg2, arg3)
+}
+
+// LoginURL mocks base method.
+func (m *MockOIDCUseCase) LoginURL(arg0 context.Context) (models.OIDCLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginURL", arg0)
+	ret0, _ := ret[0].(models.OIDCLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginURL indicates an expected call of LoginURL.
+func (mr *MockOIDCUseCaseMockRecorder) LoginURL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginURL", reflect.TypeOf((*MockOIDCUseCase)(nil).LoginURL), arg0)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\usecase\interface\preference.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPreferenceUseCase is a mock of PreferenceUseCase interface.
+type MockPreferenceUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferenceUseCaseMockRecorder
+}
+
+// MockPreferenceUseCaseMockRecorder is the mock recorder for MockPreferenceUseCase.
+type MockPreferenceUseCaseMockRecorder struct {
+	mock *MockPreferenceUseCase
+}
+
+// NewMockPreferenceUseCase creates a new mock instance.
+func NewMockPreferenceUseCase(ctrl *gomock.Controller) *MockPreferenceUseCase {
+	mock := &MockPreferenceUseCase{ctrl: ctrl}
+	mock.recorder = &MockPreferenceUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferenceUseCase) EXPECT() *MockPreferenceUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockPreferenceUseCase) GetPreferences(arg0 context.Context, arg1 string) (models.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", arg0, arg1)
+	ret0, _ := ret[0].(models.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockPreferenceUseCaseMockRecorder) GetPreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockPreferenceUseCase)(nil).GetPreferences), arg0, arg1)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockPreferenceUseCase) UpdatePreferences(arg0 context.Context, arg1 string, arg2 models.Preferences) (models.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockPreferenceUseCaseMockRecorder) UpdatePreferences(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockPreferenceUseCase)(nil).UpdatePreferences), arg0, arg1, arg2)
+}
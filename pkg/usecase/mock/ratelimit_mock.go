@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\usecase\interface\ratelimit.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRateLimitUseCase is a mock of RateLimitUseCase interface.
+type MockRateLimitUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitUseCaseMockRecorder
+}
+
+// MockRateLimitUseCaseMockRecorder is the mock recorder for MockRateLimitUseCase.
+type MockRateLimitUseCaseMockRecorder struct {
+	mock *MockRateLimitUseCase
+}
+
+// NewMockRateLimitUseCase creates a new mock instance.
+func NewMockRateLimitUseCase(ctrl *gomock.Controller) *MockRateLimitUseCase {
+	mock := &MockRateLimitUseCase{ctrl: ctrl}
+	mock.recorder = &MockRateLimitUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitUseCase) EXPECT() *MockRateLimitUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockRateLimitUseCase) Allow(arg0 context.Context, arg1 string, arg2 models.RateLimit) models.RateLimitResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.RateLimitResult)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockRateLimitUseCaseMockRecorder) Allow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimitUseCase)(nil).Allow), arg0, arg1, arg2)
+}
+
+// Purge mocks base method.
+func (m *MockRateLimitUseCase) Purge(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockRateLimitUseCaseMockRecorder) Purge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockRateLimitUseCase)(nil).Purge), arg0)
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\usecase\interface\session.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	models "taskmanagementapi/pkg/utils/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSessionUseCase is a mock of SessionUseCase interface.
+type MockSessionUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionUseCaseMockRecorder
+}
+
+// MockSessionUseCaseMockRecorder is the mock recorder for MockSessionUseCase.
+type MockSessionUseCaseMockRecorder struct {
+	mock *MockSessionUseCase
+}
+
+// NewMockSessionUseCase creates a new mock instance.
+func NewMockSessionUseCase(ctrl *gomock.Controller) *MockSessionUseCase {
+	mock := &MockSessionUseCase{ctrl: ctrl}
+	mock.recorder = &MockSessionUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionUseCase) EXPECT() *MockSessionUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockSessionUseCase) Flush(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockSessionUseCaseMockRecorder) Flush(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockSessionUseCase)(nil).Flush), arg0)
+}
+
+// GetSessions mocks base method.
+func (m *MockSessionUseCase) GetSessions(arg0 context.Context, arg1, arg2 string) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockSessionUseCaseMockRecorder) GetSessions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockSessionUseCase)(nil).GetSessions), arg0, arg1, arg2)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionUseCase) RevokeSession(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionUseCaseMockRecorder) RevokeSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionUseCase)(nil).RevokeSession), arg0, arg1, arg2)
+}
+
+// SignOut mocks base method.
+func (m *MockSessionUseCase) SignOut(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignOut", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignOut indicates an expected call of SignOut.
+func (mr *MockSessionUseCaseMockRecorder) SignOut(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOut", reflect.TypeOf((*MockSessionUseCase)(nil).SignOut), arg0, arg1)
+}
+
+// Touch mocks base method.
+func (m *MockSessionUseCase) Touch(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Touch", arg0)
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockSessionUseCaseMockRecorder) Touch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockSessionUseCase)(nil).Touch), arg0)
+}
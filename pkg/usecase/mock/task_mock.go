@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 	models "taskmanagementapi/pkg/utils/models"
 
@@ -34,74 +35,105 @@ func (m *MockTaskUseCase) EXPECT() *MockTaskUseCaseMockRecorder {
 	return m.recorder
 }
 
+// BulkTasks mocks base method.
+func (m *MockTaskUseCase) BulkTasks(arg0 context.Context, arg1 string, arg2 models.BulkTaskRequest) ([]models.BulkTaskResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkTasks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.BulkTaskResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkTasks indicates an expected call of BulkTasks.
+func (mr *MockTaskUseCaseMockRecorder) BulkTasks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkTasks", reflect.TypeOf((*MockTaskUseCase)(nil).BulkTasks), arg0, arg1, arg2)
+}
+
 // CreateTask mocks base method.
-func (m *MockTaskUseCase) CreateTask(arg0 models.CreateTask, arg1 string) error {
+func (m *MockTaskUseCase) CreateTask(arg0 context.Context, arg1 models.CreateTask, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CreateTask indicates an expected call of CreateTask.
-func (mr *MockTaskUseCaseMockRecorder) CreateTask(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) CreateTask(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskUseCase)(nil).CreateTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskUseCase)(nil).CreateTask), arg0, arg1, arg2)
 }
 
 // DeleteTask mocks base method.
-func (m *MockTaskUseCase) DeleteTask(arg0, arg1 string) error {
+func (m *MockTaskUseCase) DeleteTask(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskUseCaseMockRecorder) DeleteTask(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) DeleteTask(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskUseCase)(nil).DeleteTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskUseCase)(nil).DeleteTask), arg0, arg1, arg2)
 }
 
 // GetTask mocks base method.
-func (m *MockTaskUseCase) GetTask(arg0, arg1 string) (models.TaskDetails, error) {
+func (m *MockTaskUseCase) GetTask(arg0 context.Context, arg1, arg2 string) (models.TaskDetails, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetTask", arg0, arg1, arg2)
 	ret0, _ := ret[0].(models.TaskDetails)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTask indicates an expected call of GetTask.
-func (mr *MockTaskUseCaseMockRecorder) GetTask(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) GetTask(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskUseCase)(nil).GetTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskUseCase)(nil).GetTask), arg0, arg1, arg2)
 }
 
 // GetTasks mocks base method.
-func (m *MockTaskUseCase) GetTasks(arg0 string) ([]models.TaskDetails, error) {
+func (m *MockTaskUseCase) GetTasks(arg0 context.Context, arg1 string, arg2 models.TaskQuery) ([]models.TaskDetails, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", arg0)
+	ret := m.ctrl.Call(m, "GetTasks", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]models.TaskDetails)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasks indicates an expected call of GetTasks.
-func (mr *MockTaskUseCaseMockRecorder) GetTasks(arg0 interface{}) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) GetTasks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTaskUseCase)(nil).GetTasks), arg0, arg1, arg2)
+}
+
+// PatchTask mocks base method.
+func (m *MockTaskUseCase) PatchTask(arg0 context.Context, arg1, arg2 string, arg3 models.TaskPatch) (models.TaskDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTask", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.TaskDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchTask indicates an expected call of PatchTask.
+func (mr *MockTaskUseCaseMockRecorder) PatchTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTaskUseCase)(nil).GetTasks), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTask", reflect.TypeOf((*MockTaskUseCase)(nil).PatchTask), arg0, arg1, arg2, arg3)
 }
 
 // UpdateTask mocks base method.
-func (m *MockTaskUseCase) UpdateTask(arg0, arg1 string, arg2 models.CreateTask) error {
+func (m *MockTaskUseCase) UpdateTask(arg0 context.Context, arg1, arg2 string, arg3 models.CreateTask) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateTask", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskUseCaseMockRecorder) UpdateTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) UpdateTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskUseCase)(nil).UpdateTask), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskUseCase)(nil).UpdateTask), arg0, arg1, arg2, arg3)
 }
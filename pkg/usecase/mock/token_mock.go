@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\usecase\interface\token.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	helper "taskmanagementapi/pkg/helper"
+	models "taskmanagementapi/pkg/utils/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokenUseCase is a mock of TokenUseCase interface.
+type MockTokenUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenUseCaseMockRecorder
+}
+
+// MockTokenUseCaseMockRecorder is the mock recorder for MockTokenUseCase.
+type MockTokenUseCaseMockRecorder struct {
+	mock *MockTokenUseCase
+}
+
+// NewMockTokenUseCase creates a new mock instance.
+func NewMockTokenUseCase(ctrl *gomock.Controller) *MockTokenUseCase {
+	mock := &MockTokenUseCase{ctrl: ctrl}
+	mock.recorder = &MockTokenUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenUseCase) EXPECT() *MockTokenUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateToken mocks base method.
+func (m *MockTokenUseCase) CreateToken(arg0 context.Context, arg1 string, arg2 models.CreateToken) (models.CreatedToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.CreatedToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockTokenUseCaseMockRecorder) CreateToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockTokenUseCase)(nil).CreateToken), arg0, arg1, arg2)
+}
+
+// GetJWKS mocks base method.
+func (m *MockTokenUseCase) GetJWKS() models.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS")
+	ret0, _ := ret[0].(models.JWKS)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockTokenUseCaseMockRecorder) GetJWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockTokenUseCase)(nil).GetJWKS))
+}
+
+// GetTokens mocks base method.
+func (m *MockTokenUseCase) GetTokens(arg0 context.Context, arg1 string) ([]models.TokenDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokens", arg0, arg1)
+	ret0, _ := ret[0].([]models.TokenDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokens indicates an expected call of GetTokens.
+func (mr *MockTokenUseCaseMockRecorder) GetTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokens", reflect.TypeOf((*MockTokenUseCase)(nil).GetTokens), arg0, arg1)
+}
+
+// RevokeToken mocks base method.
+func (m *MockTokenUseCase) RevokeToken(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockTokenUseCaseMockRecorder) RevokeToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenUseCase)(nil).RevokeToken), arg0, arg1, arg2)
+}
+
+// ValidateJWT mocks base method.
+func (m *MockTokenUseCase) ValidateJWT(arg0 context.Context, arg1 string) (*helper.AuthUserClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateJWT", arg0, arg1)
+	ret0, _ := ret[0].(*helper.AuthUserClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateJWT indicates an expected call of ValidateJWT.
+func (mr *MockTokenUseCaseMockRecorder) ValidateJWT(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateJWT", reflect.TypeOf((*MockTokenUseCase)(nil).ValidateJWT), arg0, arg1)
+}
+
+// ValidateToken mocks base method.
+func (m *MockTokenUseCase) ValidateToken(arg0 context.Context, arg1 string) (models.TokenAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateToken", arg0, arg1)
+	ret0, _ := ret[0].(models.TokenAuth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateToken indicates an expected call of ValidateToken.
+func (mr *MockTokenUseCaseMockRecorder) ValidateToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockTokenUseCase)(nil).ValidateToken), arg0, arg1)
+}
@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 	models "taskmanagementapi/pkg/utils/models"
 
@@ -34,31 +35,89 @@ func (m *MockUserUseCase) EXPECT() *MockUserUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockUserUseCase) ChangePassword(arg0 context.Context, arg1 string, arg2 models.ChangePassword, arg3 models.ClientInfo) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUserUseCaseMockRecorder) ChangePassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserUseCase)(nil).ChangePassword), arg0, arg1, arg2, arg3)
+}
+
+// GetProfile mocks base method.
+func (m *MockUserUseCase) GetProfile(arg0 context.Context, arg1 string) (models.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", arg0, arg1)
+	ret0, _ := ret[0].(models.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockUserUseCaseMockRecorder) GetProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockUserUseCase)(nil).GetProfile), arg0, arg1)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUserUseCase) UpdateProfile(arg0 context.Context, arg1 string, arg2 models.UpdateProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserUseCaseMockRecorder) UpdateProfile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserUseCase)(nil).UpdateProfile), arg0, arg1, arg2)
+}
+
 // UserSignIn mocks base method.
-func (m *MockUserUseCase) UserSignIn(arg0 models.UserSignIn) (string, error) {
+func (m *MockUserUseCase) UserSignIn(arg0 context.Context, arg1 models.UserSignIn, arg2 models.ClientInfo) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserSignIn", arg0)
+	ret := m.ctrl.Call(m, "UserSignIn", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UserSignIn indicates an expected call of UserSignIn.
-func (mr *MockUserUseCaseMockRecorder) UserSignIn(arg0 interface{}) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) UserSignIn(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSignIn", reflect.TypeOf((*MockUserUseCase)(nil).UserSignIn), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSignIn", reflect.TypeOf((*MockUserUseCase)(nil).UserSignIn), arg0, arg1, arg2)
 }
 
 // UserSignUp mocks base method.
-func (m *MockUserUseCase) UserSignUp(arg0 models.UserSignup) error {
+func (m *MockUserUseCase) UserSignUp(arg0 context.Context, arg1 models.UserSignup) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserSignUp", arg0)
+	ret := m.ctrl.Call(m, "UserSignUp", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UserSignUp indicates an expected call of UserSignUp.
-func (mr *MockUserUseCaseMockRecorder) UserSignUp(arg0 interface{}) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) UserSignUp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSignUp", reflect.TypeOf((*MockUserUseCase)(nil).UserSignUp), arg0, arg1)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockUserUseCase) VerifyEmail(arg0 context.Context, arg1 string, arg2 models.VerifyEmail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserUseCaseMockRecorder) VerifyEmail(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSignUp", reflect.TypeOf((*MockUserUseCase)(nil).UserSignUp), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserUseCase)(nil).VerifyEmail), arg0, arg1, arg2)
 }
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"taskmanagementapi/pkg/logging"
+	"taskmanagementapi/pkg/oidc"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+const oidcStateTTL = 10 * time.Minute
+
+var (
+	errOIDCNotConfigured     = services.NotFound("sso_not_configured", "oidc login is not configured")
+	errOIDCInvalidState      = services.Unauthorized("invalid_login_state", "invalid login state")
+	errOIDCStateExpired      = services.Unauthorized("invalid_login_state", "login state expired")
+	errOIDCRejected          = services.Unauthorized("sso_failed", "identity provider did not confirm the login")
+	errOIDCEmailUnverified   = services.Forbidden("email_not_verified", "email is not verified by identity provider")
+	errOIDCAccountUnverified = services.Conflict("account_exists", "an account with this email already exists; sign in with its password and confirm the email first")
+)
+
+type oidcUseCase struct {
+	client            *oidc.Client
+	oidcRepository    interfaces.OIDCRepository
+	userRepository    interfaces.UserRepository
+	sessionRepository interfaces.SessionRepository
+}
+
+func NewOIDCUseCase(client *oidc.Client, oidcRepository interfaces.OIDCRepository, userRepository interfaces.UserRepository, sessionRepository interfaces.SessionRepository) services.OIDCUseCase {
+	return &oidcUseCase{
+		client:            client,
+		oidcRepository:    oidcRepository,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+	}
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (ou *oidcUseCase) LoginURL(ctx context.Context) (models.OIDCLogin, error) {
+	if !ou.client.Enabled() {
+		return models.OIDCLogin{}, errOIDCNotConfigured
+	}
+	var values [3]string
+	for i := range values {
+		value, err := randomToken()
+		if err != nil {
+			return models.OIDCLogin{}, errors.New("error from generating login state")
+		}
+		values[i] = value
+	}
+	state := models.OIDCState{
+		State:     values[0],
+		Verifier:  values[1],
+		Nonce:     values[2],
+		CreatedAt: time.Now().UTC(),
+	}
+	authURL, err := ou.client.AuthCodeURL(ctx, state.State, state.Nonce, state.Verifier)
+	if err != nil {
+		return models.OIDCLogin{}, err
+	}
+	if err := ou.oidcRepository.InsertState(ctx, state); err != nil {
+		return models.OIDCLogin{}, errors.New("error from insert login state")
+	}
+	return models.OIDCLogin{URL: authURL, State: state.State, ExpiresAt: state.CreatedAt.Add(oidcStateTTL)}, nil
+}
+
+func (ou *oidcUseCase) Callback(ctx context.Context, state, code string, client models.ClientInfo) (string, error) {
+	pending, err := ou.oidcRepository.ConsumeState(ctx, state)
+	if err != nil {
+		return "", errors.New("error in find login state")
+	}
+	if pending.State == "" {
+		return "", errOIDCInvalidState
+	}
+	if time.Since(pending.CreatedAt) > oidcStateTTL {
+		return "", errOIDCStateExpired
+	}
+	claims, err := ou.client.Exchange(ctx, code, pending.Verifier, pending.Nonce)
+	if errors.Is(err, oidc.ErrRejected) {
+		logging.FromContext(ctx).Warn("oidc exchange rejected", "error", err)
+		return "", errOIDCRejected
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("oidc exchange", "error", err)
+		return "", errors.New("error from token exchange")
+	}
+
+	user, err := ou.userRepository.FindUserByIdentity(ctx, ou.client.Issuer(), claims.Subject)
+	if err != nil {
+		return "", errors.New("error in find user details")
+	}
+	if user.ID == "" {
+		user, err = ou.linkOrProvision(ctx, claims)
+		if err != nil {
+			return "", err
+		}
+	}
+	if user.Disabled {
+		return "", errAccountDisabled
+	}
+	if user.Role == "" {
+		user.Role = models.RoleUser
+	}
+	return startSession(ctx, ou.userRepository, ou.sessionRepository, user, client)
+}
+
+// linkOrProvision attaches a first-time identity to the account with the same
+// email, or creates a new account for it. Either way the provider must have
+// verified the email, otherwise anyone could claim an existing account. The
+// local account must have verified it too: anyone can sign up with someone
+// else's email, and linking would then sign the owner into that account.
+func (ou *oidcUseCase) linkOrProvision(ctx context.Context, claims *oidc.Claims) (models.UserDetails, error) {
+	if claims.Email == "" || !claims.EmailVerified {
+		return models.UserDetails{}, errOIDCEmailUnverified
+	}
+	user, err := ou.userRepository.FindUserDetailsByEmail(ctx, claims.Email)
+	if err != nil {
+		return models.UserDetails{}, errors.New("error in find user details")
+	}
+	if user.ID != "" {
+		if !user.EmailVerified {
+			return models.UserDetails{}, errOIDCAccountUnverified
+		}
+		if err := ou.userRepository.LinkIdentity(ctx, user.ID, ou.client.Issuer(), claims.Subject); err != nil {
+			return models.UserDetails{}, errors.New("could not link identity")
+		}
+		return user, nil
+	}
+	name := claims.Name
+	if name == "" {
+		name = claims.Email
+	}
+	id, err := ou.userRepository.CreateOIDCUser(ctx, models.OIDCUser{
+		Name:    name,
+		Email:   claims.Email,
+		Issuer:  ou.client.Issuer(),
+		Subject: claims.Subject,
+	})
+	if err != nil {
+		return models.UserDetails{}, errors.New("could not add the user data")
+	}
+	return models.UserDetails{
+		ID:    id,
+		Name:  name,
+		Email: claims.Email,
+		Role:  models.RoleUser,
+	}, nil
+}
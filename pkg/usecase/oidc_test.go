@@ -0,0 +1,169 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/config"
+	"taskmanagementapi/pkg/oidc"
+	"taskmanagementapi/pkg/oidc/oidctest"
+	"taskmanagementapi/pkg/usecase"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	mockRepository "taskmanagementapi/pkg/repository/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OIDCCallback(t *testing.T) {
+	provider, err := oidctest.NewProvider("client", "secret")
+	require.NoError(t, err)
+	defer provider.Close()
+	client := oidc.NewClient(config.Config{
+		OIDCIssuer:       provider.Issuer(),
+		OIDCClientID:     "client",
+		OIDCClientSecret: "secret",
+		OIDCRedirectURL:  "http://localhost:3000/user/oidc/callback",
+	})
+
+	testData := map[string]struct {
+		user      oidctest.User
+		stub      func(*mockRepository.MockUserRepository)
+		wantToken string
+		wantErr   error
+	}{
+		"existing identity": {
+			user: oidctest.User{Subject: "sub-1", Email: "arun@example.com", EmailVerified: true},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByIdentity(gomock.Any(), provider.Issuer(), "sub-1").Return(models.UserDetails{ID: "u1", Email: "arun@example.com"}, nil)
+				userRepo.EXPECT().GenerateJwtToken(models.UserDetails{ID: "u1", Email: "arun@example.com", Role: models.RoleUser}, gomock.Any()).Return("jwt", time.Now().Add(time.Hour), nil)
+			},
+			wantToken: "jwt",
+		},
+		"links account by verified email": {
+			user: oidctest.User{Subject: "sub-1", Email: "arun@example.com", EmailVerified: true},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByIdentity(gomock.Any(), provider.Issuer(), "sub-1").Return(models.UserDetails{}, nil)
+				userRepo.EXPECT().FindUserDetailsByEmail(gomock.Any(), "arun@example.com").Return(models.UserDetails{ID: "u1", Email: "arun@example.com", Role: models.RoleAdmin, EmailVerified: true}, nil)
+				userRepo.EXPECT().LinkIdentity(gomock.Any(), "u1", provider.Issuer(), "sub-1").Return(nil)
+				userRepo.EXPECT().GenerateJwtToken(gomock.Any(), gomock.Any()).Return("jwt", time.Now().Add(time.Hour), nil)
+			},
+			wantToken: "jwt",
+		},
+		"unverified local account is not linked": {
+			user: oidctest.User{Subject: "sub-1", Email: "arun@example.com", EmailVerified: true},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByIdentity(gomock.Any(), provider.Issuer(), "sub-1").Return(models.UserDetails{}, nil)
+				userRepo.EXPECT().FindUserDetailsByEmail(gomock.Any(), "arun@example.com").Return(models.UserDetails{ID: "u1", Email: "arun@example.com", Password: "hash"}, nil)
+			},
+			wantErr: services.Conflict("account_exists", "an account with this email already exists; sign in with its password and confirm the email first"),
+		},
+		"provisions new account": {
+			user: oidctest.User{Subject: "sub-2", Email: "new@example.com", EmailVerified: true, Name: "New User"},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByIdentity(gomock.Any(), provider.Issuer(), "sub-2").Return(models.UserDetails{}, nil)
+				userRepo.EXPECT().FindUserDetailsByEmail(gomock.Any(), "new@example.com").Return(models.UserDetails{}, nil)
+				userRepo.EXPECT().CreateOIDCUser(gomock.Any(), models.OIDCUser{
+					Name:    "New User",
+					Email:   "new@example.com",
+					Issuer:  provider.Issuer(),
+					Subject: "sub-2",
+				}).Return("u2", nil)
+				userRepo.EXPECT().GenerateJwtToken(models.UserDetails{ID: "u2", Name: "New User", Email: "new@example.com", Role: models.RoleUser}, gomock.Any()).Return("jwt", time.Now().Add(time.Hour), nil)
+			},
+			wantToken: "jwt",
+		},
+		"unverified email is rejected": {
+			user: oidctest.User{Subject: "sub-3", Email: "arun@example.com", EmailVerified: false},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByIdentity(gomock.Any(), provider.Issuer(), "sub-3").Return(models.UserDetails{}, nil)
+			},
+			wantErr: services.Forbidden("email_not_verified", "email is not verified by identity provider"),
+		},
+		"disabled account": {
+			user: oidctest.User{Subject: "sub-1", Email: "arun@example.com", EmailVerified: true},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByIdentity(gomock.Any(), provider.Issuer(), "sub-1").Return(models.UserDetails{ID: "u1", Disabled: true}, nil)
+			},
+			wantErr: services.Forbidden("account_disabled", "account is disabled"),
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			oidcRepo := mockRepository.NewMockOIDCRepository(ctrl)
+			userRepo := mockRepository.NewMockUserRepository(ctrl)
+			sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+			sessionRepo.EXPECT().InsertSession(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			oidcUseCase := usecase.NewOIDCUseCase(client, oidcRepo, userRepo, sessionRepo)
+
+			var stored models.OIDCState
+			oidcRepo.EXPECT().InsertState(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, state models.OIDCState) error {
+				stored = state
+				return nil
+			})
+			login, err := oidcUseCase.LoginURL(context.Background())
+			require.NoError(t, err)
+			code, state, err := provider.Authorize(login.URL, test.user)
+			require.NoError(t, err)
+			assert.Equal(t, stored.State, state)
+			assert.Equal(t, stored.State, login.State)
+
+			oidcRepo.EXPECT().ConsumeState(gomock.Any(), state).Return(stored, nil)
+			test.stub(userRepo)
+			token, err := oidcUseCase.Callback(context.Background(), state, code, models.ClientInfo{IP: "10.0.0.1"})
+			if test.wantErr != nil {
+				assert.Equal(t, test.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantToken, token)
+		})
+	}
+}
+
+func Test_OIDCCallbackInvalidState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	oidcRepo := mockRepository.NewMockOIDCRepository(ctrl)
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	oidcUseCase := usecase.NewOIDCUseCase(oidc.NewClient(config.Config{}), oidcRepo, userRepo, nil)
+
+	oidcRepo.EXPECT().ConsumeState(gomock.Any(), "unknown").Return(models.OIDCState{}, nil)
+	_, err := oidcUseCase.Callback(context.Background(), "unknown", "code", models.ClientInfo{})
+	assert.Equal(t, services.Unauthorized("invalid_login_state", "invalid login state"), err)
+
+	oidcRepo.EXPECT().ConsumeState(gomock.Any(), "old").Return(models.OIDCState{State: "old", CreatedAt: time.Now().Add(-time.Hour)}, nil)
+	_, err = oidcUseCase.Callback(context.Background(), "old", "code", models.ClientInfo{})
+	assert.Equal(t, services.Unauthorized("invalid_login_state", "login state expired"), err)
+
+	oidcRepo.EXPECT().ConsumeState(gomock.Any(), "s").Return(models.OIDCState{}, errors.New("db error"))
+	_, err = oidcUseCase.Callback(context.Background(), "s", "code", models.ClientInfo{})
+	assert.EqualError(t, err, "error in find login state")
+}
+
+func Test_OIDCCallbackRejectedCode(t *testing.T) {
+	provider, err := oidctest.NewProvider("client", "secret")
+	require.NoError(t, err)
+	defer provider.Close()
+	client := oidc.NewClient(config.Config{
+		OIDCIssuer:       provider.Issuer(),
+		OIDCClientID:     "client",
+		OIDCClientSecret: "secret",
+		OIDCRedirectURL:  "http://localhost:3000/user/oidc/callback",
+	})
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	oidcRepo := mockRepository.NewMockOIDCRepository(ctrl)
+	oidcUseCase := usecase.NewOIDCUseCase(client, oidcRepo, nil, nil)
+
+	oidcRepo.EXPECT().ConsumeState(gomock.Any(), "s").Return(models.OIDCState{State: "s", Verifier: "v", Nonce: "n", CreatedAt: time.Now()}, nil)
+	_, err = oidcUseCase.Callback(context.Background(), "s", "forged-code", models.ClientInfo{})
+	assert.Equal(t, services.Unauthorized("sso_failed", "identity provider did not confirm the login"), err)
+}
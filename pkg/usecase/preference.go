@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+	_ "time/tzdata"
+
+	"golang.org/x/text/language"
+)
+
+type preferenceUseCase struct {
+	preferenceRepository interfaces.PreferenceRepository
+}
+
+func NewPreferenceUseCase(repository interfaces.PreferenceRepository) services.PreferenceUseCase {
+	return &preferenceUseCase{
+		preferenceRepository: repository,
+	}
+}
+
+// loadPreferences returns the stored preferences or the defaults for users
+// who never saved any.
+func loadPreferences(ctx context.Context, repository interfaces.PreferenceRepository, userID string) (models.Preferences, error) {
+	preferences, err := repository.GetPreferences(ctx, userID)
+	if err != nil {
+		return models.Preferences{}, errors.New("error from get preferences")
+	}
+	if preferences.Timezone == "" {
+		return models.DefaultPreferences(), nil
+	}
+	return preferences, nil
+}
+
+func (pu *preferenceUseCase) GetPreferences(ctx context.Context, userID string) (models.Preferences, error) {
+	return loadPreferences(ctx, pu.preferenceRepository, userID)
+}
+
+func (pu *preferenceUseCase) UpdatePreferences(ctx context.Context, userID string, preferences models.Preferences) (models.Preferences, error) {
+	// "Local" would resolve to the server's zone, which means nothing to the
+	// user, so only real IANA names are accepted.
+	if preferences.Timezone == "Local" {
+		return models.Preferences{}, errInvalidTimezone
+	}
+	if _, err := time.LoadLocation(preferences.Timezone); err != nil {
+		return models.Preferences{}, errInvalidTimezone
+	}
+	tag, err := language.Parse(preferences.Locale)
+	if err != nil {
+		return models.Preferences{}, errInvalidLocale
+	}
+	preferences.Locale = tag.String()
+	if err := pu.preferenceRepository.UpsertPreferences(ctx, userID, preferences); err != nil {
+		return models.Preferences{}, errors.New("error from update preferences")
+	}
+	return preferences, nil
+}
@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"context"
+	"taskmanagementapi/pkg/usecase"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	mockRepository "taskmanagementapi/pkg/repository/mock"
+	services "taskmanagementapi/pkg/usecase/interface"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	preferenceRepo := mockRepository.NewMockPreferenceRepository(ctrl)
+	preferenceUseCase := usecase.NewPreferenceUseCase(preferenceRepo)
+
+	preferenceRepo.EXPECT().GetPreferences(gomock.Any(), "user1").Return(models.Preferences{}, nil).Times(1)
+	preferences, err := preferenceUseCase.GetPreferences(context.Background(), "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultPreferences(), preferences)
+
+	stored := models.DefaultPreferences()
+	stored.Timezone = "Asia/Kolkata"
+	preferenceRepo.EXPECT().GetPreferences(gomock.Any(), "user1").Return(stored, nil).Times(1)
+	preferences, err = preferenceUseCase.GetPreferences(context.Background(), "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, stored, preferences)
+}
+
+func Test_UpdatePreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	preferenceRepo := mockRepository.NewMockPreferenceRepository(ctrl)
+	preferenceUseCase := usecase.NewPreferenceUseCase(preferenceRepo)
+
+	with := func(change func(*models.Preferences)) models.Preferences {
+		preferences := models.DefaultPreferences()
+		change(&preferences)
+		return preferences
+	}
+
+	invalidTimezone := services.InvalidInput("invalid_fields", "invalid timezone",
+		models.FieldError{Field: "timezone", Rule: "timezone", Message: "must be an IANA time zone name such as Europe/Berlin"})
+	invalidLocale := services.InvalidInput("invalid_fields", "invalid locale",
+		models.FieldError{Field: "locale", Rule: "bcp47", Message: "must be a BCP 47 language tag such as en-US"})
+
+	testData := map[string]struct {
+		input   models.Preferences
+		stored  models.Preferences
+		wantErr error
+	}{
+		"valid zone and locale": {
+			input:  with(func(p *models.Preferences) { p.Timezone = "America/New_York"; p.Locale = "en-us" }),
+			stored: with(func(p *models.Preferences) { p.Timezone = "America/New_York"; p.Locale = "en-US" }),
+		},
+		"unknown zone": {
+			input:   with(func(p *models.Preferences) { p.Timezone = "Mars/Olympus_Mons" }),
+			wantErr: invalidTimezone,
+		},
+		"server local zone": {
+			input:   with(func(p *models.Preferences) { p.Timezone = "Local" }),
+			wantErr: invalidTimezone,
+		},
+		"invalid locale": {
+			input:   with(func(p *models.Preferences) { p.Locale = "not a locale" }),
+			wantErr: invalidLocale,
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			if test.wantErr == nil {
+				preferenceRepo.EXPECT().UpsertPreferences(gomock.Any(), "user1", test.stored).Return(nil).Times(1)
+			}
+			preferences, err := preferenceUseCase.UpdatePreferences(context.Background(), "user1", test.input)
+			assert.Equal(t, test.wantErr, err)
+			if test.wantErr == nil {
+				assert.Equal(t, test.stored, preferences)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"taskmanagementapi/pkg/logging"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+// RateLimitPurgeInterval is how often Purge should run.
+const RateLimitPurgeInterval = 10 * time.Minute
+
+type rateLimitUseCase struct {
+	rateLimitRepository interfaces.RateLimitRepository
+}
+
+func NewRateLimitUseCase(repository interfaces.RateLimitRepository) services.RateLimitUseCase {
+	return &rateLimitUseCase{rateLimitRepository: repository}
+}
+
+// Allow takes a request from the bucket for key. If the store is unavailable
+// the request is let through with an empty result rather than failing the
+// API along with it; the error is logged, since requests then go unlimited.
+func (ru *rateLimitUseCase) Allow(ctx context.Context, key string, limit models.RateLimit) models.RateLimitResult {
+	result, err := ru.rateLimitRepository.Take(ctx, key, limit)
+	if err != nil {
+		logging.FromContext(ctx).Error("rate limit store unavailable, allowing request", "key", key, "error", err)
+		return models.RateLimitResult{Allowed: true}
+	}
+	return result
+}
+
+// Purge drops buckets that have refilled completely.
+func (ru *rateLimitUseCase) Purge(ctx context.Context) error {
+	return ru.rateLimitRepository.DeleteExpired(ctx)
+}
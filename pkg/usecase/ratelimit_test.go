@@ -0,0 +1,39 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/logging"
+	"taskmanagementapi/pkg/usecase"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	mockRepository "taskmanagementapi/pkg/repository/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RateLimitAllow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	rateLimitRepo := mockRepository.NewMockRateLimitRepository(ctrl)
+	rateLimitUseCase := usecase.NewRateLimitUseCase(rateLimitRepo)
+	limit := models.RateLimit{Burst: 5, Every: time.Second}
+
+	rejected := models.RateLimitResult{Limit: 5, RetryAfter: time.Second}
+	rateLimitRepo.EXPECT().Take(gomock.Any(), "k", limit).Return(rejected, nil).Times(1)
+	assert.Equal(t, rejected, rateLimitUseCase.Allow(context.Background(), "k", limit))
+
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "info")
+	require.NoError(t, err)
+	ctx := logging.WithContext(context.Background(), logger)
+	rateLimitRepo.EXPECT().Take(gomock.Any(), "k", limit).Return(models.RateLimitResult{}, errors.New("db error")).Times(1)
+	assert.Equal(t, models.RateLimitResult{Allowed: true}, rateLimitUseCase.Allow(ctx, "k", limit), "fails open")
+	assert.Contains(t, buf.String(), "rate limit store unavailable")
+	assert.Contains(t, buf.String(), "db error")
+}
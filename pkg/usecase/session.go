@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+// SessionFlushInterval is how often Flush should run.
+const SessionFlushInterval = time.Minute
+
+type sessionUseCase struct {
+	sessionRepository interfaces.SessionRepository
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func NewSessionUseCase(repository interfaces.SessionRepository) services.SessionUseCase {
+	return &sessionUseCase{
+		sessionRepository: repository,
+		seen:              make(map[string]time.Time),
+	}
+}
+
+// startSession records a session for a successful sign-in and returns a JWT
+// bound to it through the jti claim.
+func startSession(ctx context.Context, userRepository interfaces.UserRepository, sessionRepository interfaces.SessionRepository, user models.UserDetails, client models.ClientInfo) (string, error) {
+	jti, err := randomToken()
+	if err != nil {
+		return "", errors.New("couldn't create token")
+	}
+	token, expiresAt, err := userRepository.GenerateJwtToken(user, jti)
+	if err != nil {
+		return "", errors.New("couldn't create token")
+	}
+	err = sessionRepository.InsertSession(ctx, models.NewSession{
+		JTI:       jti,
+		UserID:    user.ID,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: expiresAt.UTC(),
+	})
+	if err != nil {
+		return "", errors.New("error from insert session")
+	}
+	return token, nil
+}
+
+func (su *sessionUseCase) GetSessions(ctx context.Context, userID, currentJTI string) ([]models.Session, error) {
+	sessions, err := su.sessionRepository.GetSessions(ctx, userID)
+	if err != nil {
+		return []models.Session{}, errors.New("error from get sessions")
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].JTI == currentJTI
+	}
+	return sessions, nil
+}
+
+func (su *sessionUseCase) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	deleted, err := su.sessionRepository.DeleteSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errSessionNotFound
+	}
+	return nil
+}
+
+// SignOut ends the session a sign-in JWT belongs to, so that the token stops
+// working even if it was copied out of the cookie.
+func (su *sessionUseCase) SignOut(ctx context.Context, jti string) error {
+	if err := su.sessionRepository.DeleteSessionByJTI(ctx, jti); err != nil {
+		return errors.New("error from delete session")
+	}
+	return nil
+}
+
+// Touch notes that the session was used. Nothing is written until the next
+// flush, so a busy session costs one write per interval instead of one per
+// request.
+func (su *sessionUseCase) Touch(jti string) {
+	su.mu.Lock()
+	su.seen[jti] = time.Now().UTC()
+	su.mu.Unlock()
+}
+
+// Flush writes the buffered last-seen times. It is best effort: last-seen is
+// informational, so a failed batch is dropped rather than retried.
+func (su *sessionUseCase) Flush(ctx context.Context) error {
+	su.mu.Lock()
+	batch := su.seen
+	su.seen = make(map[string]time.Time)
+	su.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+	return su.sessionRepository.TouchSessions(ctx, batch)
+}
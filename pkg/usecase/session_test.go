@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/usecase"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	mockRepository "taskmanagementapi/pkg/repository/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+	sessionUseCase := usecase.NewSessionUseCase(sessionRepo)
+
+	sessionRepo.EXPECT().GetSessions(gomock.Any(), "user1").Return([]models.Session{{ID: "s1", JTI: "jti1"}, {ID: "s2", JTI: "jti2"}}, nil).Times(1)
+	sessions, err := sessionUseCase.GetSessions(context.Background(), "user1", "jti2")
+	assert.NoError(t, err)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+
+	sessionRepo.EXPECT().GetSessions(gomock.Any(), "user1").Return(nil, errors.New("db error")).Times(1)
+	_, err = sessionUseCase.GetSessions(context.Background(), "user1", "jti2")
+	assert.EqualError(t, err, "error from get sessions")
+}
+
+func Test_RevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+	sessionUseCase := usecase.NewSessionUseCase(sessionRepo)
+
+	sessionRepo.EXPECT().DeleteSession(gomock.Any(), "user1", "s1").Return(true, nil).Times(1)
+	assert.NoError(t, sessionUseCase.RevokeSession(context.Background(), "user1", "s1"))
+
+	sessionRepo.EXPECT().DeleteSession(gomock.Any(), "user1", "s2").Return(false, nil).Times(1)
+	assert.Equal(t, services.NotFound("session_not_found", "session doesn't exist"), sessionUseCase.RevokeSession(context.Background(), "user1", "s2"))
+}
+
+func Test_SignOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+	sessionUseCase := usecase.NewSessionUseCase(sessionRepo)
+
+	sessionRepo.EXPECT().DeleteSessionByJTI(gomock.Any(), "jti1").Return(nil).Times(1)
+	assert.NoError(t, sessionUseCase.SignOut(context.Background(), "jti1"))
+
+	sessionRepo.EXPECT().DeleteSessionByJTI(gomock.Any(), "jti1").Return(errors.New("db error")).Times(1)
+	assert.EqualError(t, sessionUseCase.SignOut(context.Background(), "jti1"), "error from delete session")
+}
+
+func Test_TouchSessionBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+	sessionUseCase := usecase.NewSessionUseCase(sessionRepo)
+
+	sessionRepo.EXPECT().TouchSessions(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, seen map[string]time.Time) error {
+		assert.Len(t, seen, 2)
+		assert.Contains(t, seen, "jti1")
+		assert.Contains(t, seen, "jti2")
+		return nil
+	}).Times(1)
+
+	for i := 0; i < 5; i++ {
+		sessionUseCase.Touch("jti1")
+	}
+	sessionUseCase.Touch("jti2")
+
+	assert.NoError(t, sessionUseCase.Flush(context.Background()))
+	// The batch was taken, so there is nothing left to write.
+	assert.NoError(t, sessionUseCase.Flush(context.Background()))
+}
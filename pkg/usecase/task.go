@@ -1,113 +1,364 @@
 package usecase
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"taskmanagementapi/pkg/jsonpatch"
+	"taskmanagementapi/pkg/logging"
 	interfaces "taskmanagementapi/pkg/repository/interface"
 	services "taskmanagementapi/pkg/usecase/interface"
 	"taskmanagementapi/pkg/utils/models"
+	"taskmanagementapi/pkg/validation"
 )
 
 type TaskUseCase struct {
-	taskRepository interfaces.TaskRepository
+	taskRepository       interfaces.TaskRepository
+	preferenceRepository interfaces.PreferenceRepository
 }
 
-func NewTaskUseCase(repository interfaces.TaskRepository) services.TaskUseCase {
+func NewTaskUseCase(repository interfaces.TaskRepository, preferenceRepository interfaces.PreferenceRepository) services.TaskUseCase {
 	return &TaskUseCase{
-		taskRepository: repository,
+		taskRepository:       repository,
+		preferenceRepository: preferenceRepository,
 	}
 }
 
-func (tk *TaskUseCase) CreateTask(task models.CreateTask, userID string) error {
-	exist, err := tk.taskRepository.CheckUserIDExist(userID)
+func (tk *TaskUseCase) CreateTask(ctx context.Context, task models.CreateTask, userID string) (string, error) {
+	exist, err := tk.taskRepository.CheckUserIDExist(ctx, userID)
 	if !exist {
-		return errors.New("user doesn't exist")
+		return "", errUserNotFound
 	}
 	if err != nil {
-		return err
+		return "", err
+	}
+	if task.Project == "" {
+		preferences, err := loadPreferences(ctx, tk.preferenceRepository, userID)
+		if err != nil {
+			return "", err
+		}
+		task.Project = preferences.DefaultProject
 	}
-	err = tk.taskRepository.InsertTask(task, userID)
+	taskID, err := tk.taskRepository.InsertTask(ctx, task, userID)
 	if err != nil {
-		return errors.New("error from insert task")
+		logging.FromContext(ctx).Error("insert task", "error", err)
+		return "", errors.New("error from insert task")
 	}
-	return nil
+	return taskID, nil
 }
 
-func (tk *TaskUseCase) GetTasks(userID string) ([]models.TaskDetails, error) {
-	exist, err := tk.taskRepository.CheckUserIDExist(userID)
+func (tk *TaskUseCase) GetTasks(ctx context.Context, userID string, query models.TaskQuery) ([]models.TaskDetails, error) {
+	exist, err := tk.taskRepository.CheckUserIDExist(ctx, userID)
 	if !exist {
-		return []models.TaskDetails{}, errors.New("user doesn't exist")
+		return []models.TaskDetails{}, errUserNotFound
+	}
+	if err != nil {
+		return []models.TaskDetails{}, err
 	}
+	preferences, err := loadPreferences(ctx, tk.preferenceRepository, userID)
 	if err != nil {
 		return []models.TaskDetails{}, err
 	}
-	tasks, err := tk.taskRepository.GetTasks(userID)
+	if query.Sort == "" {
+		query.Sort = preferences.DefaultSort
+	}
+	if query.Limit == 0 {
+		query.Limit = preferences.PageSize
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	tasks, err := tk.taskRepository.ListTasks(ctx, userID, query)
 	if err != nil {
+		logging.FromContext(ctx).Error("list tasks", "error", err)
 		return []models.TaskDetails{}, errors.New("error from get tasks")
 	}
 	return tasks, nil
 }
 
-func (tk *TaskUseCase) GetTask(userID, taskID string) (models.TaskDetails, error) {
-	existUserID, err := tk.taskRepository.CheckUserIDExist(userID)
+func (tk *TaskUseCase) GetTask(ctx context.Context, userID, taskID string) (models.TaskDetails, error) {
+	existUserID, err := tk.taskRepository.CheckUserIDExist(ctx, userID)
 	if !existUserID {
-		return models.TaskDetails{}, errors.New("user doesn't exist")
+		return models.TaskDetails{}, errUserNotFound
 	}
 	if err != nil {
 		return models.TaskDetails{}, err
 	}
-	existTaskID, err := tk.taskRepository.CheckTaskIDExist(taskID)
-	if !existTaskID {
-		return models.TaskDetails{}, errors.New("task doesn't exist")
+	task, err := tk.taskRepository.GetTask(ctx, userID, taskID)
+	if errors.Is(err, interfaces.ErrNotFound) {
+		return models.TaskDetails{}, errTaskNotFound
 	}
 	if err != nil {
-		return models.TaskDetails{}, err
-	}
-	task, err := tk.taskRepository.GetTask(userID, taskID)
-	if err != nil {
+		logging.FromContext(ctx).Error("get task", "error", err)
 		return models.TaskDetails{}, errors.New("error from get task")
 	}
 	return task, nil
 }
 
-func (tk *TaskUseCase) UpdateTask(userID, taskID string, task models.CreateTask) error {
-	existUserID, err := tk.taskRepository.CheckUserIDExist(userID)
+func (tk *TaskUseCase) UpdateTask(ctx context.Context, userID, taskID string, task models.CreateTask) error {
+	existUserID, err := tk.taskRepository.CheckUserIDExist(ctx, userID)
 	if !existUserID {
-		return errors.New("user doesn't exist")
+		return errUserNotFound
 	}
 	if err != nil {
 		return err
 	}
-	existTaskID, err := tk.taskRepository.CheckTaskIDExist(taskID)
-	if !existTaskID {
-		return errors.New("task doesn't exist")
-	}
-	if err != nil {
-		return err
+	err = tk.taskRepository.Update(ctx, userID, taskID, task)
+	if errors.Is(err, interfaces.ErrNotFound) {
+		return errTaskNotFound
 	}
-	err = tk.taskRepository.Update(userID, taskID, task)
 	if err != nil {
+		logging.FromContext(ctx).Error("update task", "error", err)
 		return errors.New("error from update title")
 	}
 	return nil
 }
 
-func (tk *TaskUseCase) DeleteTask(userID, taskID string) error {
-	existUserID, err := tk.taskRepository.CheckUserIDExist(userID)
+// PatchTask applies a merge patch or JSON patch to the task, validates the
+// result like a full update and writes only the fields that changed.
+func (tk *TaskUseCase) PatchTask(ctx context.Context, userID, taskID string, patch models.TaskPatch) (models.TaskDetails, error) {
+	existUserID, err := tk.taskRepository.CheckUserIDExist(ctx, userID)
 	if !existUserID {
-		return errors.New("user doesn't exist")
+		return models.TaskDetails{}, errUserNotFound
+	}
+	if err != nil {
+		return models.TaskDetails{}, err
+	}
+	current, err := tk.taskRepository.GetTask(ctx, userID, taskID)
+	if errors.Is(err, interfaces.ErrNotFound) {
+		return models.TaskDetails{}, errTaskNotFound
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("get task", "error", err)
+		return models.TaskDetails{}, errors.New("error from get task")
+	}
+	patched, err := applyTaskPatch(current, patch)
+	if err != nil {
+		return models.TaskDetails{}, err
+	}
+	if err := validation.Struct(patched); err != nil {
+		return models.TaskDetails{}, err
+	}
+	changes := taskChanges(current, patched)
+	if len(changes.Set) == 0 && len(changes.Unset) == 0 {
+		return current, nil
+	}
+	err = tk.taskRepository.PatchTask(ctx, userID, taskID, changes)
+	if errors.Is(err, interfaces.ErrConflict) {
+		return models.TaskDetails{}, services.Conflict("task_modified", "task was changed by another request, fetch it and apply the patch again")
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("patch task", "error", err)
+		return models.TaskDetails{}, errors.New("error from patch task")
+	}
+	current.Title, current.Description, current.Project = patched.Title, patched.Description, patched.Project
+	return current, nil
+}
+
+func applyTaskPatch(current models.TaskDetails, patch models.TaskPatch) (models.CreateTask, error) {
+	doc, err := json.Marshal(models.CreateTask{Title: current.Title, Description: current.Description, Project: current.Project})
+	if err != nil {
+		return models.CreateTask{}, err
+	}
+	switch patch.ContentType {
+	case models.MergePatchContentType:
+		doc, err = jsonpatch.MergePatch(doc, patch.Patch)
+	case models.JSONPatchContentType:
+		doc, err = jsonpatch.Apply(doc, patch.Patch)
+	default:
+		return models.CreateTask{}, errUnsupportedPatch
+	}
+	if errors.Is(err, jsonpatch.ErrConflict) {
+		return models.CreateTask{}, services.Conflict("patch_conflict", err.Error())
+	}
+	if err != nil {
+		return models.CreateTask{}, services.InvalidInput("invalid_patch", err.Error())
+	}
+	var patched models.CreateTask
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patched); err != nil {
+		return models.CreateTask{}, services.Validation("invalid_patch_result", "patched task is not valid: "+err.Error())
+	}
+	return patched, nil
+}
+
+// taskChanges diffs two versions of a task. An empty project is stored by
+// leaving the field out, so clearing it becomes an $unset.
+func taskChanges(current models.TaskDetails, patched models.CreateTask) models.TaskChanges {
+	changes := models.TaskChanges{
+		Set:      map[string]string{},
+		Expected: models.CreateTask{Title: current.Title, Description: current.Description, Project: current.Project},
+	}
+	if patched.Title != current.Title {
+		changes.Set["title"] = patched.Title
+	}
+	if patched.Description != current.Description {
+		changes.Set["description"] = patched.Description
+	}
+	if patched.Project != current.Project {
+		if patched.Project == "" {
+			changes.Unset = append(changes.Unset, "project")
+		} else {
+			changes.Set["project"] = patched.Project
+		}
+	}
+	return changes
+}
+
+// BulkTasks validates each operation on its own, checks that updated and
+// deleted tasks belong to the user, and sends the remaining operations to the
+// repository in one bulk write. Failures are reported per item; the returned
+// error is only for failures that affect the whole request.
+func (tk *TaskUseCase) BulkTasks(ctx context.Context, userID string, bulk models.BulkTaskRequest) ([]models.BulkTaskResult, error) {
+	exist, err := tk.taskRepository.CheckUserIDExist(ctx, userID)
+	if !exist {
+		return nil, errUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDuplicateTaskIDs(bulk.Operations); err != nil {
+		return nil, err
+	}
+	ordered := bulk.Ordered == nil || *bulk.Ordered
+
+	results := make([]models.BulkTaskResult, len(bulk.Operations))
+	var taskIDs []string
+	needsDefaultProject := false
+	for i, op := range bulk.Operations {
+		results[i] = models.BulkTaskResult{Index: i, Op: op.Op, ID: op.ID, Err: validateBulkOperation(op)}
+		if results[i].Err != nil {
+			continue
+		}
+		if op.Op == models.BulkCreate {
+			needsDefaultProject = needsDefaultProject || op.Task.Project == ""
+		} else {
+			taskIDs = append(taskIDs, op.ID)
+		}
+	}
+	if len(taskIDs) > 0 {
+		found, err := tk.taskRepository.FindTaskIDs(ctx, userID, taskIDs)
+		if err != nil {
+			return nil, err
+		}
+		owned := make(map[string]bool, len(found))
+		for _, taskID := range found {
+			owned[taskID] = true
+		}
+		for i, op := range bulk.Operations {
+			if results[i].Err == nil && op.Op != models.BulkCreate && !owned[op.ID] {
+				results[i].Err = errTaskNotFound
+			}
+		}
+	}
+	var defaultProject string
+	if needsDefaultProject {
+		preferences, err := loadPreferences(ctx, tk.preferenceRepository, userID)
+		if err != nil {
+			return nil, err
+		}
+		defaultProject = preferences.DefaultProject
 	}
+
+	var writes []models.TaskWrite
+	var indexes []int
+	for i, op := range bulk.Operations {
+		if results[i].Err != nil {
+			if ordered {
+				for j := i + 1; j < len(results); j++ {
+					results[j].Err = errNotExecuted
+				}
+				break
+			}
+			continue
+		}
+		write := models.TaskWrite{Op: op.Op, ID: op.ID}
+		if op.Task != nil {
+			write.Task = *op.Task
+		}
+		if op.Op == models.BulkCreate && write.Task.Project == "" {
+			write.Task.Project = defaultProject
+		}
+		writes = append(writes, write)
+		indexes = append(indexes, i)
+	}
+	if len(writes) == 0 {
+		return results, nil
+	}
+	written, err := tk.taskRepository.BulkWriteTasks(ctx, userID, writes, ordered)
 	if err != nil {
+		logging.FromContext(ctx).Error("bulk write tasks", "error", err)
+		return nil, errors.New("error from bulk write tasks")
+	}
+	for w, i := range indexes {
+		results[i].ID = written[w].ID
+		switch {
+		case errors.Is(written[w].Err, interfaces.ErrNotExecuted):
+			results[i].Err = errNotExecuted
+		case errors.Is(written[w].Err, interfaces.ErrNotFound):
+			results[i].Err = errTaskNotFound
+		case written[w].Err != nil:
+			logging.FromContext(ctx).Error("bulk write task", "index", i, "error", written[w].Err)
+			results[i].Err = errors.New("error from bulk write tasks")
+		}
+	}
+	return results, nil
+}
+
+// checkDuplicateTaskIDs rejects a batch that updates or deletes a task more
+// than once, since its writes could not be told apart in the bulk result.
+func checkDuplicateTaskIDs(operations []models.BulkTaskOperation) error {
+	seen := make(map[string]bool, len(operations))
+	for i, op := range operations {
+		if op.Op == models.BulkCreate || op.ID == "" {
+			continue
+		}
+		if seen[op.ID] {
+			return services.InvalidInput("duplicate_task_id", "a task can appear only once in a bulk request",
+				models.FieldError{Field: fmt.Sprintf("operations[%d].id", i), Rule: "unique", Message: "is used by an earlier operation"})
+		}
+		seen[op.ID] = true
+	}
+	return nil
+}
+
+// validateBulkOperation checks the fields each op needs. The validator also
+// descends into Task, reporting its fields as task.title and so on.
+func validateBulkOperation(op models.BulkTaskOperation) error {
+	if err := validation.Struct(op); err != nil {
 		return err
 	}
-	existTaskID, err := tk.taskRepository.CheckTaskIDExist(taskID)
-	if !existTaskID {
-		return errors.New("task doesn't exist")
+	if op.Op != models.BulkCreate && op.ID == "" {
+		return services.InvalidInput("invalid_fields", "one or more fields are invalid",
+			models.FieldError{Field: "id", Rule: "required", Message: "is required"})
+	}
+	if op.Op == models.BulkDelete {
+		return nil
+	}
+	if op.Task == nil {
+		return services.InvalidInput("invalid_fields", "one or more fields are invalid",
+			models.FieldError{Field: "task", Rule: "required", Message: "is required"})
+	}
+	return nil
+}
+
+func (tk *TaskUseCase) DeleteTask(ctx context.Context, userID, taskID string) error {
+	existUserID, err := tk.taskRepository.CheckUserIDExist(ctx, userID)
+	if !existUserID {
+		return errUserNotFound
 	}
 	if err != nil {
 		return err
 	}
-	err = tk.taskRepository.DeleteTask(userID, taskID)
+	err = tk.taskRepository.DeleteTask(ctx, userID, taskID)
+	if errors.Is(err, interfaces.ErrNotFound) {
+		return errTaskNotFound
+	}
 	if err != nil {
+		logging.FromContext(ctx).Error("delete task", "error", err)
 		return errors.New("error from delete task")
 	}
 	return nil
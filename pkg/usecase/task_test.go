@@ -1,12 +1,15 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"taskmanagementapi/pkg/usecase"
 	"taskmanagementapi/pkg/utils/models"
 	"testing"
 
+	interfaces "taskmanagementapi/pkg/repository/interface"
 	mockRepository "taskmanagementapi/pkg/repository/mock" 
+	services "taskmanagementapi/pkg/usecase/interface"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -17,7 +20,8 @@ func Test_CreateTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	taskRepo := mockRepository.NewMockTaskRepository(ctrl)
-	taskUseCase := usecase.NewTaskUseCase(taskRepo)
+	preferenceRepo := mockRepository.NewMockPreferenceRepository(ctrl)
+	taskUseCase := usecase.NewTaskUseCase(taskRepo, preferenceRepo)
 
 	testData := map[string]struct {
 		input   models.CreateTask
@@ -32,8 +36,38 @@ func Test_CreateTask(t *testing.T) {
 			},
 			userID: "123",
 			stub: func(repo *mockRepository.MockTaskRepository, task models.CreateTask, userID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(true, nil).Times(1)
-				repo.EXPECT().InsertTask(task, userID).Return(nil).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				preferenceRepo.EXPECT().GetPreferences(gomock.Any(), userID).Return(models.Preferences{}, nil).Times(1)
+				repo.EXPECT().InsertTask(gomock.Any(), task, userID).Return("t1", nil).Times(1)
+			},
+			wantErr: nil,
+		},
+		"default project from preferences": {
+			input: models.CreateTask{
+				Title:       "New Task",
+				Description: "Task description",
+			},
+			userID: "123",
+			stub: func(repo *mockRepository.MockTaskRepository, task models.CreateTask, userID string) {
+				preferences := models.DefaultPreferences()
+				preferences.DefaultProject = "home"
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				preferenceRepo.EXPECT().GetPreferences(gomock.Any(), userID).Return(preferences, nil).Times(1)
+				task.Project = "home"
+				repo.EXPECT().InsertTask(gomock.Any(), task, userID).Return("t1", nil).Times(1)
+			},
+			wantErr: nil,
+		},
+		"explicit project is kept": {
+			input: models.CreateTask{
+				Title:       "New Task",
+				Description: "Task description",
+				Project:     "work",
+			},
+			userID: "123",
+			stub: func(repo *mockRepository.MockTaskRepository, task models.CreateTask, userID string) {
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				repo.EXPECT().InsertTask(gomock.Any(), task, userID).Return("t1", nil).Times(1)
 			},
 			wantErr: nil,
 		},
@@ -44,9 +78,9 @@ func Test_CreateTask(t *testing.T) {
 			},
 			userID: "123",
 			stub: func(repo *mockRepository.MockTaskRepository, task models.CreateTask, userID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(false, nil).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(false, nil).Times(1)
 			},
-			wantErr: errors.New("user doesn't exist"),
+			wantErr: services.NotFound("user_not_found", "user doesn't exist"),
 		},
 		"repository error": {
 			input: models.CreateTask{
@@ -55,8 +89,9 @@ func Test_CreateTask(t *testing.T) {
 			},
 			userID: "123",
 			stub: func(repo *mockRepository.MockTaskRepository, task models.CreateTask, userID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(true, nil).Times(1) 
-				repo.EXPECT().InsertTask(task, userID).Return(errors.New("error from insert task")).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1) 
+				preferenceRepo.EXPECT().GetPreferences(gomock.Any(), userID).Return(models.Preferences{}, nil).Times(1)
+				repo.EXPECT().InsertTask(gomock.Any(), task, userID).Return("", errors.New("error from insert task")).Times(1)
 			},
 			wantErr: errors.New("error from insert task"),
 		},
@@ -65,8 +100,11 @@ func Test_CreateTask(t *testing.T) {
 	for testName, test := range testData {
 		t.Run(testName, func(t *testing.T) {
 			test.stub(taskRepo, test.input, test.userID)
-			err := taskUseCase.CreateTask(test.input, test.userID)
+			taskID, err := taskUseCase.CreateTask(context.Background(), test.input, test.userID)
 			assert.Equal(t, test.wantErr, err)
+			if err == nil {
+				assert.Equal(t, "t1", taskID)
+			}
 		})
 	}
 }
@@ -77,10 +115,13 @@ func Test_GetTasks(t *testing.T) {
 	defer ctrl.Finish()
 
 	taskRepo := mockRepository.NewMockTaskRepository(ctrl)
-	taskUseCase := usecase.NewTaskUseCase(taskRepo)
+	preferenceRepo := mockRepository.NewMockPreferenceRepository(ctrl)
+	taskUseCase := usecase.NewTaskUseCase(taskRepo, preferenceRepo)
+	defaultQuery := models.TaskQuery{Sort: "-created_at", Page: 1, Limit: 20}
 
 	testData := map[string]struct {
 		userID  string
+		query   models.TaskQuery
 		stub    func(*mockRepository.MockTaskRepository, string)
 		want    []models.TaskDetails
 		wantErr error
@@ -88,8 +129,9 @@ func Test_GetTasks(t *testing.T) {
 		"success": {
 			userID: "123",
 			stub: func(repo *mockRepository.MockTaskRepository, userID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(true, nil).Times(1)
-				repo.EXPECT().GetTasks(userID).Return([]models.TaskDetails{
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				preferenceRepo.EXPECT().GetPreferences(gomock.Any(), userID).Return(models.Preferences{}, nil).Times(1)
+				repo.EXPECT().ListTasks(gomock.Any(), userID, defaultQuery).Return([]models.TaskDetails{
 					{Title: "Task1", Description: "Desc1"},
 					{Title: "Task2", Description: "Desc2"},
 				}, nil).Times(1)
@@ -100,19 +142,34 @@ func Test_GetTasks(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		"stored preferences fill missing params": {
+			userID: "123",
+			query:  models.TaskQuery{Sort: "title", Page: 2},
+			stub: func(repo *mockRepository.MockTaskRepository, userID string) {
+				preferences := models.DefaultPreferences()
+				preferences.DefaultSort = "created_at"
+				preferences.PageSize = 50
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				preferenceRepo.EXPECT().GetPreferences(gomock.Any(), userID).Return(preferences, nil).Times(1)
+				repo.EXPECT().ListTasks(gomock.Any(), userID, models.TaskQuery{Sort: "title", Page: 2, Limit: 50}).Return([]models.TaskDetails{}, nil).Times(1)
+			},
+			want:    []models.TaskDetails{},
+			wantErr: nil,
+		},
 		"user does not exist": {
 			userID: "123",
 			stub: func(repo *mockRepository.MockTaskRepository, userID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(false, nil).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(false, nil).Times(1)
 			},
 			want:    []models.TaskDetails{},
-			wantErr: errors.New("user doesn't exist"),
+			wantErr: services.NotFound("user_not_found", "user doesn't exist"),
 		},
 		"repository error": {
 			userID: "123",
 			stub: func(repo *mockRepository.MockTaskRepository, userID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(true, nil).Times(1)
-				repo.EXPECT().GetTasks(userID).Return([]models.TaskDetails{}, errors.New("error from get tasks")).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				preferenceRepo.EXPECT().GetPreferences(gomock.Any(), userID).Return(models.Preferences{}, nil).Times(1)
+				repo.EXPECT().ListTasks(gomock.Any(), userID, defaultQuery).Return([]models.TaskDetails{}, errors.New("error from get tasks")).Times(1)
 			},
 			want:    []models.TaskDetails{},
 			wantErr: errors.New("error from get tasks"),
@@ -122,7 +179,7 @@ func Test_GetTasks(t *testing.T) {
 	for testName, test := range testData {
 		t.Run(testName, func(t *testing.T) {
 			test.stub(taskRepo, test.userID)
-			tasks, err := taskUseCase.GetTasks(test.userID)
+			tasks, err := taskUseCase.GetTasks(context.Background(), test.userID, test.query)
 			assert.Equal(t, test.want, tasks)
 			assert.Equal(t, test.wantErr, err)
 		})
@@ -134,7 +191,7 @@ func Test_GetTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	taskRepo := mockRepository.NewMockTaskRepository(ctrl)
-	taskUseCase := usecase.NewTaskUseCase(taskRepo)
+	taskUseCase := usecase.NewTaskUseCase(taskRepo, nil)
 
 	testData := map[string]struct {
 		userID  string
@@ -147,9 +204,8 @@ func Test_GetTask(t *testing.T) {
 			userID: "123",
 			taskID: "456",
 			stub: func(repo *mockRepository.MockTaskRepository, userID string, taskID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(true, nil).Times(1)
-				repo.EXPECT().CheckTaskIDExist(taskID).Return(true, nil).Times(1)
-				repo.EXPECT().GetTask(userID, taskID).Return(models.TaskDetails{
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				repo.EXPECT().GetTask(gomock.Any(), userID, taskID).Return(models.TaskDetails{
 					Title:       "Task1",
 					Description: "Desc1",
 				}, nil).Times(1)
@@ -164,27 +220,27 @@ func Test_GetTask(t *testing.T) {
 			userID: "123",
 			taskID: "456",
 			stub: func(repo *mockRepository.MockTaskRepository, userID string, taskID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(false, nil).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(false, nil).Times(1)
 			},
 			want:    models.TaskDetails{},
-			wantErr: errors.New("user doesn't exist"),
+			wantErr: services.NotFound("user_not_found", "user doesn't exist"),
 		},
-		"task does not exist": {
+		"task owned by another user": {
 			userID: "123",
 			taskID: "456",
 			stub: func(repo *mockRepository.MockTaskRepository, userID string, taskID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(true, nil).Times(1)
-				repo.EXPECT().CheckTaskIDExist(taskID).Return(false, nil).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				repo.EXPECT().GetTask(gomock.Any(), userID, taskID).Return(models.TaskDetails{}, interfaces.ErrNotFound).Times(1)
 			},
 			want:    models.TaskDetails{},
-			wantErr: errors.New("task doesn't exist"),
+			wantErr: services.NotFound("task_not_found", "task doesn't exist"),
 		},
 	}
 
 	for testName, test := range testData {
 		t.Run(testName, func(t *testing.T) {
 			test.stub(taskRepo, test.userID, test.taskID)
-			task, err := taskUseCase.GetTask(test.userID, test.taskID)
+			task, err := taskUseCase.GetTask(context.Background(), test.userID, test.taskID)
 			assert.Equal(t, test.want, task)
 			assert.Equal(t, test.wantErr, err)
 		})
@@ -196,7 +252,7 @@ func Test_UpdateTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	taskRepo := mockRepository.NewMockTaskRepository(ctrl)
-	taskUseCase := usecase.NewTaskUseCase(taskRepo)
+	taskUseCase := usecase.NewTaskUseCase(taskRepo, nil)
 
 	testData := map[string]struct {
 		userID  string
@@ -213,9 +269,8 @@ func Test_UpdateTask(t *testing.T) {
 				Description: "Updated Description",
 			},
 			stub: func(repo *mockRepository.MockTaskRepository, userID string, taskID string, task models.CreateTask) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(true, nil).Times(1)
-				repo.EXPECT().CheckTaskIDExist(taskID).Return(true, nil).Times(1)
-				repo.EXPECT().Update(userID, taskID, task).Return(nil).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				repo.EXPECT().Update(gomock.Any(), userID, taskID, task).Return(nil).Times(1)
 			},
 			wantErr: nil,
 		},
@@ -227,27 +282,248 @@ func Test_UpdateTask(t *testing.T) {
 				Description: "Updated Description",
 			},
 			stub: func(repo *mockRepository.MockTaskRepository, userID string, taskID string, task models.CreateTask) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(false, nil).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(false, nil).Times(1)
 			},
-			wantErr: errors.New("user doesn't exist"),
+			wantErr: services.NotFound("user_not_found", "user doesn't exist"),
+		},
+		"task owned by another user": {
+			userID: "123",
+			taskID: "456",
+			input: models.CreateTask{
+				Title:       "Updated Task",
+				Description: "Updated Description",
+			},
+			stub: func(repo *mockRepository.MockTaskRepository, userID string, taskID string, task models.CreateTask) {
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				repo.EXPECT().Update(gomock.Any(), userID, taskID, task).Return(interfaces.ErrNotFound).Times(1)
+			},
+			wantErr: services.NotFound("task_not_found", "task doesn't exist"),
 		},
 	}
 
 	for testName, test := range testData {
 		t.Run(testName, func(t *testing.T) {
 			test.stub(taskRepo, test.userID, test.taskID, test.input)
-			err := taskUseCase.UpdateTask(test.userID, test.taskID, test.input)
+			err := taskUseCase.UpdateTask(context.Background(), test.userID, test.taskID, test.input)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
 }
 
+func Test_PatchTask(t *testing.T) {
+	current := models.TaskDetails{ID: "456", Title: "Task", Description: "Description", Project: "home"}
+	expected := models.CreateTask{Title: "Task", Description: "Description", Project: "home"}
+
+	testData := map[string]struct {
+		patch   models.TaskPatch
+		stub    func(*mockRepository.MockTaskRepository)
+		want    models.TaskDetails
+		wantErr error
+	}{
+		"merge patch": {
+			patch: models.TaskPatch{ContentType: models.MergePatchContentType, Patch: []byte(`{"title":"New title","project":null}`)},
+			stub: func(repo *mockRepository.MockTaskRepository) {
+				repo.EXPECT().PatchTask(gomock.Any(), "123", "456", models.TaskChanges{
+					Set:      map[string]string{"title": "New title"},
+					Unset:    []string{"project"},
+					Expected: expected,
+				}).Return(nil).Times(1)
+			},
+			want: models.TaskDetails{ID: "456", Title: "New title", Description: "Description"},
+		},
+		"json patch": {
+			patch: models.TaskPatch{ContentType: models.JSONPatchContentType, Patch: []byte(`[{"op":"test","path":"/title","value":"Task"},{"op":"replace","path":"/description","value":"Changed"}]`)},
+			stub: func(repo *mockRepository.MockTaskRepository) {
+				repo.EXPECT().PatchTask(gomock.Any(), "123", "456", models.TaskChanges{Set: map[string]string{"description": "Changed"}, Expected: expected}).Return(nil).Times(1)
+			},
+			want: models.TaskDetails{ID: "456", Title: "Task", Description: "Changed", Project: "home"},
+		},
+		"changed concurrently": {
+			patch: models.TaskPatch{ContentType: models.MergePatchContentType, Patch: []byte(`{"title":"New title"}`)},
+			stub: func(repo *mockRepository.MockTaskRepository) {
+				repo.EXPECT().PatchTask(gomock.Any(), "123", "456", gomock.Any()).Return(interfaces.ErrConflict).Times(1)
+			},
+			wantErr: services.ErrConflict,
+		},
+		"no changes": {
+			patch: models.TaskPatch{ContentType: models.MergePatchContentType, Patch: []byte(`{"title":"Task"}`)},
+			stub:  func(repo *mockRepository.MockTaskRepository) {},
+			want:  current,
+		},
+		"failed test op": {
+			patch:   models.TaskPatch{ContentType: models.JSONPatchContentType, Patch: []byte(`[{"op":"test","path":"/title","value":"Other"}]`)},
+			stub:    func(repo *mockRepository.MockTaskRepository) {},
+			wantErr: services.ErrConflict,
+		},
+		"malformed patch": {
+			patch:   models.TaskPatch{ContentType: models.JSONPatchContentType, Patch: []byte(`{"op":"add"}`)},
+			stub:    func(repo *mockRepository.MockTaskRepository) {},
+			wantErr: services.InvalidInput("invalid_patch", ""),
+		},
+		"result fails validation": {
+			patch:   models.TaskPatch{ContentType: models.MergePatchContentType, Patch: []byte(`{"title":null}`)},
+			stub:    func(repo *mockRepository.MockTaskRepository) {},
+			wantErr: services.InvalidInput("invalid_fields", ""),
+		},
+		"unknown field": {
+			patch:   models.TaskPatch{ContentType: models.MergePatchContentType, Patch: []byte(`{"done":true}`)},
+			stub:    func(repo *mockRepository.MockTaskRepository) {},
+			wantErr: services.ErrValidation,
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepo := mockRepository.NewMockTaskRepository(ctrl)
+			taskRepo.EXPECT().CheckUserIDExist(gomock.Any(), "123").Return(true, nil).Times(1)
+			taskRepo.EXPECT().GetTask(gomock.Any(), "123", "456").Return(current, nil).Times(1)
+			test.stub(taskRepo)
+
+			taskUseCase := usecase.NewTaskUseCase(taskRepo, nil)
+			task, err := taskUseCase.PatchTask(context.Background(), "123", "456", test.patch)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, task)
+		})
+	}
+}
+
+func Test_BulkTasks(t *testing.T) {
+	task := &models.CreateTask{Title: "Task", Description: "Description"}
+	unordered := false
+	preferences := models.DefaultPreferences()
+	preferences.DefaultProject = "home"
+
+	testData := map[string]struct {
+		input      models.BulkTaskRequest
+		stub       func(*mockRepository.MockTaskRepository, *mockRepository.MockPreferenceRepository)
+		wantIDs    []string
+		wantErrors []error
+		wantErr    error
+	}{
+		"all operations succeed": {
+			input: models.BulkTaskRequest{Operations: []models.BulkTaskOperation{
+				{Op: models.BulkCreate, Task: task},
+				{Op: models.BulkUpdate, ID: "t1", Task: task},
+				{Op: models.BulkDelete, ID: "t2"},
+			}},
+			stub: func(repo *mockRepository.MockTaskRepository, preferenceRepo *mockRepository.MockPreferenceRepository) {
+				repo.EXPECT().FindTaskIDs(gomock.Any(), "123", []string{"t1", "t2"}).Return([]string{"t1", "t2"}, nil).Times(1)
+				preferenceRepo.EXPECT().GetPreferences(gomock.Any(), "123").Return(preferences, nil).Times(1)
+				repo.EXPECT().BulkWriteTasks(gomock.Any(), "123", []models.TaskWrite{
+					{Op: models.BulkCreate, Task: models.CreateTask{Title: "Task", Description: "Description", Project: "home"}},
+					{Op: models.BulkUpdate, ID: "t1", Task: *task},
+					{Op: models.BulkDelete, ID: "t2"},
+				}, true).Return([]models.TaskWriteResult{{ID: "new"}, {ID: "t1"}, {ID: "t2"}}, nil).Times(1)
+			},
+			wantIDs:    []string{"new", "t1", "t2"},
+			wantErrors: []error{nil, nil, nil},
+		},
+		"ordered stops at invalid operation": {
+			input: models.BulkTaskRequest{Operations: []models.BulkTaskOperation{
+				{Op: models.BulkDelete, ID: "t1"},
+				{Op: models.BulkUpdate, ID: "t2"},
+				{Op: models.BulkDelete, ID: "t3"},
+			}},
+			stub: func(repo *mockRepository.MockTaskRepository, preferenceRepo *mockRepository.MockPreferenceRepository) {
+				repo.EXPECT().FindTaskIDs(gomock.Any(), "123", []string{"t1", "t3"}).Return([]string{"t1", "t3"}, nil).Times(1)
+				repo.EXPECT().BulkWriteTasks(gomock.Any(), "123", []models.TaskWrite{{Op: models.BulkDelete, ID: "t1"}}, true).
+					Return([]models.TaskWriteResult{{ID: "t1"}}, nil).Times(1)
+			},
+			wantIDs:    []string{"t1", "t2", "t3"},
+			wantErrors: []error{nil, services.ErrInvalidInput, services.Conflict("not_executed", "")},
+		},
+		"unordered skips only failed operations": {
+			input: models.BulkTaskRequest{Ordered: &unordered, Operations: []models.BulkTaskOperation{
+				{Op: models.BulkDelete, ID: "missing"},
+				{Op: models.BulkCreate, Task: &models.CreateTask{Title: "Task", Description: "Description", Project: "work"}},
+				{Op: models.BulkDelete, ID: "t1"},
+			}},
+			stub: func(repo *mockRepository.MockTaskRepository, preferenceRepo *mockRepository.MockPreferenceRepository) {
+				repo.EXPECT().FindTaskIDs(gomock.Any(), "123", []string{"missing", "t1"}).Return([]string{"t1"}, nil).Times(1)
+				repo.EXPECT().BulkWriteTasks(gomock.Any(), "123", []models.TaskWrite{
+					{Op: models.BulkCreate, Task: models.CreateTask{Title: "Task", Description: "Description", Project: "work"}},
+					{Op: models.BulkDelete, ID: "t1"},
+				}, false).Return([]models.TaskWriteResult{{ID: "new"}, {ID: "t1", Err: errors.New("write failed")}}, nil).Times(1)
+			},
+			wantIDs:    []string{"missing", "new", "t1"},
+			wantErrors: []error{services.NotFound("task_not_found", ""), nil, errors.New("error from bulk write tasks")},
+		},
+		"task deleted before the write": {
+			input: models.BulkTaskRequest{Operations: []models.BulkTaskOperation{{Op: models.BulkUpdate, ID: "t1", Task: task}}},
+			stub: func(repo *mockRepository.MockTaskRepository, preferenceRepo *mockRepository.MockPreferenceRepository) {
+				repo.EXPECT().FindTaskIDs(gomock.Any(), "123", []string{"t1"}).Return([]string{"t1"}, nil).Times(1)
+				repo.EXPECT().BulkWriteTasks(gomock.Any(), "123", gomock.Any(), true).
+					Return([]models.TaskWriteResult{{ID: "t1", Err: interfaces.ErrNotFound}}, nil).Times(1)
+			},
+			wantIDs:    []string{"t1"},
+			wantErrors: []error{services.NotFound("task_not_found", "")},
+		},
+		"duplicate task IDs": {
+			input: models.BulkTaskRequest{Operations: []models.BulkTaskOperation{
+				{Op: models.BulkUpdate, ID: "t1", Task: task},
+				{Op: models.BulkDelete, ID: "t1"},
+			}},
+			stub: func(repo *mockRepository.MockTaskRepository, preferenceRepo *mockRepository.MockPreferenceRepository) {},
+			wantErr: services.InvalidInput("duplicate_task_id", "a task can appear only once in a bulk request",
+				models.FieldError{Field: "operations[1].id", Rule: "unique", Message: "is used by an earlier operation"}),
+		},
+		"bulk write fails": {
+			input: models.BulkTaskRequest{Operations: []models.BulkTaskOperation{{Op: models.BulkDelete, ID: "t1"}}},
+			stub: func(repo *mockRepository.MockTaskRepository, preferenceRepo *mockRepository.MockPreferenceRepository) {
+				repo.EXPECT().FindTaskIDs(gomock.Any(), "123", []string{"t1"}).Return([]string{"t1"}, nil).Times(1)
+				repo.EXPECT().BulkWriteTasks(gomock.Any(), "123", gomock.Any(), true).Return(nil, errors.New("connection lost")).Times(1)
+			},
+			wantErr: errors.New("error from bulk write tasks"),
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepo := mockRepository.NewMockTaskRepository(ctrl)
+			preferenceRepo := mockRepository.NewMockPreferenceRepository(ctrl)
+			taskRepo.EXPECT().CheckUserIDExist(gomock.Any(), "123").Return(true, nil).Times(1)
+			test.stub(taskRepo, preferenceRepo)
+
+			taskUseCase := usecase.NewTaskUseCase(taskRepo, preferenceRepo)
+			results, err := taskUseCase.BulkTasks(context.Background(), "123", test.input)
+			if test.wantErr != nil {
+				assert.Equal(t, test.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, results, len(test.wantIDs))
+			for i, result := range results {
+				assert.Equal(t, i, result.Index)
+				assert.Equal(t, test.wantIDs[i], result.ID)
+				switch want := test.wantErrors[i]; {
+				case want == nil:
+					assert.NoError(t, result.Err)
+				case errors.Is(want, services.ErrNotFound), errors.Is(want, services.ErrInvalidInput), errors.Is(want, services.ErrConflict):
+					assert.ErrorIs(t, result.Err, want)
+				default:
+					assert.Equal(t, want, result.Err)
+				}
+			}
+		})
+	}
+}
+
 func Test_DeleteTask(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	taskRepo := mockRepository.NewMockTaskRepository(ctrl)
-	taskUseCase := usecase.NewTaskUseCase(taskRepo)
+	taskUseCase := usecase.NewTaskUseCase(taskRepo, nil)
 
 	testData := map[string]struct {
 		userID  string
@@ -259,9 +535,8 @@ func Test_DeleteTask(t *testing.T) {
 			userID: "123",
 			taskID: "456",
 			stub: func(repo *mockRepository.MockTaskRepository, userID string, taskID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(true, nil).Times(1)
-				repo.EXPECT().CheckTaskIDExist(taskID).Return(true, nil).Times(1)
-				repo.EXPECT().DeleteTask(userID, taskID).Return(nil).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				repo.EXPECT().DeleteTask(gomock.Any(), userID, taskID).Return(nil).Times(1)
 			},
 			wantErr: nil,
 		},
@@ -269,16 +544,25 @@ func Test_DeleteTask(t *testing.T) {
 			userID: "123",
 			taskID: "456",
 			stub: func(repo *mockRepository.MockTaskRepository, userID string, taskID string) {
-				repo.EXPECT().CheckUserIDExist(userID).Return(false, nil).Times(1)
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(false, nil).Times(1)
+			},
+			wantErr: services.NotFound("user_not_found", "user doesn't exist"),
+		},
+		"task owned by another user": {
+			userID: "123",
+			taskID: "456",
+			stub: func(repo *mockRepository.MockTaskRepository, userID string, taskID string) {
+				repo.EXPECT().CheckUserIDExist(gomock.Any(), userID).Return(true, nil).Times(1)
+				repo.EXPECT().DeleteTask(gomock.Any(), userID, taskID).Return(interfaces.ErrNotFound).Times(1)
 			},
-			wantErr: errors.New("user doesn't exist"),
+			wantErr: services.NotFound("task_not_found", "task doesn't exist"),
 		},
 	}
 
 	for testName, test := range testData {
 		t.Run(testName, func(t *testing.T) {
 			test.stub(taskRepo, test.userID, test.taskID)
-			err := taskUseCase.DeleteTask(test.userID, test.taskID)
+			err := taskUseCase.DeleteTask(context.Background(), test.userID, test.taskID)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/helper"
+	"taskmanagementapi/pkg/keyset"
+	interfaces "taskmanagementapi/pkg/repository/interface"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"time"
+)
+
+type tokenUseCase struct {
+	tokenRepository   interfaces.TokenRepository
+	userRepository    interfaces.UserRepository
+	sessionRepository interfaces.SessionRepository
+	keySet            *keyset.KeySet
+}
+
+func NewTokenUseCase(repository interfaces.TokenRepository, userRepository interfaces.UserRepository, sessionRepository interfaces.SessionRepository, keySet *keyset.KeySet) services.TokenUseCase {
+	return &tokenUseCase{
+		tokenRepository:   repository,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		keySet:            keySet,
+	}
+}
+
+func (tu *tokenUseCase) CreateToken(ctx context.Context, userID string, token models.CreateToken) (models.CreatedToken, error) {
+	plain, err := helper.GenerateAccessToken()
+	if err != nil {
+		return models.CreatedToken{}, err
+	}
+	newToken := models.NewToken{
+		UserID:    userID,
+		Name:      token.Name,
+		TokenHash: helper.HashAccessToken(plain),
+		Scopes:    token.Scopes,
+		CreatedAt: time.Now().UTC(),
+	}
+	if token.ExpiresInDays > 0 {
+		expiresAt := newToken.CreatedAt.AddDate(0, 0, token.ExpiresInDays)
+		newToken.ExpiresAt = &expiresAt
+	}
+	id, err := tu.tokenRepository.InsertToken(ctx, newToken)
+	if err != nil {
+		return models.CreatedToken{}, errors.New("error from insert token")
+	}
+	return models.CreatedToken{
+		Token: plain,
+		TokenDetails: models.TokenDetails{
+			ID:        id,
+			Name:      newToken.Name,
+			Scopes:    newToken.Scopes,
+			CreatedAt: newToken.CreatedAt,
+			ExpiresAt: newToken.ExpiresAt,
+		},
+	}, nil
+}
+
+func (tu *tokenUseCase) GetTokens(ctx context.Context, userID string) ([]models.TokenDetails, error) {
+	tokens, err := tu.tokenRepository.GetTokens(ctx, userID)
+	if err != nil {
+		return []models.TokenDetails{}, errors.New("error from get tokens")
+	}
+	return tokens, nil
+}
+
+func (tu *tokenUseCase) RevokeToken(ctx context.Context, userID, tokenID string) error {
+	deleted, err := tu.tokenRepository.DeleteToken(ctx, userID, tokenID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errTokenNotFound
+	}
+	return nil
+}
+
+func (tu *tokenUseCase) ValidateToken(ctx context.Context, token string) (models.TokenAuth, error) {
+	auth, err := tu.tokenRepository.FindTokenByHash(ctx, helper.HashAccessToken(token))
+	if err != nil {
+		return models.TokenAuth{}, errors.New("error in find token")
+	}
+	if auth.UserID == "" {
+		return models.TokenAuth{}, errInvalidAccessToken
+	}
+	if auth.ExpiresAt != nil && time.Now().After(*auth.ExpiresAt) {
+		return models.TokenAuth{}, errAccessTokenExpired
+	}
+	return auth, nil
+}
+
+func (tu *tokenUseCase) ValidateJWT(ctx context.Context, token string) (*helper.AuthUserClaims, error) {
+	claims := &helper.AuthUserClaims{}
+	if err := tu.keySet.Parse(ctx, token, claims); err != nil {
+		return nil, services.Unauthorized("invalid_token", err.Error())
+	}
+	// Every JWT belongs to a session; one that was revoked, or a token minted
+	// before sessions existed, is no longer accepted.
+	session, err := tu.sessionRepository.FindSessionByJTI(ctx, claims.StandardClaims.Id)
+	if err != nil {
+		return nil, errors.New("error in find session")
+	}
+	if session.ID == "" || session.UserID != claims.Id {
+		return nil, errSessionRevoked
+	}
+	user, err := tu.userRepository.FindUserByID(ctx, claims.Id)
+	if err != nil {
+		return nil, errors.New("error in find user details")
+	}
+	if user.ID == "" || user.Disabled {
+		return nil, errAccountInactive
+	}
+	// iat only has second precision, so compare against the truncated time to
+	// keep the token issued alongside a password change valid.
+	if claims.IssuedAt < user.TokensValidAfter.Unix() {
+		return nil, errTokenRevoked
+	}
+	// The stored role wins so that a demotion takes effect immediately.
+	claims.Role = user.Role
+	if claims.Role == "" {
+		claims.Role = models.RoleUser
+	}
+	return claims, nil
+}
+
+func (tu *tokenUseCase) GetJWKS() models.JWKS {
+	return tu.keySet.JWKS()
+}
@@ -0,0 +1,222 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/config"
+	"taskmanagementapi/pkg/helper"
+	"taskmanagementapi/pkg/keyset"
+	"taskmanagementapi/pkg/usecase"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+	"time"
+
+	mockRepository "taskmanagementapi/pkg/repository/mock"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tokenRepo := mockRepository.NewMockTokenRepository(ctrl)
+	tokenUseCase := usecase.NewTokenUseCase(tokenRepo, nil, nil, nil)
+
+	var stored models.NewToken
+	tokenRepo.EXPECT().InsertToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token models.NewToken) (string, error) {
+		stored = token
+		return "6705824f80a09eb0313f0e42", nil
+	}).Times(1)
+
+	created, err := tokenUseCase.CreateToken(context.Background(), "user1", models.CreateToken{
+		Name:          "ci",
+		Scopes:        []string{models.ScopeTasksRead},
+		ExpiresInDays: 30,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "6705824f80a09eb0313f0e42", created.ID)
+	assert.True(t, helper.IsAccessToken(created.Token))
+	assert.Equal(t, helper.HashAccessToken(created.Token), stored.TokenHash)
+	assert.NotEqual(t, created.Token, stored.TokenHash)
+	assert.Equal(t, "user1", stored.UserID)
+	if assert.NotNil(t, created.ExpiresAt) {
+		assert.Equal(t, stored.CreatedAt.AddDate(0, 0, 30), *created.ExpiresAt)
+	}
+
+	tokenRepo.EXPECT().InsertToken(gomock.Any(), gomock.Any()).Return("", errors.New("db error")).Times(1)
+	_, err = tokenUseCase.CreateToken(context.Background(), "user1", models.CreateToken{Name: "ci", Scopes: []string{models.ScopeTasksRead}})
+	assert.EqualError(t, err, "error from insert token")
+}
+
+func Test_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tokenRepo := mockRepository.NewMockTokenRepository(ctrl)
+	tokenUseCase := usecase.NewTokenUseCase(tokenRepo, nil, nil, nil)
+
+	testData := map[string]struct {
+		stub    func(*mockRepository.MockTokenRepository)
+		wantErr error
+	}{
+		"success": {
+			stub: func(repo *mockRepository.MockTokenRepository) {
+				repo.EXPECT().DeleteToken(gomock.Any(), "user1", "token1").Return(true, nil).Times(1)
+			},
+			wantErr: nil,
+		},
+		"token does not exist": {
+			stub: func(repo *mockRepository.MockTokenRepository) {
+				repo.EXPECT().DeleteToken(gomock.Any(), "user1", "token1").Return(false, nil).Times(1)
+			},
+			wantErr: services.NotFound("token_not_found", "token doesn't exist"),
+		},
+		"repository error": {
+			stub: func(repo *mockRepository.MockTokenRepository) {
+				repo.EXPECT().DeleteToken(gomock.Any(), "user1", "token1").Return(false, errors.New("db error")).Times(1)
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			test.stub(tokenRepo)
+			err := tokenUseCase.RevokeToken(context.Background(), "user1", "token1")
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+func Test_ValidateToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tokenRepo := mockRepository.NewMockTokenRepository(ctrl)
+	tokenUseCase := usecase.NewTokenUseCase(tokenRepo, nil, nil, nil)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	testData := map[string]struct {
+		found   models.TokenAuth
+		wantErr error
+	}{
+		"valid token": {
+			found: models.TokenAuth{UserID: "user1", Scopes: []string{models.ScopeTasksRead}, ExpiresAt: &future},
+		},
+		"unknown token": {
+			found:   models.TokenAuth{},
+			wantErr: services.Unauthorized("invalid_token", "invalid access token"),
+		},
+		"expired token": {
+			found:   models.TokenAuth{UserID: "user1", ExpiresAt: &past},
+			wantErr: services.Unauthorized("invalid_token", "access token expired"),
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			tokenRepo.EXPECT().FindTokenByHash(gomock.Any(), helper.HashAccessToken("tma_secret")).Return(test.found, nil).Times(1)
+			auth, err := tokenUseCase.ValidateToken(context.Background(), "tma_secret")
+			assert.Equal(t, test.wantErr, err)
+			if test.wantErr == nil {
+				assert.Equal(t, test.found, auth)
+			}
+		})
+	}
+}
+
+func Test_ValidateJWT(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	keySet, err := keyset.New(context.Background(), config.Config{JwtAlgorithm: keyset.AlgorithmHS256, JwtSecretKey: "secret", JwtTokenTTL: time.Hour}, nil)
+	assert.NoError(t, err)
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+	tokenUseCase := usecase.NewTokenUseCase(mockRepository.NewMockTokenRepository(ctrl), userRepo, sessionRepo, keySet)
+
+	issuedAt := time.Now().Add(-time.Minute)
+	token, err := keySet.Sign(&helper.AuthUserClaims{
+		Id:   "user1",
+		Role: models.RoleUser,
+		StandardClaims: jwt.StandardClaims{
+			Id:        "jti1",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			IssuedAt:  issuedAt.Unix(),
+		},
+	})
+	assert.NoError(t, err)
+
+	session := models.Session{ID: "s1", JTI: "jti1", UserID: "user1"}
+	testData := map[string]struct {
+		session  *models.Session
+		user     models.UserDetails
+		wantRole string
+		wantErr  error
+	}{
+		"session revoked": {
+			session: &models.Session{},
+			wantErr: services.Unauthorized("invalid_token", "session has been revoked"),
+		},
+		"session of another user": {
+			session: &models.Session{ID: "s1", JTI: "jti1", UserID: "user2"},
+			wantErr: services.Unauthorized("invalid_token", "session has been revoked"),
+		},
+		"valid token": {
+			user:     models.UserDetails{ID: "user1", Role: models.RoleAdmin},
+			wantRole: models.RoleAdmin,
+		},
+		"role defaults to user": {
+			user:     models.UserDetails{ID: "user1"},
+			wantRole: models.RoleUser,
+		},
+		"user deleted": {
+			user:    models.UserDetails{},
+			wantErr: services.Unauthorized("invalid_token", "account is not active"),
+		},
+		"user disabled": {
+			user:    models.UserDetails{ID: "user1", Disabled: true},
+			wantErr: services.Unauthorized("invalid_token", "account is not active"),
+		},
+		"password changed after issue": {
+			user:    models.UserDetails{ID: "user1", TokensValidAfter: issuedAt.Add(time.Second)},
+			wantErr: services.Unauthorized("invalid_token", "token has been revoked"),
+		},
+		"password changed before issue": {
+			user:     models.UserDetails{ID: "user1", TokensValidAfter: issuedAt.Add(-time.Hour)},
+			wantRole: models.RoleUser,
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			if test.session != nil {
+				sessionRepo.EXPECT().FindSessionByJTI(gomock.Any(), "jti1").Return(*test.session, nil).Times(1)
+			} else {
+				sessionRepo.EXPECT().FindSessionByJTI(gomock.Any(), "jti1").Return(session, nil).Times(1)
+				userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(test.user, nil).Times(1)
+			}
+			claims, err := tokenUseCase.ValidateJWT(context.Background(), token)
+			assert.Equal(t, test.wantErr, err)
+			if test.wantErr == nil {
+				assert.Equal(t, "user1", claims.Id)
+				assert.Equal(t, test.wantRole, claims.Role)
+			}
+		})
+	}
+
+	expired, err := keySet.Sign(&helper.AuthUserClaims{
+		Id:             "user1",
+		StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(-time.Hour).Unix()},
+	})
+	assert.NoError(t, err)
+	_, err = tokenUseCase.ValidateJWT(context.Background(), expired)
+	assert.ErrorIs(t, err, services.ErrUnauthorized)
+
+	// A failed lookup says nothing about the token and is not a 401.
+	sessionRepo.EXPECT().FindSessionByJTI(gomock.Any(), "jti1").Return(models.Session{}, errors.New("connection lost")).Times(1)
+	_, err = tokenUseCase.ValidateJWT(context.Background(), token)
+	assert.EqualError(t, err, "error in find session")
+	assert.NotErrorIs(t, err, services.ErrUnauthorized)
+}
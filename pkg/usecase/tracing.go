@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"taskmanagementapi/pkg/tracing"
+	services "taskmanagementapi/pkg/usecase/interface"
+	"taskmanagementapi/pkg/utils/models"
+)
+
+// The traced usecases wrap each method in a span named after it, so that the
+// repository calls it makes show up beneath it.
+
+type tracedTaskUseCase struct {
+	next services.TaskUseCase
+}
+
+func NewTracedTaskUseCase(next services.TaskUseCase) services.TaskUseCase {
+	return &tracedTaskUseCase{next: next}
+}
+
+func (t *tracedTaskUseCase) CreateTask(ctx context.Context, task models.CreateTask, userID string) (taskID string, err error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.CreateTask")
+	defer func() { tracing.End(span, err) }()
+	return t.next.CreateTask(ctx, task, userID)
+}
+
+func (t *tracedTaskUseCase) GetTasks(ctx context.Context, userID string, query models.TaskQuery) (tasks []models.TaskDetails, err error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.GetTasks")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetTasks(ctx, userID, query)
+}
+
+func (t *tracedTaskUseCase) GetTask(ctx context.Context, userID, taskID string) (task models.TaskDetails, err error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.GetTask")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetTask(ctx, userID, taskID)
+}
+
+func (t *tracedTaskUseCase) UpdateTask(ctx context.Context, userID, taskID string, task models.CreateTask) (err error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.UpdateTask")
+	defer func() { tracing.End(span, err) }()
+	return t.next.UpdateTask(ctx, userID, taskID, task)
+}
+
+func (t *tracedTaskUseCase) PatchTask(ctx context.Context, userID, taskID string, patch models.TaskPatch) (task models.TaskDetails, err error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.PatchTask")
+	defer func() { tracing.End(span, err) }()
+	return t.next.PatchTask(ctx, userID, taskID, patch)
+}
+
+func (t *tracedTaskUseCase) BulkTasks(ctx context.Context, userID string, bulk models.BulkTaskRequest) (results []models.BulkTaskResult, err error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.BulkTasks")
+	defer func() { tracing.End(span, err) }()
+	return t.next.BulkTasks(ctx, userID, bulk)
+}
+
+func (t *tracedTaskUseCase) DeleteTask(ctx context.Context, userID, taskID string) (err error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.DeleteTask")
+	defer func() { tracing.End(span, err) }()
+	return t.next.DeleteTask(ctx, userID, taskID)
+}
+
+type tracedUserUseCase struct {
+	next services.UserUseCase
+}
+
+func NewTracedUserUseCase(next services.UserUseCase) services.UserUseCase {
+	return &tracedUserUseCase{next: next}
+}
+
+func (t *tracedUserUseCase) UserSignUp(ctx context.Context, user models.UserSignup) (err error) {
+	ctx, span := tracing.Start(ctx, "UserUseCase.UserSignUp")
+	defer func() { tracing.End(span, err) }()
+	return t.next.UserSignUp(ctx, user)
+}
+
+func (t *tracedUserUseCase) UserSignIn(ctx context.Context, user models.UserSignIn, client models.ClientInfo) (token string, err error) {
+	ctx, span := tracing.Start(ctx, "UserUseCase.UserSignIn")
+	defer func() { tracing.End(span, err) }()
+	return t.next.UserSignIn(ctx, user, client)
+}
+
+func (t *tracedUserUseCase) GetProfile(ctx context.Context, userID string) (profile models.UserProfile, err error) {
+	ctx, span := tracing.Start(ctx, "UserUseCase.GetProfile")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetProfile(ctx, userID)
+}
+
+func (t *tracedUserUseCase) UpdateProfile(ctx context.Context, userID string, update models.UpdateProfile) (err error) {
+	ctx, span := tracing.Start(ctx, "UserUseCase.UpdateProfile")
+	defer func() { tracing.End(span, err) }()
+	return t.next.UpdateProfile(ctx, userID, update)
+}
+
+func (t *tracedUserUseCase) VerifyEmail(ctx context.Context, userID string, verify models.VerifyEmail) (err error) {
+	ctx, span := tracing.Start(ctx, "UserUseCase.VerifyEmail")
+	defer func() { tracing.End(span, err) }()
+	return t.next.VerifyEmail(ctx, userID, verify)
+}
+
+func (t *tracedUserUseCase) ChangePassword(ctx context.Context, userID string, change models.ChangePassword, client models.ClientInfo) (token string, err error) {
+	ctx, span := tracing.Start(ctx, "UserUseCase.ChangePassword")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ChangePassword(ctx, userID, change, client)
+}
@@ -0,0 +1,70 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"taskmanagementapi/pkg/usecase"
+	"taskmanagementapi/pkg/utils/models"
+	"testing"
+
+	services "taskmanagementapi/pkg/usecase/interface"
+	mockUseCase "taskmanagementapi/pkg/usecase/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_TracedTaskUseCase(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	testCases := map[string]struct {
+		err        error
+		wantStatus codes.Code
+		wantEvents int
+	}{
+		"success":          {wantStatus: codes.Unset},
+		"typed error":      {err: services.NotFound("task_not_found", "task doesn't exist"), wantStatus: codes.Unset, wantEvents: 1},
+		"unexpected error": {err: errors.New("connection reset"), wantStatus: codes.Error, wantEvents: 1},
+	}
+
+	for testName, test := range testCases {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			recorder.Reset()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			taskUseCase := mockUseCase.NewMockTaskUseCase(ctrl)
+			traced := usecase.NewTracedTaskUseCase(taskUseCase)
+
+			ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+			var innerSpan trace.SpanContext
+			taskUseCase.EXPECT().GetTask(gomock.Any(), "user1", "task1").DoAndReturn(func(ctx context.Context, userID, taskID string) (models.TaskDetails, error) {
+				innerSpan = trace.SpanContextFromContext(ctx)
+				return models.TaskDetails{}, test.err
+			}).Times(1)
+
+			_, err := traced.GetTask(ctx, "user1", "task1")
+			parent.End()
+			assert.Equal(t, test.err, err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 2)
+			span := spans[0]
+			assert.Equal(t, "TaskUseCase.GetTask", span.Name())
+			assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+			assert.Equal(t, span.SpanContext().SpanID(), innerSpan.SpanID())
+			assert.Equal(t, test.wantStatus, span.Status().Code)
+			assert.Len(t, span.Events(), test.wantEvents)
+		})
+	}
+}
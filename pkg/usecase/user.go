@@ -1,66 +1,328 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"taskmanagementapi/pkg/helper"
+	"taskmanagementapi/pkg/mailer"
+	"taskmanagementapi/pkg/password"
 	interfaces "taskmanagementapi/pkg/repository/interface"
 	services "taskmanagementapi/pkg/usecase/interface"
 	"taskmanagementapi/pkg/utils/models"
-
-	"golang.org/x/crypto/bcrypt"
+	"time"
 )
 
+const emailVerificationTTL = 24 * time.Hour
+
 type userUseCase struct {
-	userRepository interfaces.UserRepository
+	userRepository    interfaces.UserRepository
+	attemptRepository interfaces.LoginAttemptRepository
+	sessionRepository interfaces.SessionRepository
+	tokenRepository   interfaces.TokenRepository
+	mailer            mailer.Mailer
+	passwordPolicy    *password.Policy
+	hasher            *password.Hasher
+
+	dummyHashOnce sync.Once
+	dummyHash     string
 }
 
-func NewUserUseCase(repository interfaces.UserRepository) services.UserUseCase {
+func NewUserUseCase(repository interfaces.UserRepository, attemptRepository interfaces.LoginAttemptRepository, sessionRepository interfaces.SessionRepository, tokenRepository interfaces.TokenRepository, mailer mailer.Mailer, passwordPolicy *password.Policy, hasher *password.Hasher) services.UserUseCase {
 	return &userUseCase{
-		userRepository: repository,
+		userRepository:    repository,
+		attemptRepository: attemptRepository,
+		sessionRepository: sessionRepository,
+		tokenRepository:   tokenRepository,
+		mailer:            mailer,
+		passwordPolicy:    passwordPolicy,
+		hasher:            hasher,
 	}
 }
 
-func (ur *userUseCase) UserSignUp(user models.UserSignup) error {
-	email, err := ur.userRepository.CheckUserExistsByEmail(user.Email)
+func (ur *userUseCase) UserSignUp(ctx context.Context, user models.UserSignup) error {
+	email, err := ur.userRepository.CheckUserExistsByEmail(ctx, user.Email)
 	if email {
-		return errors.New("user with this email is already exists")
+		return errEmailTaken
 	}
 	if err != nil {
 		return errors.New("error from check email")
 	}
-	hashPassword, err := helper.PasswordHash(user.Password)
+	if err := checkPassword(ur.passwordPolicy, user.Password, user.Email, user.Name); err != nil {
+		return err
+	}
+	hashPassword, err := ur.hasher.Hash(user.Password)
 	if err != nil {
 		return errors.New("error in hashing password")
 	}
 	user.Password = hashPassword
-	err = ur.userRepository.UserSignUp(user)
+	err = ur.userRepository.UserSignUp(ctx, user)
 	if err != nil {
 		return errors.New("could not add the user data")
 	}
 	return nil
 }
 
-func (ur *userUseCase) UserSignIn(user models.UserSignIn) (string, error) {
-	exist, err := ur.userRepository.CheckUserExistsByEmail(user.Email)
+func (ur *userUseCase) UserSignIn(ctx context.Context, user models.UserSignIn, client models.ClientInfo) (string, error) {
+	keys := []string{"email:" + strings.ToLower(user.Email), "ip:" + client.IP}
+	for _, key := range keys {
+		attempt, err := ur.attemptRepository.GetAttempt(ctx, key)
+		if err != nil {
+			return "", errors.New("error from check login attempts")
+		}
+		if time.Now().Before(attempt.LockedUntil) {
+			return "", services.ErrTooManyAttempts
+		}
+	}
+
+	userdeatils, err := ur.userRepository.FindUserDetailsByEmail(ctx, user.Email)
+	if err != nil {
+		return "", errors.New("error in find user details")
+	}
+	// Unknown emails and password-less accounts are still checked against a
+	// real hash so that response time does not reveal whether the email exists.
+	hash := userdeatils.Password
+	if userdeatils.ID == "" || hash == "" {
+		hash = ur.dummyPasswordHash()
+	}
+	match, rehash, err := ur.hasher.Verify(user.Password, hash)
+	if err != nil || !match || userdeatils.ID == "" || userdeatils.Password == "" {
+		ur.recordFailure(ctx, keys[0], emailLockout)
+		ur.recordFailure(ctx, keys[1], ipLockout)
+		return "", services.ErrInvalidCredentials
+	}
+	if err := ur.attemptRepository.ResetAttempts(ctx, keys[0]); err != nil {
+		return "", errors.New("error from reset login attempts")
+	}
+	if userdeatils.Disabled {
+		return "", errAccountDisabled
+	}
+	if rehash {
+		ur.upgradeHash(ctx, userdeatils, user.Password)
+	}
+	if userdeatils.Role == "" {
+		userdeatils.Role = models.RoleUser
+	}
+	return startSession(ctx, ur.userRepository, ur.sessionRepository, userdeatils, client)
+}
+
+func (ur *userUseCase) GetProfile(ctx context.Context, userID string) (models.UserProfile, error) {
+	user, err := ur.userRepository.FindUserByID(ctx, userID)
+	if err != nil {
+		return models.UserProfile{}, errors.New("error in find user details")
+	}
+	if user.ID == "" {
+		return models.UserProfile{}, errUserNotFound
+	}
+	profile := models.UserProfile{
+		ID:    user.ID,
+		Name:  user.Name,
+		Email: user.Email,
+		Role:  user.Role,
+
+		EmailVerified: user.EmailVerified,
+	}
+	if profile.Role == "" {
+		profile.Role = models.RoleUser
+	}
+	if user.EmailVerification != nil && time.Now().Before(user.EmailVerification.ExpiresAt) {
+		profile.PendingEmail = user.EmailVerification.Email
+	}
+	return profile, nil
+}
+
+// UpdateProfile changes the name immediately. A new email only becomes the
+// login address once the link sent to it has been confirmed via VerifyEmail.
+// Sending the current, unverified email starts the same confirmation for it.
+func (ur *userUseCase) UpdateProfile(ctx context.Context, userID string, update models.UpdateProfile) error {
+	user, err := ur.userRepository.FindUserByID(ctx, userID)
+	if err != nil {
+		return errors.New("error in find user details")
+	}
+	if user.ID == "" {
+		return errUserNotFound
+	}
+	if update.Name != nil && *update.Name != user.Name {
+		if _, err := ur.userRepository.UpdateName(ctx, userID, *update.Name); err != nil {
+			return errors.New("error from update name")
+		}
+	}
+	if update.Email == nil {
+		return nil
+	}
+	email := *update.Email
+	if strings.EqualFold(email, user.Email) {
+		if user.EmailVerified {
+			return nil
+		}
+		email = user.Email
+	} else {
+		exists, err := ur.userRepository.CheckUserExistsByEmail(ctx, email)
+		if err != nil {
+			return errors.New("error from check email")
+		}
+		if exists {
+			return errEmailTaken
+		}
+	}
+	token, err := randomToken()
 	if err != nil {
-		return "", err 
+		return errors.New("error from generating verification token")
+	}
+	verification := models.EmailVerification{
+		Email:     email,
+		TokenHash: helper.HashAccessToken(token),
+		ExpiresAt: time.Now().UTC().Add(emailVerificationTTL),
 	}
-	if !exist {
-		return "", errors.New("email doesn't exist")
+	if _, err := ur.userRepository.SetPendingEmail(ctx, userID, verification); err != nil {
+		return errors.New("error from update email")
 	}
-	
+	body := "Use this code to confirm your new email address: " + token
+	if err := ur.mailer.Send(verification.Email, "Confirm your email address", body); err != nil {
+		return errors.New("error from sending verification email")
+	}
+	return nil
+}
 
-	userdeatils, err := ur.userRepository.FindUserDetailsByEmail(user.Email)
+func (ur *userUseCase) VerifyEmail(ctx context.Context, userID string, verify models.VerifyEmail) error {
+	user, err := ur.userRepository.FindUserByID(ctx, userID)
+	if err != nil {
+		return errors.New("error in find user details")
+	}
+	if user.EmailVerification == nil {
+		return services.Conflict("no_pending_email", "no email change pending")
+	}
+	// The address may have been registered by someone else since the change
+	// was requested, unless it is the one the account already has.
+	if !strings.EqualFold(user.EmailVerification.Email, user.Email) {
+		exists, err := ur.userRepository.CheckUserExistsByEmail(ctx, user.EmailVerification.Email)
+		if err != nil {
+			return errors.New("error from check email")
+		}
+		if exists {
+			return errEmailTaken
+		}
+	}
+	confirmed, err := ur.userRepository.ConfirmEmail(ctx, userID, helper.HashAccessToken(verify.Token))
+	if err != nil {
+		return errors.New("error from update email")
+	}
+	if !confirmed {
+		return services.Validation("invalid_verification_token", "invalid or expired verification token")
+	}
+	return nil
+}
+
+// ChangePassword ends every session, including the one used for this request,
+// and returns the token for a new session on the calling device.
+func (ur *userUseCase) ChangePassword(ctx context.Context, userID string, change models.ChangePassword, client models.ClientInfo) (string, error) {
+	user, err := ur.userRepository.FindUserByID(ctx, userID)
 	if err != nil {
 		return "", errors.New("error in find user details")
 	}
-	err = bcrypt.CompareHashAndPassword([]byte(userdeatils.Password), []byte(user.Password))
+	if user.ID == "" || user.Password == "" {
+		return "", services.ErrInvalidCredentials
+	}
+	if match, _, err := ur.hasher.Verify(change.CurrentPassword, user.Password); err != nil || !match {
+		return "", services.ErrInvalidCredentials
+	}
+	if err := checkPassword(ur.passwordPolicy, change.NewPassword, user.Email, user.Name); err != nil {
+		return "", err
+	}
+	hashPassword, err := ur.hasher.Hash(change.NewPassword)
 	if err != nil {
-		return "", errors.New("password not matching")
+		return "", errors.New("error in hashing password")
+	}
+	if _, err := ur.userRepository.UpdatePassword(ctx, userID, hashPassword); err != nil {
+		return "", errors.New("error from update password")
+	}
+	// A leaked password may have been used to mint API tokens, so they go
+	// along with the other sessions.
+	if err := ur.tokenRepository.DeleteTokensByUser(ctx, userID); err != nil {
+		return "", errors.New("error from revoke tokens")
+	}
+	if err := ur.sessionRepository.DeleteSessionsByUser(ctx, userID); err != nil {
+		return "", errors.New("error from revoke sessions")
+	}
+	if user.Role == "" {
+		user.Role = models.RoleUser
+	}
+	return startSession(ctx, ur.userRepository, ur.sessionRepository, user, client)
+}
+
+// checkPassword wraps policy violations in ErrWeakPassword so handlers can
+// report them as unprocessable. A check that could not run is a server error.
+func checkPassword(policy *password.Policy, newPassword string, personal ...string) error {
+	if policy == nil {
+		return nil
+	}
+	err := policy.Check(newPassword, personal...)
+	var violation *password.Violation
+	if errors.As(err, &violation) {
+		return fmt.Errorf("%w: %v", services.ErrWeakPassword, err)
 	}
-	token, err := ur.userRepository.GenerateJwtToken(userdeatils)
 	if err != nil {
-		return "", errors.New("couldn't create token")
+		return fmt.Errorf("error in check password: %w", err)
 	}
-	return token, nil
+	return nil
+}
+
+type lockoutPolicy struct {
+	threshold int
+	base      time.Duration
+	max       time.Duration
+}
+
+var (
+	emailLockout = lockoutPolicy{threshold: 5, base: 30 * time.Second, max: 15 * time.Minute}
+	ipLockout    = lockoutPolicy{threshold: 20, base: 30 * time.Second, max: 15 * time.Minute}
+)
+
+const failureWindow = 15 * time.Minute
+
+// lockDuration doubles the lockout for every failure past the threshold.
+func (p lockoutPolicy) lockDuration(failures int) time.Duration {
+	if failures < p.threshold {
+		return 0
+	}
+	d := p.base
+	for i := p.threshold; i < failures && d < p.max; i++ {
+		d *= 2
+	}
+	if d > p.max {
+		d = p.max
+	}
+	return d
+}
+
+// recordFailure is best effort: a storage error must not turn a wrong
+// password into a different response.
+func (ur *userUseCase) recordFailure(ctx context.Context, key string, policy lockoutPolicy) {
+	attempt, err := ur.attemptRepository.RecordFailure(ctx, key, failureWindow)
+	if err != nil {
+		return
+	}
+	if d := policy.lockDuration(attempt.Failures); d > 0 {
+		ur.attemptRepository.LockUntil(ctx, key, time.Now().Add(d))
+	}
+}
+
+// upgradeHash stores a hash made with the current algorithm and parameters.
+// The sign-in has already succeeded, so a failure here only means the upgrade
+// is retried next time.
+func (ur *userUseCase) upgradeHash(ctx context.Context, user models.UserDetails, plain string) {
+	hash, err := ur.hasher.Hash(plain)
+	if err != nil {
+		return
+	}
+	ur.userRepository.RehashPassword(ctx, user.ID, user.Password, hash)
+}
+
+func (ur *userUseCase) dummyPasswordHash() string {
+	ur.dummyHashOnce.Do(func() {
+		ur.dummyHash, _ = ur.hasher.Hash("dummy-password-for-timing")
+	})
+	return ur.dummyHash
 }
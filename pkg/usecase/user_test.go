@@ -1,23 +1,34 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"taskmanagementapi/pkg/password"
 	"taskmanagementapi/pkg/usecase"
 	"taskmanagementapi/pkg/utils/models"
 	"testing"
+	"time"
 
 	mockRepository "taskmanagementapi/pkg/repository/mock"
+	services "taskmanagementapi/pkg/usecase/interface"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// testHasher matches the cost used by the fixtures below so that no rehash is
+// expected unless a test asks for one.
+var testHasher = &password.Hasher{Algorithm: password.Bcrypt, BcryptCost: bcrypt.DefaultCost}
+
 func Test_UserSignUp(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	userRepo := mockRepository.NewMockUserRepository(ctrl)
-	userUseCase := usecase.NewUserUseCase(userRepo)
+	attemptRepo := mockRepository.NewMockLoginAttemptRepository(ctrl)
+	userUseCase := usecase.NewUserUseCase(userRepo, attemptRepo, nil, nil, nil, &password.Policy{MinLength: 8, DisallowPersonal: true}, testHasher)
 
 	testData := map[string]struct {
 		input   models.UserSignup
@@ -31,11 +42,33 @@ func Test_UserSignUp(t *testing.T) {
 				Password: "password123",
 			},
 			stub: func(userRepo *mockRepository.MockUserRepository) {
-				userRepo.EXPECT().CheckUserExistsByEmail("akhil@example.com").Return(false, nil).Times(1)
-				userRepo.EXPECT().UserSignUp(gomock.Any()).Return(nil).Times(1)
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), "akhil@example.com").Return(false, nil).Times(1)
+				userRepo.EXPECT().UserSignUp(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 			},
 			wantErr: nil,
 		},
+		"password too short": {
+			input: models.UserSignup{
+				Name:     "Akhil",
+				Email:    "akhil@example.com",
+				Password: "pass",
+			},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), "akhil@example.com").Return(false, nil).Times(1)
+			},
+			wantErr: fmt.Errorf("%w: %v", services.ErrWeakPassword, "password must be at least 8 characters"),
+		},
+		"password contains name": {
+			input: models.UserSignup{
+				Name:     "Akhil",
+				Email:    "akhil@example.com",
+				Password: "AKHIL-2024",
+			},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), "akhil@example.com").Return(false, nil).Times(1)
+			},
+			wantErr: fmt.Errorf("%w: %v", services.ErrWeakPassword, "password must not contain your name or email"),
+		},
 		"user exists": {
 			input: models.UserSignup{
 				Name:     "Akhil",
@@ -43,9 +76,9 @@ func Test_UserSignUp(t *testing.T) {
 				Password: "password123",
 			},
 			stub: func(userRepo *mockRepository.MockUserRepository) {
-				userRepo.EXPECT().CheckUserExistsByEmail("akhil@example.com").Return(true, nil).Times(1)
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), "akhil@example.com").Return(true, nil).Times(1)
 			},
-			wantErr: errors.New("user with this email is already exists"),
+			wantErr: services.Conflict("email_taken", "user with this email is already exists"),
 		},
 		"error checking user": {
 			input: models.UserSignup{
@@ -54,7 +87,7 @@ func Test_UserSignUp(t *testing.T) {
 				Password: "password123",
 			},
 			stub: func(userRepo *mockRepository.MockUserRepository) {
-				userRepo.EXPECT().CheckUserExistsByEmail("akhil@example.com").Return(false, errors.New("error from check email")).Times(1)
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), "akhil@example.com").Return(false, errors.New("error from check email")).Times(1)
 			},
 			wantErr: errors.New("error from check email"),
 		},
@@ -65,8 +98,8 @@ func Test_UserSignUp(t *testing.T) {
 				Password: "password123",
 			},
 			stub: func(userRepo *mockRepository.MockUserRepository) {
-				userRepo.EXPECT().CheckUserExistsByEmail("akhil@example.com").Return(false, nil).Times(1)
-				userRepo.EXPECT().UserSignUp(gomock.Any()).Return(errors.New("could not add the user data")).Times(1)
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), "akhil@example.com").Return(false, nil).Times(1)
+				userRepo.EXPECT().UserSignUp(gomock.Any(), gomock.Any()).Return(errors.New("could not add the user data")).Times(1)
 			},
 			wantErr: errors.New("could not add the user data"),
 		},
@@ -75,49 +108,423 @@ func Test_UserSignUp(t *testing.T) {
 	for testName, test := range testData {
 		t.Run(testName, func(t *testing.T) {
 			test.stub(userRepo)
-			err := userUseCase.UserSignUp(test.input)
+			err := userUseCase.UserSignUp(context.Background(), test.input)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
 }
 
 func TestUserSignIn(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
+	emailKey, ipKey := "email:test@example.com", "ip:10.0.0.1"
+	noLock := func(attemptRepo *mockRepository.MockLoginAttemptRepository) {
+		attemptRepo.EXPECT().GetAttempt(gomock.Any(), emailKey).Return(models.LoginAttempt{Key: emailKey}, nil)
+		attemptRepo.EXPECT().GetAttempt(gomock.Any(), ipKey).Return(models.LoginAttempt{Key: ipKey}, nil)
+	}
+	failure := func(attemptRepo *mockRepository.MockLoginAttemptRepository) {
+		attemptRepo.EXPECT().RecordFailure(gomock.Any(), emailKey, gomock.Any()).Return(models.LoginAttempt{Failures: 1}, nil)
+		attemptRepo.EXPECT().RecordFailure(gomock.Any(), ipKey, gomock.Any()).Return(models.LoginAttempt{Failures: 1}, nil)
+	}
+
+	testData := map[string]struct {
+		input     models.UserSignIn
+		hasher    *password.Hasher
+		stub      func(*mockRepository.MockUserRepository, *mockRepository.MockLoginAttemptRepository)
+		wantToken string
+		wantErr   error
+	}{
+		"unknown email": {
+			input: models.UserSignIn{Email: "test@example.com", Password: "testpassword"},
+			stub: func(userRepo *mockRepository.MockUserRepository, attemptRepo *mockRepository.MockLoginAttemptRepository) {
+				noLock(attemptRepo)
+				userRepo.EXPECT().FindUserDetailsByEmail(gomock.Any(), "test@example.com").Return(models.UserDetails{}, nil)
+				failure(attemptRepo)
+			},
+			wantErr: services.ErrInvalidCredentials,
+		},
+		"find user error": {
+			input: models.UserSignIn{Email: "test@example.com", Password: "testpassword"},
+			stub: func(userRepo *mockRepository.MockUserRepository, attemptRepo *mockRepository.MockLoginAttemptRepository) {
+				noLock(attemptRepo)
+				userRepo.EXPECT().FindUserDetailsByEmail(gomock.Any(), "test@example.com").Return(models.UserDetails{}, errors.New("db error"))
+			},
+			wantErr: errors.New("error in find user details"),
+		},
+		"wrong password": {
+			input: models.UserSignIn{Email: "test@example.com", Password: "wrongpassword"},
+			stub: func(userRepo *mockRepository.MockUserRepository, attemptRepo *mockRepository.MockLoginAttemptRepository) {
+				noLock(attemptRepo)
+				userRepo.EXPECT().FindUserDetailsByEmail(gomock.Any(), "test@example.com").Return(models.UserDetails{ID: "u1", Password: string(hashedPassword)}, nil)
+				failure(attemptRepo)
+			},
+			wantErr: services.ErrInvalidCredentials,
+		},
+		"fifth failure locks the account": {
+			input: models.UserSignIn{Email: "test@example.com", Password: "wrongpassword"},
+			stub: func(userRepo *mockRepository.MockUserRepository, attemptRepo *mockRepository.MockLoginAttemptRepository) {
+				noLock(attemptRepo)
+				userRepo.EXPECT().FindUserDetailsByEmail(gomock.Any(), "test@example.com").Return(models.UserDetails{ID: "u1", Password: string(hashedPassword)}, nil)
+				attemptRepo.EXPECT().RecordFailure(gomock.Any(), emailKey, gomock.Any()).Return(models.LoginAttempt{Failures: 5}, nil)
+				attemptRepo.EXPECT().LockUntil(gomock.Any(), emailKey, gomock.Any()).Return(nil)
+				attemptRepo.EXPECT().RecordFailure(gomock.Any(), ipKey, gomock.Any()).Return(models.LoginAttempt{Failures: 5}, nil)
+			},
+			wantErr: services.ErrInvalidCredentials,
+		},
+		"locked account": {
+			input: models.UserSignIn{Email: "Test@Example.com", Password: "correctpassword"},
+			stub: func(userRepo *mockRepository.MockUserRepository, attemptRepo *mockRepository.MockLoginAttemptRepository) {
+				attemptRepo.EXPECT().GetAttempt(gomock.Any(), emailKey).Return(models.LoginAttempt{Key: emailKey, LockedUntil: time.Now().Add(time.Minute)}, nil)
+			},
+			wantErr: services.ErrTooManyAttempts,
+		},
+		"locked ip": {
+			input: models.UserSignIn{Email: "test@example.com", Password: "correctpassword"},
+			stub: func(userRepo *mockRepository.MockUserRepository, attemptRepo *mockRepository.MockLoginAttemptRepository) {
+				attemptRepo.EXPECT().GetAttempt(gomock.Any(), emailKey).Return(models.LoginAttempt{Key: emailKey}, nil)
+				attemptRepo.EXPECT().GetAttempt(gomock.Any(), ipKey).Return(models.LoginAttempt{Key: ipKey, LockedUntil: time.Now().Add(time.Minute)}, nil)
+			},
+			wantErr: services.ErrTooManyAttempts,
+		},
+		"success resets counter": {
+			input: models.UserSignIn{Email: "test@example.com", Password: "correctpassword"},
+			stub: func(userRepo *mockRepository.MockUserRepository, attemptRepo *mockRepository.MockLoginAttemptRepository) {
+				noLock(attemptRepo)
+				userRepo.EXPECT().FindUserDetailsByEmail(gomock.Any(), "test@example.com").Return(models.UserDetails{ID: "u1", Password: string(hashedPassword)}, nil)
+				attemptRepo.EXPECT().ResetAttempts(gomock.Any(), emailKey).Return(nil)
+				userRepo.EXPECT().GenerateJwtToken(models.UserDetails{ID: "u1", Password: string(hashedPassword), Role: models.RoleUser}, gomock.Any()).Return("validToken", time.Now().Add(time.Hour), nil)
+			},
+			wantToken: "validToken",
+		},
+		"bcrypt hash upgraded to argon2id": {
+			input:  models.UserSignIn{Email: "test@example.com", Password: "correctpassword"},
+			hasher: &password.Hasher{Algorithm: password.Argon2id, Argon2: password.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1}},
+			stub: func(userRepo *mockRepository.MockUserRepository, attemptRepo *mockRepository.MockLoginAttemptRepository) {
+				noLock(attemptRepo)
+				userRepo.EXPECT().FindUserDetailsByEmail(gomock.Any(), "test@example.com").Return(models.UserDetails{ID: "u1", Password: string(hashedPassword)}, nil)
+				attemptRepo.EXPECT().ResetAttempts(gomock.Any(), emailKey).Return(nil)
+				userRepo.EXPECT().RehashPassword(gomock.Any(), "u1", string(hashedPassword), gomock.Any()).DoAndReturn(func(_ context.Context, _, _, hash string) (bool, error) {
+					assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+					return true, nil
+				})
+				userRepo.EXPECT().GenerateJwtToken(gomock.Any(), gomock.Any()).Return("validToken", time.Now().Add(time.Hour), nil)
+			},
+			wantToken: "validToken",
+		},
+		"disabled account": {
+			input: models.UserSignIn{Email: "test@example.com", Password: "correctpassword"},
+			stub: func(userRepo *mockRepository.MockUserRepository, attemptRepo *mockRepository.MockLoginAttemptRepository) {
+				noLock(attemptRepo)
+				userRepo.EXPECT().FindUserDetailsByEmail(gomock.Any(), "test@example.com").Return(models.UserDetails{ID: "u1", Password: string(hashedPassword), Disabled: true}, nil)
+				attemptRepo.EXPECT().ResetAttempts(gomock.Any(), emailKey).Return(nil)
+			},
+			wantErr: services.Forbidden("account_disabled", "account is disabled"),
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			userRepo := mockRepository.NewMockUserRepository(ctrl)
+			attemptRepo := mockRepository.NewMockLoginAttemptRepository(ctrl)
+			sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+			hasher := test.hasher
+			if hasher == nil {
+				hasher = testHasher
+			}
+			userUseCase := usecase.NewUserUseCase(userRepo, attemptRepo, sessionRepo, nil, nil, nil, hasher)
+			test.stub(userRepo, attemptRepo)
+			if test.wantToken != "" {
+				sessionRepo.EXPECT().InsertSession(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, session models.NewSession) error {
+					assert.Equal(t, "u1", session.UserID)
+					assert.Equal(t, "10.0.0.1", session.IP)
+					assert.Equal(t, "curl/8.0", session.UserAgent)
+					assert.NotEmpty(t, session.JTI)
+					return nil
+				}).Times(1)
+			}
+			token, err := userUseCase.UserSignIn(context.Background(), test.input, models.ClientInfo{IP: "10.0.0.1", UserAgent: "curl/8.0"})
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantToken, token)
+		})
+	}
+}
+
+type sentMail struct {
+	to, subject, body string
+}
+
+type fakeMailer struct {
+	sent []sentMail
+}
+
+func (m *fakeMailer) Send(to, subject, body string) error {
+	m.sent = append(m.sent, sentMail{to: to, subject: subject, body: body})
+	return nil
+}
+
+// unreadableCorpus fails every breached-password lookup.
+type unreadableCorpus struct{}
+
+func (unreadableCorpus) Range(string) ([]string, error) {
+	return nil, errors.New("read error")
+}
+
+func Test_UserSignUpPolicyFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockRepo := mockRepository.NewMockUserRepository(ctrl)
-	userUseCase := usecase.NewUserUseCase(mockRepo)
-	mockRepo.EXPECT().CheckUserExistsByEmail("test@example.com").Return(false, nil)
-	_, err := userUseCase.UserSignIn(models.UserSignIn{
-		Email:    "test@example.com",
-		Password: "testpassword",
-	})
-	assert.EqualError(t, err, "email doesn't exist")
-	mockRepo.EXPECT().CheckUserExistsByEmail("test@example.com").Return(true, nil)
-	mockRepo.EXPECT().FindUserDetailsByEmail("test@example.com").Return(models.UserDetails{}, errors.New("db error"))
-	_, err = userUseCase.UserSignIn(models.UserSignIn{
-		Email:    "test@example.com",
-		Password: "testpassword",
-	})
-	assert.EqualError(t, err, "error in find user details")
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
-	mockRepo.EXPECT().CheckUserExistsByEmail("test@example.com").Return(true, nil)
-	mockRepo.EXPECT().FindUserDetailsByEmail("test@example.com").Return(models.UserDetails{
-		Password: string(hashedPassword),
-	}, nil)
-	_, err = userUseCase.UserSignIn(models.UserSignIn{
-		Email:    "test@example.com",
-		Password: "wrongpassword", 
-	})
-	assert.EqualError(t, err, "password not matching")
-	mockRepo.EXPECT().CheckUserExistsByEmail("test@example.com").Return(true, nil)
-	mockRepo.EXPECT().FindUserDetailsByEmail("test@example.com").Return(models.UserDetails{
-		Password: string(hashedPassword),
-	}, nil)
-	mockRepo.EXPECT().GenerateJwtToken(gomock.Any()).Return("validToken", nil)
-	token, err := userUseCase.UserSignIn(models.UserSignIn{
-		Email:    "test@example.com",
-		Password: "correctpassword",
-	})
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	userUseCase := usecase.NewUserUseCase(userRepo, nil, nil, nil, nil, &password.Policy{MinLength: 8, Breached: unreadableCorpus{}}, testHasher)
+
+	userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), "akhil@example.com").Return(false, nil).Times(1)
+	err := userUseCase.UserSignUp(context.Background(), models.UserSignup{Name: "Akhil", Email: "akhil@example.com", Password: "correct horse battery"})
+	assert.EqualError(t, err, "error in check password: read error")
+	assert.NotErrorIs(t, err, services.ErrWeakPassword)
+}
+
+func Test_GetProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	userUseCase := usecase.NewUserUseCase(userRepo, nil, nil, nil, nil, nil, testHasher)
+
+	userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(models.UserDetails{
+		ID:    "user1",
+		Name:  "Akhil",
+		Email: "akhil@example.com",
+		EmailVerification: &models.EmailVerification{
+			Email:     "new@example.com",
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+	}, nil).Times(1)
+	profile, err := userUseCase.GetProfile(context.Background(), "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.UserProfile{
+		ID:           "user1",
+		Name:         "Akhil",
+		Email:        "akhil@example.com",
+		Role:         models.RoleUser,
+		PendingEmail: "new@example.com",
+	}, profile)
+
+	userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(models.UserDetails{}, nil).Times(1)
+	_, err = userUseCase.GetProfile(context.Background(), "user1")
+	assert.EqualError(t, err, "user doesn't exist")
+}
+
+func Test_UpdateProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	mail := &fakeMailer{}
+	userUseCase := usecase.NewUserUseCase(userRepo, nil, nil, nil, mail, nil, testHasher)
+
+	current := models.UserDetails{ID: "user1", Name: "Akhil", Email: "akhil@example.com", EmailVerified: true}
+	name := "Akhil K"
+	newEmail := "new@example.com"
+	sameEmail := "AKHIL@example.com"
+
+	testData := map[string]struct {
+		input    models.UpdateProfile
+		stub     func(*mockRepository.MockUserRepository)
+		wantErr  error
+		wantMail bool
+	}{
+		"name only": {
+			input: models.UpdateProfile{Name: &name},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().UpdateName(gomock.Any(), "user1", name).Return(true, nil).Times(1)
+			},
+		},
+		"same verified email is ignored": {
+			input: models.UpdateProfile{Email: &sameEmail},
+			stub:  func(userRepo *mockRepository.MockUserRepository) {},
+		},
+		"new email needs verification": {
+			input: models.UpdateProfile{Email: &newEmail},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), newEmail).Return(false, nil).Times(1)
+				userRepo.EXPECT().SetPendingEmail(gomock.Any(), "user1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, v models.EmailVerification) (bool, error) {
+					assert.Equal(t, newEmail, v.Email)
+					assert.NotEmpty(t, v.TokenHash)
+					assert.True(t, v.ExpiresAt.After(time.Now()))
+					return true, nil
+				}).Times(1)
+			},
+			wantMail: true,
+		},
+		"email taken": {
+			input: models.UpdateProfile{Email: &newEmail},
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), newEmail).Return(true, nil).Times(1)
+			},
+			wantErr: services.Conflict("email_taken", "user with this email is already exists"),
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			mail.sent = nil
+			userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(current, nil).Times(1)
+			test.stub(userRepo)
+			err := userUseCase.UpdateProfile(context.Background(), "user1", test.input)
+			assert.Equal(t, test.wantErr, err)
+			if test.wantMail {
+				if assert.Len(t, mail.sent, 1) {
+					assert.Equal(t, newEmail, mail.sent[0].to)
+				}
+			} else {
+				assert.Empty(t, mail.sent)
+			}
+		})
+	}
+}
+
+func Test_UpdateProfileConfirmsCurrentEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	mail := &fakeMailer{}
+	userUseCase := usecase.NewUserUseCase(userRepo, nil, nil, nil, mail, nil, testHasher)
+
+	// The account's own email is not taken by someone else.
+	userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(models.UserDetails{ID: "user1", Email: "akhil@example.com"}, nil).Times(1)
+	userRepo.EXPECT().SetPendingEmail(gomock.Any(), "user1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, v models.EmailVerification) (bool, error) {
+		assert.Equal(t, "akhil@example.com", v.Email)
+		return true, nil
+	}).Times(1)
+	sameEmail := "AKHIL@example.com"
+	assert.NoError(t, userUseCase.UpdateProfile(context.Background(), "user1", models.UpdateProfile{Email: &sameEmail}))
+	if assert.Len(t, mail.sent, 1) {
+		assert.Equal(t, "akhil@example.com", mail.sent[0].to)
+	}
+
+	userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(models.UserDetails{
+		ID:                "user1",
+		Email:             "akhil@example.com",
+		EmailVerification: &models.EmailVerification{Email: "akhil@example.com"},
+	}, nil).Times(1)
+	userRepo.EXPECT().ConfirmEmail(gomock.Any(), "user1", gomock.Any()).Return(true, nil).Times(1)
+	assert.NoError(t, userUseCase.VerifyEmail(context.Background(), "user1", models.VerifyEmail{Token: "code"}))
+}
+
+func Test_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	userUseCase := usecase.NewUserUseCase(userRepo, nil, nil, nil, nil, nil, testHasher)
+
+	pending := models.UserDetails{ID: "user1", EmailVerification: &models.EmailVerification{Email: "new@example.com"}}
+
+	testData := map[string]struct {
+		stub    func(*mockRepository.MockUserRepository)
+		wantErr error
+	}{
+		"success": {
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(pending, nil).Times(1)
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), "new@example.com").Return(false, nil).Times(1)
+				userRepo.EXPECT().ConfirmEmail(gomock.Any(), "user1", gomock.Any()).Return(true, nil).Times(1)
+			},
+		},
+		"nothing pending": {
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(models.UserDetails{ID: "user1"}, nil).Times(1)
+			},
+			wantErr: services.Conflict("no_pending_email", "no email change pending"),
+		},
+		"email taken meanwhile": {
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(pending, nil).Times(1)
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), "new@example.com").Return(true, nil).Times(1)
+			},
+			wantErr: services.Conflict("email_taken", "user with this email is already exists"),
+		},
+		"wrong or expired token": {
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(pending, nil).Times(1)
+				userRepo.EXPECT().CheckUserExistsByEmail(gomock.Any(), "new@example.com").Return(false, nil).Times(1)
+				userRepo.EXPECT().ConfirmEmail(gomock.Any(), "user1", gomock.Any()).Return(false, nil).Times(1)
+			},
+			wantErr: services.Validation("invalid_verification_token", "invalid or expired verification token"),
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			test.stub(userRepo)
+			err := userUseCase.VerifyEmail(context.Background(), "user1", models.VerifyEmail{Token: "code"})
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+func Test_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mockRepository.NewMockUserRepository(ctrl)
+	sessionRepo := mockRepository.NewMockSessionRepository(ctrl)
+	tokenRepo := mockRepository.NewMockTokenRepository(ctrl)
+	userUseCase := usecase.NewUserUseCase(userRepo, nil, sessionRepo, tokenRepo, nil, &password.Policy{MinLength: 8, DisallowPersonal: true}, &password.Hasher{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost})
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("oldpass"), bcrypt.MinCost)
 	assert.NoError(t, err)
-	assert.Equal(t, "validToken", token)
+	user := models.UserDetails{ID: "user1", Email: "akhil@example.com", Password: string(hash)}
+
+	testData := map[string]struct {
+		current   string
+		new       string
+		user      models.UserDetails
+		stub      func(*mockRepository.MockUserRepository)
+		wantToken string
+		wantErr   error
+	}{
+		"success": {
+			current: "oldpass",
+			new:     "newpass123",
+			user:    user,
+			stub: func(userRepo *mockRepository.MockUserRepository) {
+				userRepo.EXPECT().UpdatePassword(gomock.Any(), "user1", gomock.Any()).DoAndReturn(func(_ context.Context, _, hashed string) (bool, error) {
+					assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hashed), []byte("newpass123")))
+					return true, nil
+				}).Times(1)
+				tokenRepo.EXPECT().DeleteTokensByUser(gomock.Any(), "user1").Return(nil).Times(1)
+				sessionRepo.EXPECT().DeleteSessionsByUser(gomock.Any(), "user1").Return(nil).Times(1)
+				userRepo.EXPECT().GenerateJwtToken(gomock.Any(), gomock.Any()).Return("token", time.Now().Add(time.Hour), nil).Times(1)
+				sessionRepo.EXPECT().InsertSession(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			wantToken: "token",
+		},
+		"new password contains email": {
+			current: "oldpass",
+			new:     "akhil@example.com",
+			user:    user,
+			stub:    func(userRepo *mockRepository.MockUserRepository) {},
+			wantErr: fmt.Errorf("%w: %v", services.ErrWeakPassword, "password must not contain your name or email"),
+		},
+		"wrong current password": {
+			current: "wrong",
+			new:     "newpass123",
+			user:    user,
+			stub:    func(userRepo *mockRepository.MockUserRepository) {},
+			wantErr: services.ErrInvalidCredentials,
+		},
+		"account without password": {
+			current: "oldpass",
+			new:     "newpass123",
+			user:    models.UserDetails{ID: "user1"},
+			stub:    func(userRepo *mockRepository.MockUserRepository) {},
+			wantErr: services.ErrInvalidCredentials,
+		},
+	}
+
+	for testName, test := range testData {
+		t.Run(testName, func(t *testing.T) {
+			userRepo.EXPECT().FindUserByID(gomock.Any(), "user1").Return(test.user, nil).Times(1)
+			test.stub(userRepo)
+			token, err := userUseCase.ChangePassword(context.Background(), "user1", models.ChangePassword{CurrentPassword: test.current, NewPassword: test.new}, models.ClientInfo{})
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantToken, token)
+		})
+	}
 }
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	ExportFormatJSON = "json"
+	ExportFormatZIP  = "zip"
+)
+
+type UserExport struct {
+	ExportedAt  time.Time      `json:"exported_at"`
+	Profile     UserProfile    `json:"profile"`
+	Preferences Preferences    `json:"preferences"`
+	Tasks       []TaskDetails  `json:"tasks"`
+	Tokens      []TokenDetails `json:"tokens"`
+}
+
+type DeleteAccount struct {
+	Password string `json:"password"`
+}
+
+// UserTombstone records that an account existed and was deleted without
+// keeping any of its personal data.
+type UserTombstone struct {
+	UserID        string    `bson:"user_id"`
+	DeletedAt     time.Time `bson:"deleted_at"`
+	TasksDeleted  int64     `bson:"tasks_deleted"`
+	TokensDeleted int64     `bson:"tokens_deleted"`
+}
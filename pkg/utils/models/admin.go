@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	AuditListUsers     = "list_users"
+	AuditDisableUser   = "disable_user"
+	AuditEnableUser    = "enable_user"
+	AuditResetPassword = "reset_password"
+	AuditUpdateRole    = "update_role"
+	AuditViewTasks     = "view_tasks"
+)
+
+// An audit entry is written as pending before the action it records and
+// updated once the action has ended. One left pending means the outcome is
+// unknown, for example because the server stopped in between.
+const (
+	AuditPending   = "pending"
+	AuditSucceeded = "succeeded"
+	AuditFailed    = "failed"
+)
+
+type UserFilter struct {
+	Search string
+	Page   int
+	Limit  int
+}
+
+type AuditLog struct {
+	ID        string    `bson:"_id,omitempty" json:"id"`
+	ActorID   string    `bson:"actor_id" json:"actor_id"`
+	Action    string    `bson:"action" json:"action"`
+	TargetID  string    `bson:"target_id,omitempty" json:"target_id,omitempty"`
+	Details   string    `bson:"details,omitempty" json:"details,omitempty"`
+	Outcome   string    `bson:"outcome,omitempty" json:"outcome,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type LoginAttempt struct {
+	Key         string    `bson:"key"`
+	Failures    int       `bson:"failures"`
+	LockedUntil time.Time `bson:"locked_until,omitempty"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// Readiness is what /readyz reports. Checks maps MongoDB and each background
+// worker to "ok" or to what is wrong with it.
+type Readiness struct {
+	Ready  bool              `json:"ready"`
+	Checks map[string]string `json:"checks"`
+}
+
+type BuildInfo struct {
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
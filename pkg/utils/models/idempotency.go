@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// IdempotencyRecord holds the response to the first request made with an
+// idempotency key. Status is zero while that request is still running.
+type IdempotencyRecord struct {
+	Fingerprint string    `bson:"fingerprint"`
+	Status      int       `bson:"status"`
+	ContentType string    `bson:"content_type,omitempty"`
+	Body        []byte    `bson:"body,omitempty"`
+	ExpiresAt   time.Time `bson:"expires_at"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type SigningKey struct {
+	KID        string     `bson:"kid"`
+	Algorithm  string     `bson:"alg"`
+	PrivateKey string     `bson:"private_key"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	RetiredAt  *time.Time `bson:"retired_at,omitempty"`
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
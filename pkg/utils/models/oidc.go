@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type OIDCState struct {
+	State     string    `bson:"state"`
+	Verifier  string    `bson:"verifier"`
+	Nonce     string    `bson:"nonce"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// OIDCLogin is where to send the browser to sign in, and the state it must
+// come back with before ExpiresAt.
+type OIDCLogin struct {
+	URL       string
+	State     string
+	ExpiresAt time.Time
+}
+
+type OIDCUser struct {
+	Name    string
+	Email   string
+	Issuer  string
+	Subject string
+}
@@ -0,0 +1,21 @@
+package models
+
+type Preferences struct {
+	Timezone       string `json:"timezone" bson:"timezone" validate:"required"`
+	Locale         string `json:"locale" bson:"locale" validate:"required"`
+	WeekStart      string `json:"week_start" bson:"week_start" validate:"required,oneof=monday sunday saturday"`
+	DefaultSort    string `json:"default_sort" bson:"default_sort" validate:"required,oneof=created_at -created_at title -title"`
+	PageSize       int    `json:"page_size" bson:"page_size" validate:"required,min=1,max=100"`
+	DefaultProject string `json:"default_project" bson:"default_project" validate:"max=100"`
+}
+
+// DefaultPreferences applies to users who have never saved preferences.
+func DefaultPreferences() Preferences {
+	return Preferences{
+		Timezone:    "UTC",
+		Locale:      "en",
+		WeekStart:   "monday",
+		DefaultSort: "-created_at",
+		PageSize:    20,
+	}
+}
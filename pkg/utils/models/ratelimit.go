@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// RateLimit is a token bucket holding up to Burst requests and refilled with
+// one every Every.
+type RateLimit struct {
+	Burst int
+	Every time.Duration
+}
+
+// PerMinute builds a limit that sustains perMinute requests with bursts of up
+// to burst. A zero rate disables the limit.
+func PerMinute(burst, perMinute int) RateLimit {
+	if perMinute <= 0 {
+		return RateLimit{}
+	}
+	return RateLimit{Burst: burst, Every: time.Minute / time.Duration(perMinute)}
+}
+
+func (l RateLimit) Enabled() bool {
+	return l.Every > 0 && l.Burst > 0
+}
+
+// RateLimitResult describes a bucket after taking one request from it. Reset
+// is how long until the bucket is full again and RetryAfter, for rejected
+// requests, how long until the next token.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// ClientInfo describes the device a sign-in came from.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type NewSession struct {
+	JTI       string
+	UserID    string
+	UserAgent string
+	IP        string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type Session struct {
+	ID         string    `bson:"_id" json:"id"`
+	JTI        string    `bson:"jti" json:"-"`
+	UserID     string    `bson:"user_id" json:"-"`
+	UserAgent  string    `bson:"user_agent" json:"user_agent"`
+	IP         string    `bson:"ip" json:"ip"`
+	CreatedAt  time.Time `bson:"created_at" json:"created_at"`
+	LastSeenAt time.Time `bson:"last_seen_at" json:"last_seen_at"`
+	ExpiresAt  time.Time `bson:"expires_at" json:"expires_at"`
+	Current    bool      `bson:"-" json:"current"`
+}
@@ -2,14 +2,97 @@ package models
 
 import "time"
 
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+const (
+	BulkCreate = "create"
+	BulkUpdate = "update"
+	BulkDelete = "delete"
+)
+
 type CreateTask struct {
 	Title       string `json:"title" validate:"required,min=1,max=100"`
 	Description string `json:"description" validate:"required,min=1,max=1000"`
+	Project     string `json:"project" validate:"max=100"`
+}
+
+// TaskQuery holds the listing parameters; zero values are filled in from the
+// user's preferences.
+type TaskQuery struct {
+	Sort    string `query:"sort" validate:"omitempty,oneof=created_at -created_at title -title"`
+	Page    int    `query:"page" validate:"omitempty,min=1"`
+	Limit   int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Project string `query:"project" validate:"max=100"`
+}
+
+type CreatedTask struct {
+	ID string `json:"id"`
 }
 
 type TaskDetails struct {
 	ID          string    `bson:"_id"`
 	Title       string    `bson:"title"`
 	Description string    `bson:"description"`
+	Project     string    `bson:"project,omitempty"`
 	CreatedAt   time.Time `bson:"created_at"`
 }
+
+// TaskPatch is a partial update in either patch format. It is applied to the
+// task's CreateTask representation.
+type TaskPatch struct {
+	ContentType string
+	Patch       []byte
+}
+
+// TaskChanges lists the fields a patch changed; only these are written.
+// Expected is the task the patch was applied to, and the write only goes
+// through while the stored task still matches it.
+type TaskChanges struct {
+	Set      map[string]string
+	Unset    []string
+	Expected CreateTask
+}
+
+// BulkTaskRequest runs up to 100 operations. Ordered, the default, stops at
+// the first failure like a Mongo ordered bulk write; unordered runs every
+// valid operation.
+type BulkTaskRequest struct {
+	Ordered    *bool               `json:"ordered"`
+	Operations []BulkTaskOperation `json:"operations" validate:"required,min=1,max=100"`
+}
+
+// BulkTaskOperation is validated on its own so that one bad item fails only
+// itself. ID is required for update and delete, Task for create and update.
+type BulkTaskOperation struct {
+	Op   string      `json:"op" validate:"required,oneof=create update delete"`
+	ID   string      `json:"id"`
+	Task *CreateTask `json:"task"`
+}
+
+// BulkTaskResult reports one operation, at the same index as the request.
+// Err is filled in by the usecase; the handler turns it into Status and Error.
+type BulkTaskResult struct {
+	Index  int      `json:"index"`
+	Op     string   `json:"op"`
+	Status int      `json:"status"`
+	ID     string   `json:"id,omitempty"`
+	Error  *Problem `json:"error,omitempty"`
+	Err    error    `json:"-"`
+}
+
+// TaskWrite is one validated operation handed to the repository.
+type TaskWrite struct {
+	Op   string
+	ID   string
+	Task CreateTask
+}
+
+// TaskWriteResult is the outcome of a TaskWrite; ID is set for every write
+// and is the new task's ID for creates.
+type TaskWriteResult struct {
+	ID  string
+	Err error
+}
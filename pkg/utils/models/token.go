@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+const (
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+)
+
+type CreateToken struct {
+	Name          string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=tasks:read tasks:write"`
+	ExpiresInDays int      `json:"expires_in_days" validate:"omitempty,min=1,max=365"`
+}
+
+type NewToken struct {
+	UserID    string
+	Name      string
+	TokenHash string
+	Scopes    []string
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+}
+
+type TokenDetails struct {
+	ID        string     `bson:"_id" json:"id"`
+	Name      string     `bson:"name" json:"name"`
+	Scopes    []string   `bson:"scopes" json:"scopes"`
+	CreatedAt time.Time  `bson:"created_at" json:"created_at"`
+	ExpiresAt *time.Time `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+}
+
+type CreatedToken struct {
+	Token string `json:"token"`
+	TokenDetails
+}
+
+type TokenAuth struct {
+	UserID    string     `bson:"user_id"`
+	Scopes    []string   `bson:"scopes"`
+	ExpiresAt *time.Time `bson:"expires_at,omitempty"`
+}
@@ -1,14 +1,21 @@
 package models
 
+import "time"
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type UserSignup struct {
 	Name     string `json:"name" validate:"required,min=3"`
 	Email    string `json:"email" validate:"email"`
-	Password string `json:"password"  validate:"required,min=6,max=20"`
+	Password string `json:"password"  validate:"required"`
 }
 
 type UserSignIn struct {
 	Email    string `json:"email" validate:"email"`
-	Password string `json:"password" validate:"required,min=6,max=20"`
+	Password string `json:"password" validate:"required"`
 }
 
 type UserDetails struct {
@@ -16,4 +23,59 @@ type UserDetails struct {
 	Name     string `bson:"name"`
 	Email    string `bson:"email"`
 	Password string `bson:"password"`
+	Role     string `bson:"role,omitempty"`
+	Disabled bool   `bson:"disabled,omitempty"`
+	// EmailVerified is set once the owner has proven the address, by
+	// confirming it with the code sent to it or by creating the account
+	// through a provider that verified it. A sign-up alone does not set it.
+	EmailVerified bool `bson:"email_verified,omitempty"`
+
+	TokensValidAfter  time.Time          `bson:"tokens_valid_after,omitempty"`
+	EmailVerification *EmailVerification `bson:"email_verification,omitempty"`
+}
+
+type EmailVerification struct {
+	Email     string    `bson:"email"`
+	TokenHash string    `bson:"token_hash"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+type UserProfile struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Role         string `json:"role"`
+	PendingEmail string `json:"pending_email,omitempty"`
+
+	EmailVerified bool `json:"email_verified"`
+}
+
+type UpdateProfile struct {
+	Name  *string `json:"name" validate:"omitempty,min=3"`
+	Email *string `json:"email" validate:"omitempty,email"`
+}
+
+type VerifyEmail struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ChangePassword struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+type UserSummary struct {
+	ID       string `bson:"_id" json:"id"`
+	Name     string `bson:"name" json:"name"`
+	Email    string `bson:"email" json:"email"`
+	Role     string `bson:"role" json:"role"`
+	Disabled bool   `bson:"disabled" json:"disabled"`
+}
+
+type ResetPassword struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type UpdateRole struct {
+	Role string `json:"role" validate:"required,oneof=user admin"`
 }